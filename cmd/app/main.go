@@ -3,99 +3,215 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"pr-reviewer/internal/api"
+	authDelivery "pr-reviewer/internal/delivery/http/Auth"
+	healthDelivery "pr-reviewer/internal/delivery/http/Health"
+	ingestDelivery "pr-reviewer/internal/delivery/http/Ingest"
 	prDelivery "pr-reviewer/internal/delivery/http/PullRequest"
+	statsDelivery "pr-reviewer/internal/delivery/http/Stats"
 	teamDelivery "pr-reviewer/internal/delivery/http/Team"
 	userDelivery "pr-reviewer/internal/delivery/http/User"
+	webhookDelivery "pr-reviewer/internal/delivery/http/Webhook"
 	"pr-reviewer/internal/delivery/http/server"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/config"
 	"pr-reviewer/internal/pkg/db/postgres"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/metrics"
 	"pr-reviewer/internal/pkg/middleware"
+	"pr-reviewer/internal/pkg/tracing"
+	authRepo "pr-reviewer/internal/repository/Auth"
+	escalationRepo "pr-reviewer/internal/repository/Escalation"
+	healthRepo "pr-reviewer/internal/repository/Health"
+	ingestRepo "pr-reviewer/internal/repository/Ingest"
 	prRepo "pr-reviewer/internal/repository/PullRequest"
+	statsRepo "pr-reviewer/internal/repository/Stats"
 	teamRepo "pr-reviewer/internal/repository/Team"
 	userRepo "pr-reviewer/internal/repository/User"
+	webhookRepo "pr-reviewer/internal/repository/Webhook"
+	authUC "pr-reviewer/internal/usecase/Auth"
+	escalationUC "pr-reviewer/internal/usecase/Escalation"
+	healthUC "pr-reviewer/internal/usecase/Health"
+	ingestUC "pr-reviewer/internal/usecase/Ingest"
 	prUC "pr-reviewer/internal/usecase/PullRequest"
+	statsUC "pr-reviewer/internal/usecase/Stats"
 	teamUC "pr-reviewer/internal/usecase/Team"
 	userUC "pr-reviewer/internal/usecase/User"
+	webhookUC "pr-reviewer/internal/usecase/Webhook"
+	"pr-reviewer/migrations"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func init() {
-	dsn := os.Getenv("DB_URL")
-	if dsn == "" {
-		err := godotenv.Load("../../.env")
-		if err != nil {
-			log.Fatalf("failed to load env")
-		}
+func main() {
+	// Настройки: значения по умолчанию < YAML файл < окружение < флаги
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid config:\n%v", err)
 	}
-}
 
-func main() {
-	// Логгер
-	l, err := logger.NewZapLogger("warn")
+	l, err := logger.NewZapLogger(cfg.Log.Level)
 	if err != nil {
 		log.Fatalf("failed to make logger: %v", err)
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: "pr-reviewer",
+	})
+	if err != nil {
+		log.Fatalf("failed to setup tracing: %v", err)
+	}
+	// Накопленные спаны досылаются при любом исходе остановки сервера
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("failed to flush traces: %v", err)
+		}
+	}()
+
 	// Инициализация подключения к БД
-	pool, err := postgres.NewPool()
+	pool, err := postgres.NewPool(postgres.Options{
+		URL:             cfg.DB.URL,
+		MaxConns:        cfg.DB.MaxConns,
+		MinConns:        cfg.DB.MinConns,
+		ConnectTimeout:  cfg.DB.ConnectTimeout,
+		MaxConnLifetime: cfg.DB.MaxConnLifetime,
+		MaxConnIdleTime: cfg.DB.MaxConnIdleTime,
+	})
 	if err != nil {
 		log.Fatalf("failed to connect db: %v", err)
 	}
 	defer pool.Close()
+	prometheus.MustRegister(metrics.NewPoolCollector(pool))
+
+	// PullRequest
+	userRepo := userRepo.NewUserRepository(pool, l)
+	prRepo := prRepo.NewPullRequestRepository(pool, l)
+	prUC := prUC.NewPullRequestUsecase(prRepo, userRepo, l)
+	prHandler := prDelivery.NewPRHandler(prUC)
 
 	// Team
 	teamRepo := teamRepo.NewTeamRepository(pool, l)
-	teamUC := teamUC.NewTeamUsecase(teamRepo, l)
+	teamUC := teamUC.NewTeamUsecase(teamRepo, prUC, l)
 	teamHandler := teamDelivery.NewTeamHandler(teamUC)
 
 	// User
-	userRepo := userRepo.NewUserRepository(pool)
-	userUC := userUC.NewUserUsecase(userRepo, l)
+	userUC := userUC.NewUserUsecase(userRepo, prUC, l)
 	userHandler := userDelivery.NewUserHandler(userUC)
 
-	// PullRequest
-	prRepo := prRepo.NewPullRequestRepository(pool, l)
-	prUC := prUC.NewPullRequestUsecase(prRepo, userRepo, l)
-	prHandler := prDelivery.NewPRHandler(prUC)
+	// Stats
+	statsRepo := statsRepo.NewStatsRepository(pool)
+	statsUC := statsUC.NewStatsUsecase(statsRepo, l)
+	statsHandler := statsDelivery.NewStatsHandler(statsUC)
+
+	// Webhook
+	webhookRepo := webhookRepo.NewWebhookRepository(pool, l)
+	dispatcher := webhookUC.NewDispatcher(webhookRepo, l)
+	webhookUC := webhookUC.NewWebhookUsecase(webhookRepo, l)
+	webhookHandler := webhookDelivery.NewWebhookHandler(webhookUC)
+
+	// Прием событий GitHub/GitLab
+	externalUsers, err := domain.ParseExternalUserMap(cfg.Ingest.UserMap)
+	if err != nil {
+		log.Fatalf("failed to parse INGEST_USER_MAP: %v", err)
+	}
+	ingestRepo := ingestRepo.NewIngestRepository(pool)
+	ingestUC := ingestUC.NewIngestUsecase(ingestRepo, prUC, domain.IngestConfig{
+		GitHubSecret: cfg.Ingest.GitHubWebhookSecret,
+		GitLabToken:  cfg.Ingest.GitLabWebhookToken,
+		Users:        externalUsers,
+	}, l)
+	ingestHandler := ingestDelivery.NewIngestHandler(ingestUC)
+
+	// Токены доступа
+	authRepo := authRepo.NewAuthRepository(pool)
+	authUC := authUC.NewAuthUsecase(authRepo, cfg.Auth.AdminToken, l)
+	authHandler := authDelivery.NewAuthHandler(authUC)
+
+	// Эскалация просроченных ревью
+	escalationRepo := escalationRepo.NewEscalationRepository(pool, l)
+	scheduler := escalationUC.NewScheduler(escalationRepo, prUC, l)
+
+	// Проверки живости и готовности
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		log.Fatalf("failed to get schema version: %v", err)
+	}
+	healthRepo := healthRepo.NewHealthRepository(pool)
+	healthUC := healthUC.NewHealthUsecase(healthRepo, schemaVersion, l)
+	healthHandler := healthDelivery.NewHealthHandler(healthUC)
+
+	// Доставка событий из outbox и эскалация ревью до остановки сервиса
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	defer stopDispatch()
+	go dispatcher.Run(dispatchCtx)
+	go scheduler.Run(dispatchCtx)
 
 	// Композиция handlers
-	server := server.NewServer(userHandler, teamHandler, prHandler)
+	server := server.NewServer(
+		userHandler, teamHandler, prHandler, statsHandler, webhookHandler, ingestHandler, authHandler, healthHandler,
+	)
 
 	r := mux.NewRouter()
+	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
 	h := api.HandlerWithOptions(server, api.GorillaServerOptions{
-		BaseRouter:  r,
-		Middlewares: []api.MiddlewareFunc{middleware.RecoverMiddleware},
+		BaseRouter: r,
+		Middlewares: []api.MiddlewareFunc{
+			middleware.AuthMiddleware(authUC), middleware.RecoverMiddleware(l),
+			middleware.MetricsMiddleware, middleware.RequestLoggerMiddleware(l), middleware.TracingMiddleware,
+		},
 	})
 
-	addr := ":8080"
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: h,
+		Addr:              cfg.HTTP.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
 	}
 
 	// Канал для ловли сигналов остановки
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		log.Println("server started at", addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Println("server started at", cfg.HTTP.Addr, "tls:", cfg.HTTP.TLSEnabled())
+		var err error
+		if cfg.HTTP.TLSEnabled() {
+			err = srv.ListenAndServeTLS(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("failed to start server: %v", err)
 		}
 	}()
 
 	<-stop
 	log.Println("shutting down server...")
+	// Сначала /readyz перестает отвечать готовностью, затем закрывается сервер:
+	// за DrainDelay балансировщик успевает перестать направлять запросы
+	healthUC.Drain()
+	time.Sleep(cfg.HTTP.DrainDelay)
+	stopDispatch()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
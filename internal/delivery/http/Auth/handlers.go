@@ -0,0 +1,82 @@
+// Package auth содержит handlers для токенов доступа
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+	"pr-reviewer/internal/pkg/validation"
+)
+
+// AuthHandler Handler для токенов доступа
+type AuthHandler struct {
+	uc authUC
+}
+
+func NewAuthHandler(uc authUC) *AuthHandler {
+	return &AuthHandler{
+		uc: uc,
+	}
+}
+
+func (h *AuthHandler) PostAuthTokenIssue(w http.ResponseWriter, r *http.Request) {
+	var req api.PostAuthTokenIssueJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateIssueToken(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	issued, err := h.uc.IssueToken(r.Context(), domain.APIToDomainIssueToken(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.IssuedTokenResponse{Token: issued.Token, ApiToken: domain.DomainApiTokenToAPI(issued.ApiToken)}
+
+	response.SendResponse(w, http.StatusCreated, resp)
+}
+
+func (h *AuthHandler) PostAuthTokenRevoke(w http.ResponseWriter, r *http.Request) {
+	var req api.PostAuthTokenRevokeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateTokenId(req.TokenId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := h.uc.RevokeToken(r.Context(), domain.APIToDomainTokenID(req.TokenId))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.TokenResponse{ApiToken: domain.DomainApiTokenToAPI(revoked)}
+
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
+func (h *AuthHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrTokenNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	default:
+		return api.INTERNAL, http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/delivery/http/Auth/mocks"
+	"pr-reviewer/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostAuthTokenIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockauthUC(ctrl)
+	handler := NewAuthHandler(usecase)
+
+	t.Run("issued", func(t *testing.T) {
+		userID := 2
+		usecase.EXPECT().IssueToken(gomock.Any(), &domain.IssueToken{Role: domain.RoleUser, UserID: &userID}).
+			Return(&domain.IssuedToken{
+				Token:    "secret-token",
+				ApiToken: &domain.ApiToken{ID: 4, Role: domain.RoleUser, UserID: &userID, Hash: "hash", CreatedAt: time.Now()},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/issue", bytes.NewBufferString(`{"role":"user","user_id":"u2"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenIssue(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var resp domain.IssuedTokenResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret-token", resp.Token)
+		assert.Equal(t, "tok-4", resp.ApiToken.TokenId)
+		assert.Equal(t, "u2", *resp.ApiToken.UserId)
+		assert.NotContains(t, rec.Body.String(), "hash")
+	})
+
+	t.Run("user role without user_id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/issue", bytes.NewBufferString(`{"role":"user"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenIssue(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		usecase.EXPECT().IssueToken(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUserNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/issue", bytes.NewBufferString(`{"role":"user","user_id":"u9"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenIssue(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostAuthTokenRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockauthUC(ctrl)
+	handler := NewAuthHandler(usecase)
+
+	t.Run("revoked", func(t *testing.T) {
+		at := time.Now()
+		usecase.EXPECT().RevokeToken(gomock.Any(), 4).
+			Return(&domain.ApiToken{ID: 4, Role: domain.RoleAdmin, CreatedAt: at, RevokedAt: &at}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/revoke", bytes.NewBufferString(`{"token_id":"tok-4"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenRevoke(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.TokenResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.ApiToken.RevokedAt)
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/revoke", bytes.NewBufferString(`{"token_id":"4"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenRevoke(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		usecase.EXPECT().RevokeToken(gomock.Any(), 8).Return(nil, domain.ErrTokenNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/token/revoke", bytes.NewBufferString(`{"token_id":"tok-8"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostAuthTokenRevoke(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source usecase_interface.go -destination=mocks/mock_auth_usecase.go -package=mocks
+
+type authUC interface {
+	IssueToken(ctx context.Context, issue *domain.IssueToken) (*domain.IssuedToken, error)
+	RevokeToken(ctx context.Context, id int) (*domain.ApiToken, error)
+}
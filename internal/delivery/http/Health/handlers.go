@@ -0,0 +1,38 @@
+// Package health содержит handlers проверок живости, готовности и версии сборки
+package health
+
+import (
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+)
+
+type HealthHandler struct {
+	uc healthUC
+}
+
+func NewHealthHandler(uc healthUC) *HealthHandler {
+	return &HealthHandler{
+		uc: uc,
+	}
+}
+
+// GetHealthz отвечает, пока процесс обслуживает запросы, и не зависит от БД
+func (h *HealthHandler) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	response.SendResponse(w, http.StatusOK, api.HealthStatus{Status: "ok"})
+}
+
+func (h *HealthHandler) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.uc.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	response.SendResponse(w, status, domain.DomainReadinessToAPI(readiness))
+}
+
+func (h *HealthHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	response.SendResponse(w, http.StatusOK, domain.DomainBuildInfoToAPI(h.uc.BuildInfo()))
+}
@@ -0,0 +1,102 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/delivery/http/Health/mocks"
+	"pr-reviewer/internal/domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHealthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewHealthHandler(mocks.NewMockhealthUC(ctrl))
+
+	rec := httptest.NewRecorder()
+	handler.GetHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestGetReadyz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockhealthUC(ctrl)
+	handler := NewHealthHandler(usecase)
+
+	t.Run("ready", func(t *testing.T) {
+		usecase.EXPECT().Readiness(gomock.Any()).Return(&domain.Readiness{Checks: []domain.ReadinessCheck{
+			{Name: domain.ReadinessDatabase},
+			{Name: domain.ReadinessMigrations},
+		}})
+
+		rec := httptest.NewRecorder()
+		handler.GetReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp api.Readiness
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.Ready, resp.Status)
+		assert.Len(t, resp.Checks, 2)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		usecase.EXPECT().Readiness(gomock.Any()).Return(&domain.Readiness{Checks: []domain.ReadinessCheck{
+			{Name: domain.ReadinessShutdown, Err: domain.ErrShuttingDown},
+		}})
+
+		rec := httptest.NewRecorder()
+		handler.GetReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		var resp api.Readiness
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.NotReady, resp.Status)
+		assert.False(t, resp.Checks[0].Ok)
+		assert.Equal(t, domain.ErrShuttingDown.Error(), *resp.Checks[0].Error)
+	})
+
+	t.Run("raw errors are not exposed", func(t *testing.T) {
+		usecase.EXPECT().Readiness(gomock.Any()).Return(&domain.Readiness{Checks: []domain.ReadinessCheck{
+			{Name: domain.ReadinessDatabase, Err: fmt.Errorf("dial tcp 10.0.0.5:5432: connection refused")},
+			{Name: domain.ReadinessMigrations, Err: fmt.Errorf("%w: version 17", domain.ErrSchemaOutdated)},
+		}})
+
+		rec := httptest.NewRecorder()
+		handler.GetReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		var resp api.Readiness
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "check failed", *resp.Checks[0].Error)
+		assert.Equal(t, domain.ErrSchemaOutdated.Error(), *resp.Checks[1].Error)
+		assert.NotContains(t, rec.Body.String(), "10.0.0.5")
+	})
+}
+
+func TestGetVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockhealthUC(ctrl)
+	handler := NewHealthHandler(usecase)
+
+	usecase.EXPECT().BuildInfo().Return(domain.BuildInfo{Commit: "abc123", BuildTime: "2026-10-17T10:00:00Z", GoVersion: "go1.25.4"})
+
+	rec := httptest.NewRecorder()
+	handler.GetVersion(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"commit":"abc123","build_time":"2026-10-17T10:00:00Z","go_version":"go1.25.4"}`, rec.Body.String())
+}
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source usecase_interface.go -destination=mocks/mock_health_usecase.go -package=mocks
+
+type healthUC interface {
+	Readiness(ctx context.Context) *domain.Readiness
+	BuildInfo() domain.BuildInfo
+}
@@ -0,0 +1,91 @@
+// Package ingest содержит handlers для событий GitHub и GitLab
+package ingest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+)
+
+// maxPayloadSize ограничение на размер тела события
+const maxPayloadSize = 1 << 20
+
+// IngestHandler Handler для событий внешних сервисов
+type IngestHandler struct {
+	uc ingestUC
+}
+
+func NewIngestHandler(uc ingestUC) *IngestHandler {
+	return &IngestHandler{
+		uc: uc,
+	}
+}
+
+func (h *IngestHandler) PostIngestGithub(w http.ResponseWriter, r *http.Request, params api.PostIngestGithubParams) {
+	var signature string
+	if params.XHubSignature256 != nil {
+		signature = *params.XHubSignature256
+	}
+	h.ingest(w, r, domain.ProviderGitHub, params.XGitHubEvent, signature)
+}
+
+func (h *IngestHandler) PostIngestGitlab(w http.ResponseWriter, r *http.Request, params api.PostIngestGitlabParams) {
+	var token string
+	if params.XGitlabToken != nil {
+		token = *params.XGitlabToken
+	}
+	h.ingest(w, r, domain.ProviderGitLab, params.XGitlabEvent, token)
+}
+
+func (h *IngestHandler) ingest(
+	w http.ResponseWriter, r *http.Request, provider domain.ExternalProvider, event string, signature string,
+) {
+	// Подпись считается по сырому телу, поэтому оно читается целиком
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.uc.Ingest(r.Context(), &domain.IngestDelivery{
+		Provider:  provider,
+		Event:     event,
+		Signature: signature,
+		Body:      body,
+	})
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainIngestResultToAPI(res))
+}
+
+func (h *IngestHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSignature):
+		return api.INVALIDSIGNATURE, http.StatusUnauthorized
+	case errors.Is(err, domain.ErrInvalidPayload):
+		return api.BADREQUEST, http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnknownExternalUser):
+		return api.UNKNOWNUSER, http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrUserNotFound):
+		return api.UNKNOWNUSER, http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrPullRequestNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrPullRequestExists):
+		return api.PREXISTS, http.StatusConflict
+	case errors.Is(err, domain.ErrNotEnoughReviewers):
+		return api.NOTENOUGHREVIEWERS, http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return api.INVALIDTRANSITION, http.StatusConflict
+	case errors.Is(err, domain.ErrNotApproved):
+		return api.NOTAPPROVED, http.StatusConflict
+	default:
+		return api.INTERNAL, http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,113 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/delivery/http/Ingest/mocks"
+	"pr-reviewer/internal/domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostIngestGithub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockingestUC(ctrl)
+	handler := NewIngestHandler(usecase)
+
+	body := []byte(`{"action":"opened"}`)
+	signature := "sha256=abc"
+	params := api.PostIngestGithubParams{XGitHubEvent: "pull_request", XHubSignature256: &signature}
+
+	t.Run("created", func(t *testing.T) {
+		usecase.EXPECT().Ingest(gomock.Any(), &domain.IngestDelivery{
+			Provider:  domain.ProviderGitHub,
+			Event:     "pull_request",
+			Signature: signature,
+			Body:      body,
+		}).Return(&domain.IngestResult{
+			Outcome:     domain.IngestCreated,
+			PullRequest: &domain.PullRequest{ID: 5, AuthorID: 1, Name: "Add search", Status: domain.PRStatusOpen},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/ingest/github", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostIngestGithub(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp api.IngestResult
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.Created, resp.Action)
+		assert.Equal(t, "pr-5", resp.Pr.PullRequestId)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		usecase.EXPECT().Ingest(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInvalidSignature)
+
+		req := httptest.NewRequest(http.MethodPost, "/ingest/github", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostIngestGithub(rec, req, api.PostIngestGithubParams{XGitHubEvent: "pull_request"})
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unknown author", func(t *testing.T) {
+		usecase.EXPECT().Ingest(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUnknownExternalUser)
+
+		req := httptest.NewRequest(http.MethodPost, "/ingest/github", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostIngestGithub(rec, req, params)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestPostIngestGitlab(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockingestUC(ctrl)
+	handler := NewIngestHandler(usecase)
+
+	token := "0123456789abcdef"
+	params := api.PostIngestGitlabParams{XGitlabEvent: "Merge Request Hook", XGitlabToken: &token}
+
+	t.Run("ignored", func(t *testing.T) {
+		usecase.EXPECT().Ingest(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, d *domain.IngestDelivery) (*domain.IngestResult, error) {
+				assert.Equal(t, domain.ProviderGitLab, d.Provider)
+				assert.Equal(t, token, d.Signature)
+				return &domain.IngestResult{Outcome: domain.IngestIgnored}, nil
+			},
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/ingest/gitlab", bytes.NewBufferString(`{}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostIngestGitlab(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"action":"ignored"}`, rec.Body.String())
+	})
+
+	t.Run("merge not approved", func(t *testing.T) {
+		usecase.EXPECT().Ingest(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotApproved)
+
+		req := httptest.NewRequest(http.MethodPost, "/ingest/gitlab", bytes.NewBufferString(`{}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostIngestGitlab(rec, req, params)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
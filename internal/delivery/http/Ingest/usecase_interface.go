@@ -0,0 +1,12 @@
+package ingest
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source usecase_interface.go -destination=mocks/mock_ingest_usecase.go -package=mocks
+
+type ingestUC interface {
+	Ingest(ctx context.Context, d *domain.IngestDelivery) (*domain.IngestResult, error)
+}
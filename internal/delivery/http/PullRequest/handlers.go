@@ -2,6 +2,7 @@
 package pullrequest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -66,6 +67,12 @@ func (h *PRHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request)
 	prID, _ := strconv.Atoi(req.PullRequestId[3:])
 
 	mergedPR, err := h.uc.MergePullRequest(r.Context(), prID)
+	var notApproved *domain.NotApprovedError
+	if errors.As(err, &notApproved) {
+		details := domain.NotApprovedErrorToAPI(notApproved)
+		response.SendErrorResponseWithDetails(w, api.NOTAPPROVED, http.StatusConflict, details)
+		return
+	}
 	if err != nil {
 		code, status := h.mapDomainErrorToAPI(err)
 		response.SendErrorResponse(w, code, status)
@@ -78,6 +85,88 @@ func (h *PRHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request)
 	response.SendResponse(w, http.StatusOK, resp)
 }
 
+func (h *PRHandler) PostPullRequestReady(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReadyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	h.changeStatus(w, r, req.PullRequestId, h.uc.MarkReady)
+}
+
+func (h *PRHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestCloseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	h.changeStatus(w, r, req.PullRequestId, h.uc.ClosePullRequest)
+}
+
+func (h *PRHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReopenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	h.changeStatus(w, r, req.PullRequestId, h.uc.ReopenPullRequest)
+}
+
+// changeStatus валидирует id PullRequest, вызывает переход статуса и отправляет PR
+func (h *PRHandler) changeStatus(
+	w http.ResponseWriter, r *http.Request, id string,
+	change func(ctx context.Context, prID int) (*domain.PullRequest, error),
+) {
+	if err := validation.ValidatePRId(id); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	prID, _ := strconv.Atoi(id[3:])
+
+	pr, err := change(r.Context(), prID)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	prAPI := domain.DomainPRToAPI(pr)
+	resp := domain.PullRequestResponse{PullRequest: prAPI}
+
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
+func (h *PRHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateReview(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	sr := domain.APIToDomainSubmitReview(req)
+
+	reviewedPR, err := h.uc.SubmitReview(r.Context(), sr)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	prAPI := domain.DomainPRToAPI(reviewedPR)
+	resp := domain.PullRequestResponse{PullRequest: prAPI}
+
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
 func (h *PRHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
 	var req api.PostPullRequestReassignJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -114,10 +203,70 @@ func (h *PRHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Reque
 	response.SendResponse(w, http.StatusOK, resp)
 }
 
+func (h *PRHandler) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	if err := validation.ValidatePRId(params.PullRequestId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	prID, _ := strconv.Atoi(params.PullRequestId[3:])
+
+	pr, err := h.uc.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.PullRequestResponse{PullRequest: domain.DomainPRDetailsToAPI(pr)}
+	response.SendResponseWithETag(w, params.IfNoneMatch, resp)
+}
+
+func (h *PRHandler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params api.GetPullRequestHistoryParams) {
+	if err := validation.ValidatePRId(params.PullRequestId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	prID, _ := strconv.Atoi(params.PullRequestId[3:])
+	events, err := h.uc.GetHistory(r.Context(), prID)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainPullRequestHistoryToAPI(prID, events))
+}
+
+func (h *PRHandler) GetPullRequestList(w http.ResponseWriter, r *http.Request, params api.GetPullRequestListParams) {
+	if err := validation.ValidatePullRequestFilter(params); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	filter, err := domain.APIToDomainPullRequestFilter(params)
+	if err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.uc.ListPullRequests(r.Context(), filter)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainPullRequestPageToAPI(page))
+}
+
 func (h *PRHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
 	switch {
 	case errors.Is(err, domain.ErrUserNotFound):
 		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return api.NOTFOUND, http.StatusNotFound
 	case errors.Is(err, domain.ErrPullRequestExists):
 		return api.PREXISTS, http.StatusConflict
 	case errors.Is(err, domain.ErrPullRequestNotFound):
@@ -128,6 +277,14 @@ func (h *PRHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode,
 		return api.PRMERGED, http.StatusConflict
 	case errors.Is(err, domain.ErrNotAssigned):
 		return api.NOTASSIGNED, http.StatusConflict
+	case errors.Is(err, domain.ErrNotEnoughReviewers):
+		return api.NOTENOUGHREVIEWERS, http.StatusConflict
+	case errors.Is(err, domain.ErrPullRequestIsClosed):
+		return api.PRCLOSED, http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return api.INVALIDTRANSITION, http.StatusConflict
+	case errors.Is(err, domain.ErrNotApproved):
+		return api.NOTAPPROVED, http.StatusConflict
 	default:
 		return api.INTERNAL, http.StatusInternalServerError
 	}
@@ -3,12 +3,14 @@ package pullrequest
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"pr-reviewer/internal/api"
 	"pr-reviewer/internal/delivery/http/PullRequest/mocks"
 	"pr-reviewer/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -62,6 +64,37 @@ func TestPostPullRequestCreate(t *testing.T) {
 		assert.Len(t, resp.PullRequest.AssignedReviewers, 2)
 	})
 
+	t.Run("fallback reviewers in response", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestCreateJSONRequestBody{
+			AuthorId:        "u123",
+			PullRequestId:   "pr-1003",
+			PullRequestName: "Docs",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		createdPR := &domain.PullRequest{
+			ID:                1003,
+			Name:              "Docs",
+			AuthorID:          123,
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []int{1, 7},
+			FallbackReviewers: []int{7},
+		}
+
+		usecase.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any()).Return(createdPR, nil)
+
+		handler.PostPullRequestCreate(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp domain.PullRequestResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u7"}, *resp.PullRequest.FallbackReviewers)
+	})
+
 	t.Run("bad json", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString("{invalid"))
 		rec := httptest.NewRecorder()
@@ -227,6 +260,30 @@ func TestPostPullRequestMerge(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("not approved: details in response", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestMergeJSONRequestBody{PullRequestId: "pr-42"})
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().MergePullRequest(gomock.Any(), 42).Return(nil, &domain.NotApprovedError{
+			RequiredApprovals: 2, Approvals: 1, MissingApprovals: []int{3}, ChangesRequestedBy: []int{3},
+		})
+
+		handler.PostPullRequestMerge(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var resp api.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.NOTAPPROVED, resp.Error.Code)
+		assert.NotNil(t, resp.Error.Details)
+		assert.Equal(t, 2, *resp.Error.Details.RequiredApprovals)
+		assert.Equal(t, 1, *resp.Error.Details.Approvals)
+		assert.Equal(t, []string{"u3"}, *resp.Error.Details.MissingApprovals)
+		assert.Equal(t, []string{"u3"}, *resp.Error.Details.ChangesRequestedBy)
+	})
+
 	t.Run("uc returns error: pr not found", func(t *testing.T) {
 		bodyStruct := api.PostPullRequestMergeJSONRequestBody{
 			PullRequestId: "pr-99",
@@ -244,6 +301,156 @@ func TestPostPullRequestMerge(t *testing.T) {
 	})
 }
 
+func TestPostPullRequestLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockprUC(ctrl)
+	handler := NewPRHandler(usecase)
+
+	t.Run("ready ok", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestReadyJSONRequestBody{PullRequestId: "pr-7"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/ready", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		openPR := &domain.PullRequest{ID: 7, AuthorID: 1, Status: domain.PRStatusOpen, AssignedReviewers: []int{2}}
+		usecase.EXPECT().MarkReady(gomock.Any(), 7).Return(openPR, nil)
+
+		handler.PostPullRequestReady(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.PullRequestResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.PullRequestStatusOPEN, resp.PullRequest.Status)
+		assert.Equal(t, []string{"u2"}, resp.PullRequest.AssignedReviewers)
+	})
+
+	t.Run("close ok", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestCloseJSONRequestBody{PullRequestId: "pr-7"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		now := time.Now()
+		closedPR := &domain.PullRequest{ID: 7, AuthorID: 1, Status: domain.PRStatusClosed, ClosedAt: &now}
+		usecase.EXPECT().ClosePullRequest(gomock.Any(), 7).Return(closedPR, nil)
+
+		handler.PostPullRequestClose(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.PullRequestResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.PullRequestStatusCLOSED, resp.PullRequest.Status)
+		assert.NotNil(t, resp.PullRequest.ClosedAt)
+	})
+
+	t.Run("reopen merged: invalid transition", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestReopenJSONRequestBody{PullRequestId: "pr-7"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reopen", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().ReopenPullRequest(gomock.Any(), 7).
+			Return(nil, &domain.TransitionError{From: domain.PRStatusMerged, Action: domain.PRActionReopen})
+
+		handler.PostPullRequestReopen(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var resp api.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.INVALIDTRANSITION, resp.Error.Code)
+	})
+
+	t.Run("invalid pull_request_id", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestCloseJSONRequestBody{PullRequestId: "7"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostPullRequestClose(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/ready", bytes.NewBufferString("{invalid"))
+		rec := httptest.NewRecorder()
+
+		handler.PostPullRequestReady(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestPostPullRequestReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockprUC(ctrl)
+	handler := NewPRHandler(usecase)
+
+	t.Run("review ok", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-5", UserId: "u2", Verdict: api.APPROVED})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		submittedAt := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+		reviewedPR := &domain.PullRequest{
+			ID: 5, AuthorID: 1, Status: domain.PRStatusOpen, AssignedReviewers: []int{2, 3},
+			Reviews: []domain.Review{{ReviewerID: 2, Verdict: domain.VerdictApproved, SubmittedAt: submittedAt}},
+		}
+		usecase.EXPECT().SubmitReview(gomock.Any(), &domain.SubmitReview{
+			PullRequestID: 5, UserID: 2, Verdict: domain.VerdictApproved,
+		}).Return(reviewedPR, nil)
+
+		handler.PostPullRequestReview(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.PullRequestResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []api.Review{{UserId: "u2", Verdict: api.APPROVED, SubmittedAt: submittedAt}}, *resp.PullRequest.Reviews)
+	})
+
+	t.Run("invalid verdict", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review",
+			bytes.NewBufferString(`{"pull_request_id":"pr-5","user_id":"u2","verdict":"LGTM"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostPullRequestReview(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-5", UserId: "u9", Verdict: api.COMMENTED})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().SubmitReview(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotAssigned)
+
+		handler.PostPullRequestReview(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("PR closed", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-5", UserId: "u2", Verdict: api.CHANGESREQUESTED})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().SubmitReview(gomock.Any(), gomock.Any()).Return(nil, domain.ErrPullRequestIsClosed)
+
+		handler.PostPullRequestReview(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var resp api.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.PRCLOSED, resp.Error.Code)
+	})
+}
+
 func TestPostPullRequestReassign(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -363,3 +570,210 @@ func TestPostPullRequestReassign(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, rec.Code)
 	})
 }
+
+func TestGetPullRequestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockprUC(ctrl)
+	handler := NewPRHandler(usecase)
+
+	pr := &domain.PullRequest{
+		ID: 42, Name: "Add search", AuthorID: 1, AuthorTeamName: "backend", Status: domain.PRStatusOpen,
+		AssignedReviewers: []int{2, 3},
+		Reviewers: []domain.User{
+			{ID: 2, Username: "Bob", IsActive: true},
+			{ID: 3, Username: "Carol", IsActive: false},
+		},
+		CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	var etag string
+	t.Run("ok", func(t *testing.T) {
+		usecase.EXPECT().GetPullRequest(gomock.Any(), 42).Return(pr, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+
+		handler.GetPullRequestGet(rec, req, api.GetPullRequestGetParams{PullRequestId: "pr-42"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		etag = rec.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+		var resp domain.PullRequestResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", *resp.PullRequest.AuthorTeamName)
+		assert.Equal(t, []api.PullRequestReviewer{
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: false},
+		}, *resp.PullRequest.Reviewers)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		usecase.EXPECT().GetPullRequest(gomock.Any(), 42).Return(pr, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+
+		handler.GetPullRequestGet(rec, req, api.GetPullRequestGetParams{PullRequestId: "pr-42", IfNoneMatch: &etag})
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("changed after etag", func(t *testing.T) {
+		merged := *pr
+		merged.Status = domain.PRStatusMerged
+		usecase.EXPECT().GetPullRequest(gomock.Any(), 42).Return(&merged, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+
+		handler.GetPullRequestGet(rec, req, api.GetPullRequestGetParams{PullRequestId: "pr-42", IfNoneMatch: &etag})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+
+		handler.GetPullRequestGet(rec, req, api.GetPullRequestGetParams{PullRequestId: "42"})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("pr not found", func(t *testing.T) {
+		usecase.EXPECT().GetPullRequest(gomock.Any(), 7).Return(nil, domain.ErrPullRequestNotFound)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+
+		handler.GetPullRequestGet(rec, req, api.GetPullRequestGetParams{PullRequestId: "pr-7"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetPullRequestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockprUC(ctrl)
+	handler := NewPRHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		now := time.Now()
+		events := append(
+			domain.NewAssignedEvents(42, []int{2}, nil, now),
+			domain.NewReplacementEvent(42, 2, 5, domain.ReasonReassigned, nil, now),
+		)
+		usecase.EXPECT().GetHistory(gomock.Any(), 42).Return(events, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history", nil)
+
+		handler.GetPullRequestHistory(rec, req, api.GetPullRequestHistoryParams{PullRequestId: "pr-42"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.PullRequestHistory
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "pr-42", resp.PullRequestID)
+		assert.Len(t, resp.Events, 2)
+		assert.Nil(t, resp.Events[0].OldReviewerId)
+		assert.Equal(t, "u2", *resp.Events[0].NewReviewerId)
+		assert.Equal(t, api.AssignmentReasonREASSIGNED, resp.Events[1].Reason)
+		assert.Equal(t, "u2", *resp.Events[1].OldReviewerId)
+		assert.Equal(t, "u5", *resp.Events[1].NewReviewerId)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history", nil)
+
+		handler.GetPullRequestHistory(rec, req, api.GetPullRequestHistoryParams{PullRequestId: "42"})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("pr not found", func(t *testing.T) {
+		usecase.EXPECT().GetHistory(gomock.Any(), 7).Return(nil, domain.ErrPullRequestNotFound)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history", nil)
+
+		handler.GetPullRequestHistory(rec, req, api.GetPullRequestHistoryParams{PullRequestId: "pr-7"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetPullRequestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockprUC(ctrl)
+	handler := NewPRHandler(usecase)
+
+	t.Run("filters, page and next cursor", func(t *testing.T) {
+		status := api.GetPullRequestListParamsStatus(domain.PRStatusOpen)
+		sort := api.CreatedAtAsc
+		reviewerID, q, limit := "u2", "search", 1
+		createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		want := &domain.PullRequestFilter{
+			Status:     &[]domain.PullRequestStatus{domain.PRStatusOpen}[0],
+			ReviewerID: &[]int{2}[0],
+			Query:      &q,
+			Sort:       domain.SortCreatedAtAsc,
+			Limit:      1,
+		}
+		page := &domain.PullRequestPage{
+			PullRequests: []domain.PullRequest{{
+				ID: 1001, Name: "Add search", AuthorID: 1, Status: domain.PRStatusOpen,
+				AssignedReviewers: []int{2, 3}, CreatedAt: createdAt,
+			}},
+			NextCursor: &domain.PageCursor{Sort: domain.SortCreatedAtDesc, CreatedAt: createdAt, PullRequestID: 1001},
+		}
+		usecase.EXPECT().ListPullRequests(gomock.Any(), want).Return(page, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil)
+
+		handler.GetPullRequestList(rec, req, api.GetPullRequestListParams{
+			Status: &status, ReviewerId: &reviewerID, Q: &q, Sort: &sort, Limit: &limit,
+		})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.PullRequestList
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Len(t, resp.PullRequests, 1)
+		assert.Equal(t, "pr-1001", resp.PullRequests[0].PullRequestId)
+		assert.Equal(t, []string{"u2", "u3"}, *resp.PullRequests[0].AssignedReviewers)
+		assert.Equal(t, page.NextCursor.Encode(), *resp.NextCursor)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		limit := 0
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil)
+
+		handler.GetPullRequestList(rec, req, api.GetPullRequestListParams{Limit: &limit})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		usecase.EXPECT().ListPullRequests(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil)
+
+		handler.GetPullRequestList(rec, req, api.GetPullRequestListParams{})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -10,5 +10,12 @@ import (
 type prUC interface {
 	CreatePullRequest(ctx context.Context, cr *domain.CreatePullRequest) (*domain.PullRequest, error)
 	MergePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID int) (*domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	SubmitReview(ctx context.Context, sr *domain.SubmitReview) (*domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, reas *domain.ReassingReviewer) (*domain.PullRequest, int, error)
+	GetPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	GetHistory(ctx context.Context, prID int) ([]domain.AssignmentEvent, error)
+	ListPullRequests(ctx context.Context, filter *domain.PullRequestFilter) (*domain.PullRequestPage, error)
 }
@@ -0,0 +1,71 @@
+// Package stats содержит handlers для статистики назначений
+package stats
+
+import (
+	"errors"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+	"pr-reviewer/internal/pkg/validation"
+	"strconv"
+)
+
+// StatsHandler Handler для статистики
+type StatsHandler struct {
+	uc statsUC
+}
+
+func NewStatsHandler(uc statsUC) *StatsHandler {
+	return &StatsHandler{
+		uc: uc,
+	}
+}
+
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request, params api.GetStatsParams) {
+	h.sendStats(w, r, &domain.StatsFilter{From: params.From, To: params.To})
+}
+
+func (h *StatsHandler) GetStatsTeam(w http.ResponseWriter, r *http.Request, params api.GetStatsTeamParams) {
+	if err := validation.ValidateTeamName(params.TeamName); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	teamName := params.TeamName
+	h.sendStats(w, r, &domain.StatsFilter{TeamName: &teamName, From: params.From, To: params.To})
+}
+
+func (h *StatsHandler) GetStatsUser(w http.ResponseWriter, r *http.Request, params api.GetStatsUserParams) {
+	if err := validation.ValidateUserId(params.UserId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	userID, _ := strconv.Atoi(params.UserId[1:])
+	h.sendStats(w, r, &domain.StatsFilter{UserID: &userID, From: params.From, To: params.To})
+}
+
+func (h *StatsHandler) sendStats(w http.ResponseWriter, r *http.Request, filter *domain.StatsFilter) {
+	st, err := h.uc.GetStats(r.Context(), filter)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainStatsToAPI(st))
+}
+
+func (h *StatsHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTimeWindow):
+		return api.BADREQUEST, http.StatusBadRequest
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrUserNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	default:
+		return api.INTERNAL, http.StatusInternalServerError
+	}
+}
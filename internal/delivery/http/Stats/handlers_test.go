@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/delivery/http/Stats/mocks"
+	"pr-reviewer/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockstatsUC(ctrl)
+	handler := NewStatsHandler(usecase)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	avg := 90 * time.Minute
+
+	t.Run("ok", func(t *testing.T) {
+		params := api.GetStatsParams{From: &from}
+		st := &domain.Stats{
+			PullRequests:  domain.PullRequestCounts{Total: 2, Merged: 1, Open: 1},
+			AvgMergeTime:  &avg,
+			Reassignments: 1,
+			Reviewers: []domain.ReviewerStats{
+				{UserID: 1, Username: "garry", Assignments: 2, OpenReviews: 1, ReassignmentsReceived: 1},
+			},
+		}
+
+		usecase.EXPECT().GetStats(gomock.Any(), &domain.StatsFilter{From: &from}).Return(st, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+
+		handler.GetStats(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp api.Stats
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.PullRequests.Total)
+		assert.Equal(t, 5400.0, *resp.AvgMergeSeconds)
+		assert.Equal(t, "u1", resp.Reviewers[0].UserId)
+	})
+
+	t.Run("invalid time window", func(t *testing.T) {
+		usecase.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInvalidTimeWindow)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+
+		handler.GetStats(rec, req, api.GetStatsParams{})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetStatsTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockstatsUC(ctrl)
+	handler := NewStatsHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		teamName := "backend"
+		usecase.EXPECT().GetStats(gomock.Any(), &domain.StatsFilter{TeamName: &teamName}).Return(&domain.Stats{}, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/team", nil)
+
+		handler.GetStatsTeam(rec, req, api.GetStatsTeamParams{TeamName: teamName})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/team", nil)
+
+		handler.GetStatsTeam(rec, req, api.GetStatsTeamParams{TeamName: ""})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		usecase.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, domain.ErrTeamNotFound)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/team", nil)
+
+		handler.GetStatsTeam(rec, req, api.GetStatsTeamParams{TeamName: "unknown"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetStatsUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockstatsUC(ctrl)
+	handler := NewStatsHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		userID := 7
+		usecase.EXPECT().GetStats(gomock.Any(), &domain.StatsFilter{UserID: &userID}).Return(&domain.Stats{}, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/user", nil)
+
+		handler.GetStatsUser(rec, req, api.GetStatsUserParams{UserId: "u7"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/user", nil)
+
+		handler.GetStatsUser(rec, req, api.GetStatsUserParams{UserId: "bad"})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		usecase.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUserNotFound)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stats/user", nil)
+
+		handler.GetStatsUser(rec, req, api.GetStatsUserParams{UserId: "u9"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
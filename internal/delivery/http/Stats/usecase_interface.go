@@ -0,0 +1,12 @@
+package stats
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source usecase_interface.go -destination=mocks/mock_stats_usecase.go -package=mocks
+
+type statsUC interface {
+	GetStats(ctx context.Context, filter *domain.StatsFilter) (*domain.Stats, error)
+}
@@ -65,12 +65,191 @@ func (h *TeamHandler) GetTeamGet(w http.ResponseWriter, r *http.Request, params
 	response.SendResponse(w, http.StatusOK, teamAPI)
 }
 
+func (h *TeamHandler) GetTeamSettings(w http.ResponseWriter, r *http.Request, params api.GetTeamSettingsParams) {
+	if err := validation.ValidateTeamName(params.TeamName); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.uc.GetTeamSettings(r.Context(), params.TeamName)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainTeamSettingsToAPI(settings))
+}
+
+func (h *TeamHandler) PostTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamSettingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateTeamSettings(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	upd := domain.APIToDomainUpdateTeamSettings(req)
+
+	settings, err := h.uc.UpdateTeamSettings(r.Context(), upd)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.TeamSettingsResponse{Settings: domain.DomainTeamSettingsToAPI(settings)}
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
+func (h *TeamHandler) PostTeamSync(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamSyncJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateSyncTeam(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.uc.SyncTeam(r.Context(), domain.APIToDomainSyncTeam(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainTeamSyncResultToAPI(res))
+}
+
+func (h *TeamHandler) PostTeamMembersAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamMembersAddJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateAddTeamMembers(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.uc.AddTeamMembers(r.Context(), domain.APIToDomainAddTeamMembers(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.TeamResponse{Team: domain.DomainTeamToAPI(team)})
+}
+
+func (h *TeamHandler) PostTeamMembersRemove(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamMembersRemoveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateRemoveTeamMembers(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.uc.RemoveTeamMembers(r.Context(), domain.APIToDomainRemoveTeamMembers(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainTeamMembershipResultToAPI(res))
+}
+
+func (h *TeamHandler) PostTeamMembersMove(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamMembersMoveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateMoveTeamMember(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.uc.MoveTeamMember(r.Context(), domain.APIToDomainMoveTeamMember(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainTeamMembershipResultToAPI(res))
+}
+
+func (h *TeamHandler) PostTeamRename(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamRenameJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateRenameTeam(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.uc.RenameTeam(r.Context(), &domain.RenameTeam{TeamName: req.TeamName, NewTeamName: req.NewTeamName})
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.TeamResponse{Team: domain.DomainTeamToAPI(team)})
+}
+
+func (h *TeamHandler) PostTeamDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateTeamName(req.TeamName); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.uc.DeleteTeam(r.Context(), req.TeamName)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.TeamResponse{Team: domain.DomainTeamToAPI(team)})
+}
+
 func (h *TeamHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
 	switch {
 	case errors.Is(err, domain.ErrTeamExists):
 		return api.TEAMEXISTS, http.StatusBadRequest
 	case errors.Is(err, domain.ErrTeamNotFound):
 		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidSettings):
+		return api.BADREQUEST, http.StatusBadRequest
+	case errors.Is(err, domain.ErrUserInOtherTeam):
+		return api.USERINTEAM, http.StatusConflict
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrNotTeamMember):
+		return api.NOTFOUND, http.StatusNotFound
 	default:
 		return api.INTERNAL, http.StatusInternalServerError
 	}
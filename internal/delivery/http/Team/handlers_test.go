@@ -37,9 +37,10 @@ func TestPostTeamAdd(t *testing.T) {
 		team := &domain.Team{
 			Name: "NewTeam",
 			Members: []domain.TeamMember{
-				{UserID: 1, Username: "john", IsActive: true},
-				{UserID: 2, Username: "garry", IsActive: false},
+				{UserID: 1, Username: "john", IsActive: true, ReviewWeight: 1},
+				{UserID: 2, Username: "garry", IsActive: false, ReviewWeight: 1},
 			},
+			ReviewerStrategy: domain.StrategyRandom,
 		}
 
 		createdTeam := &domain.Team{ID: 10, Name: "NewTeam", Members: team.Members}
@@ -117,9 +118,10 @@ func TestPostTeamAdd(t *testing.T) {
 		apiTeam := &domain.Team{
 			Name: "TeamA",
 			Members: []domain.TeamMember{
-				{UserID: 1, Username: "john", IsActive: true},
-				{UserID: 2, Username: "garry", IsActive: false},
+				{UserID: 1, Username: "john", IsActive: true, ReviewWeight: 1},
+				{UserID: 2, Username: "garry", IsActive: false, ReviewWeight: 1},
 			},
+			ReviewerStrategy: domain.StrategyRandom,
 		}
 
 		usecase.EXPECT().CreateTeam(gomock.Any(), apiTeam).Return(nil, domain.ErrTeamExists)
@@ -212,3 +214,381 @@ func TestGetTeamGet(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 }
+
+func TestPostTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	minReviewers, maxReviewers := 3, 3
+
+	t.Run("settings updated ok", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamSettingsJSONRequestBody{
+			TeamName:     "security",
+			MinReviewers: &minReviewers,
+			MaxReviewers: &maxReviewers,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		upd := &domain.UpdateTeamSettings{TeamName: "security", MinReviewers: &minReviewers, MaxReviewers: &maxReviewers}
+		updated := &domain.TeamSettings{
+			TeamName: "security", ReviewerStrategy: domain.StrategyRandom, MinReviewers: 3, MaxReviewers: 3,
+		}
+
+		usecase.EXPECT().UpdateTeamSettings(gomock.Any(), upd).Return(updated, nil)
+
+		handler.PostTeamSettings(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TeamSettingsResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "security", resp.Settings.TeamName)
+		assert.Equal(t, 3, *resp.Settings.MinReviewers)
+		assert.Equal(t, api.RANDOM, *resp.Settings.ReviewerStrategy)
+	})
+
+	t.Run("min greater than max", func(t *testing.T) {
+		tooMany := 4
+		body, _ := json.Marshal(api.PostTeamSettingsJSONRequestBody{
+			TeamName:     "security",
+			MinReviewers: &tooMany,
+			MaxReviewers: &maxReviewers,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostTeamSettings(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamSettingsJSONRequestBody{TeamName: "unknown", MaxReviewers: &maxReviewers})
+		req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().UpdateTeamSettings(gomock.Any(), gomock.Any()).Return(nil, domain.ErrTeamNotFound)
+
+		handler.PostTeamSettings(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	t.Run("get settings ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/team/settings", nil)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().GetTeamSettings(gomock.Any(), "docs").Return(&domain.TeamSettings{
+			TeamName: "docs", ReviewerStrategy: domain.StrategyRoundRobin, MinReviewers: 1, MaxReviewers: 1,
+		}, nil)
+
+		handler.GetTeamSettings(rec, req, api.GetTeamSettingsParams{TeamName: "docs"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp api.TeamSettings
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *resp.MaxReviewers)
+		assert.Equal(t, api.ROUNDROBIN, *resp.ReviewerStrategy)
+	})
+
+	t.Run("invalid team name", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/team/settings", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetTeamSettings(rec, req, api.GetTeamSettingsParams{TeamName: " "})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestPostTeamMembersAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	body, _ := json.Marshal(api.PostTeamMembersAddJSONRequestBody{
+		TeamName: "backend",
+		Members:  []api.TeamMember{{UserId: "u7", Username: "grace", IsActive: true}},
+	})
+
+	t.Run("members added ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/members/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		add := &domain.AddTeamMembers{
+			TeamName: "backend",
+			Members:  []domain.TeamMember{{UserID: 7, Username: "grace", IsActive: true, ReviewWeight: 1}},
+		}
+		usecase.EXPECT().AddTeamMembers(gomock.Any(), add).Return(&domain.Team{Name: "backend", Members: add.Members}, nil)
+
+		handler.PostTeamMembersAdd(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TeamResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "u7", resp.Team.Members[0].UserId)
+	})
+
+	t.Run("user in another team", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/members/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().AddTeamMembers(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUserInOtherTeam)
+
+		handler.PostTeamMembersAdd(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		var resp api.ErrorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.USERINTEAM, resp.Error.Code)
+	})
+
+	t.Run("empty members", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamMembersAddJSONRequestBody{TeamName: "backend"})
+		req := httptest.NewRequest(http.MethodPost, "/team/members/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostTeamMembersAdd(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestPostTeamMembersRemove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	body, _ := json.Marshal(api.PostTeamMembersRemoveJSONRequestBody{TeamName: "backend", UserIds: []string{"u2"}})
+
+	t.Run("members removed ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/members/remove", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().RemoveTeamMembers(gomock.Any(), &domain.RemoveTeamMembers{TeamName: "backend", UserIDs: []int{2}}).Return(&domain.TeamMembershipResult{
+			Team:             &domain.Team{Name: "backend"},
+			Reassigned:       []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 4}},
+			WithoutCandidate: []domain.StuckReview{},
+		}, nil)
+
+		handler.PostTeamMembersRemove(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp api.TeamMembershipResult
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", resp.Team.TeamName)
+		assert.Equal(t, "pr-10", resp.Reassigned[0].PullRequestId)
+		assert.Equal(t, "u4", resp.Reassigned[0].NewUserId)
+	})
+
+	t.Run("not a team member", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/members/remove", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().RemoveTeamMembers(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotTeamMember)
+
+		handler.PostTeamMembersRemove(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostTeamMembersMove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	t.Run("member moved ok", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamMembersMoveJSONRequestBody{UserId: "u2", TeamName: "frontend"})
+		req := httptest.NewRequest(http.MethodPost, "/team/members/move", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().MoveTeamMember(gomock.Any(), &domain.MoveTeamMember{UserID: 2, ToTeamName: "frontend"}).Return(&domain.TeamMembershipResult{
+			Team:             &domain.Team{Name: "frontend", Members: []domain.TeamMember{{UserID: 2, Username: "bob"}}},
+			Reassigned:       []domain.ReviewReplacement{},
+			WithoutCandidate: []domain.StuckReview{},
+		}, nil)
+
+		handler.PostTeamMembersMove(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid user_id", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamMembersMoveJSONRequestBody{UserId: "2", TeamName: "frontend"})
+		req := httptest.NewRequest(http.MethodPost, "/team/members/move", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostTeamMembersMove(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamMembersMoveJSONRequestBody{UserId: "u99", TeamName: "frontend"})
+		req := httptest.NewRequest(http.MethodPost, "/team/members/move", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().MoveTeamMember(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUserNotFound)
+
+		handler.PostTeamMembersMove(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostTeamRename(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	body, _ := json.Marshal(api.PostTeamRenameJSONRequestBody{TeamName: "backend", NewTeamName: "platform"})
+
+	t.Run("renamed ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/rename", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().RenameTeam(gomock.Any(), &domain.RenameTeam{TeamName: "backend", NewTeamName: "platform"}).
+			Return(&domain.Team{Name: "platform"}, nil)
+
+		handler.PostTeamRename(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TeamResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "platform", resp.Team.TeamName)
+	})
+
+	t.Run("name is taken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/rename", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().RenameTeam(gomock.Any(), gomock.Any()).Return(nil, domain.ErrTeamExists)
+
+		handler.PostTeamRename(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestPostTeamDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	body, _ := json.Marshal(api.PostTeamDeleteJSONRequestBody{TeamName: "legacy"})
+
+	t.Run("deleted ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/delete", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().DeleteTeam(gomock.Any(), "legacy").Return(&domain.Team{Name: "legacy"}, nil)
+
+		handler.PostTeamDelete(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/team/delete", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().DeleteTeam(gomock.Any(), "legacy").Return(nil, domain.ErrTeamNotFound)
+
+		handler.PostTeamDelete(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostTeamSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockteamUC(ctrl)
+	handler := NewTeamHandler(usecase)
+
+	dryRun := true
+
+	t.Run("dry run diff", func(t *testing.T) {
+		body, _ := json.Marshal(api.PostTeamSyncJSONRequestBody{
+			TeamName: "backend",
+			Members:  []api.TeamMember{{UserId: "u7", Username: "grace", IsActive: true}},
+			DryRun:   &dryRun,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/team/sync", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		// review_weight не передан: вес не меняется
+		omitted := domain.TeamMember{UserID: 7, Username: "grace", IsActive: true}
+		grace := domain.TeamMember{UserID: 7, Username: "grace", IsActive: true, ReviewWeight: 1}
+		sync := &domain.SyncTeam{
+			Team:   &domain.Team{Name: "backend", Members: []domain.TeamMember{omitted}, ReviewerStrategy: domain.StrategyRandom},
+			DryRun: true,
+		}
+		usecase.EXPECT().SyncTeam(gomock.Any(), sync).Return(&domain.TeamSyncResult{
+			Team:             &domain.Team{Name: "backend", Members: []domain.TeamMember{grace}, ReviewerStrategy: domain.StrategyRandom},
+			DryRun:           true,
+			Added:            []domain.TeamMember{grace},
+			Updated:          []domain.TeamMember{},
+			Removed:          []int{2},
+			Reassigned:       []domain.ReviewReplacement{},
+			WithoutCandidate: []domain.StuckReview{},
+		}, nil)
+
+		handler.PostTeamSync(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp api.TeamSyncResult
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, "u7", resp.Added[0].UserId)
+		assert.Equal(t, []string{"u2"}, resp.Removed)
+	})
+
+	t.Run("duplicate user_id", func(t *testing.T) {
+		member := api.TeamMember{UserId: "u7", Username: "grace", IsActive: true}
+		body, _ := json.Marshal(api.PostTeamSyncJSONRequestBody{TeamName: "backend", Members: []api.TeamMember{member, member}})
+		req := httptest.NewRequest(http.MethodPost, "/team/sync", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostTeamSync(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
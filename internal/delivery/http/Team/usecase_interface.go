@@ -10,4 +10,12 @@ import (
 type teamUC interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeamByName(ctx context.Context, name string) (*domain.Team, error)
+	GetTeamSettings(ctx context.Context, name string) (*domain.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, upd *domain.UpdateTeamSettings) (*domain.TeamSettings, error)
+	SyncTeam(ctx context.Context, sync *domain.SyncTeam) (*domain.TeamSyncResult, error)
+	AddTeamMembers(ctx context.Context, add *domain.AddTeamMembers) (*domain.Team, error)
+	RemoveTeamMembers(ctx context.Context, rm *domain.RemoveTeamMembers) (*domain.TeamMembershipResult, error)
+	MoveTeamMember(ctx context.Context, mv *domain.MoveTeamMember) (*domain.TeamMembershipResult, error)
+	RenameTeam(ctx context.Context, rn *domain.RenameTeam) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, name string) (*domain.Team, error)
 }
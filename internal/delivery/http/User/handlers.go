@@ -8,7 +8,6 @@ import (
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/response"
 	"pr-reviewer/internal/pkg/validation"
-	"strconv"
 )
 
 type UserHandler struct {
@@ -22,23 +21,25 @@ func NewUserHandler(uc userUC) *UserHandler {
 }
 
 func (h *UserHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
-	userIdAPI := params.UserId
-	if err := validation.ValidateUserId(userIdAPI); err != nil {
+	if err := validation.ValidateReviewFilter(params); err != nil {
 		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
 		return
 	}
 
-	userID, _ := strconv.Atoi(userIdAPI[1:])
-	userPRs, err := h.uc.GetUserPullRequests(r.Context(), userID)
+	filter, err := domain.APIToDomainReviewFilter(params)
+	if err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.uc.GetUserPullRequests(r.Context(), filter)
 	if err != nil {
 		code, status := h.mapDomainErrorToAPI(err)
 		response.SendErrorResponse(w, code, status)
 		return
 	}
 
-	userPRsAPI := domain.DomainPRsToAPIShort(userPRs)
-	resp := domain.UserReviews{UserID: userIdAPI, PullRequests: userPRsAPI}
-
+	resp := domain.DomainReviewPageToAPI(params.UserId, page, filter.IncludeReviewers)
 	response.SendResponse(w, http.StatusOK, resp)
 }
 
@@ -69,10 +70,108 @@ func (h *UserHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Reques
 	response.SendResponse(w, http.StatusOK, resp)
 }
 
+func (h *UserHandler) PostUsersBulkDeactivate(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersBulkDeactivateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateBulkDeactivate(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	bd := domain.APIToDomainBulkDeactivate(req)
+
+	res, err := h.uc.BulkDeactivate(r.Context(), bd)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainBulkDeactivateResultToAPI(res))
+}
+
+func (h *UserHandler) GetUsersAvailability(w http.ResponseWriter, r *http.Request, params api.GetUsersAvailabilityParams) {
+	if err := validation.ValidateUserId(params.UserId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	userID := domain.APIToDomainAvailabilityUserID(params)
+
+	windows, err := h.uc.GetAvailability(r.Context(), userID)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	response.SendResponse(w, http.StatusOK, domain.DomainUserAvailabilityToAPI(userID, windows))
+}
+
+func (h *UserHandler) PostUsersAvailabilityAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersAvailabilityAddJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateAvailabilityWindow(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	window, err := h.uc.AddAvailabilityWindow(r.Context(), domain.APIToDomainAvailabilityWindow(req))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.AvailabilityWindowResponse{Window: domain.DomainAvailabilityWindowToAPI(window)}
+	response.SendResponse(w, http.StatusCreated, resp)
+}
+
+func (h *UserHandler) PostUsersAvailabilityDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersAvailabilityDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateAvailabilityWindowId(req.WindowId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	window, err := h.uc.DeleteAvailabilityWindow(r.Context(), domain.APIToDomainAvailabilityWindowID(req.WindowId))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.AvailabilityWindowResponse{Window: domain.DomainAvailabilityWindowToAPI(window)}
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
 func (h *UserHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
 	switch {
 	case errors.Is(err, domain.ErrUserNotFound):
 		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrAvailabilityWindowNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidAvailability):
+		return api.BADREQUEST, http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotAssigned):
+		return api.NOTASSIGNED, http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return api.FORBIDDEN, http.StatusForbidden
 	default:
 		return api.INTERNAL, http.StatusInternalServerError
 	}
@@ -10,6 +10,7 @@ import (
 	"pr-reviewer/internal/delivery/http/User/mocks"
 	"pr-reviewer/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,10 @@ func TestGetUsersGetReview(t *testing.T) {
 	usecase := mocks.NewMockuserUC(ctrl)
 	handler := NewUserHandler(usecase)
 
+	firstPage := func(userID int) *domain.ReviewFilter {
+		return &domain.ReviewFilter{UserID: userID, Limit: domain.DefaultPageLimit}
+	}
+
 	t.Run("ok get reviews", func(t *testing.T) {
 		params := api.GetUsersGetReviewParams{UserId: "u1"}
 		prs := []domain.PullRequest{
@@ -29,7 +34,7 @@ func TestGetUsersGetReview(t *testing.T) {
 			{ID: 2, Name: "Add Feature"},
 		}
 
-		usecase.EXPECT().GetUserPullRequests(gomock.Any(), 1).Return(prs, nil)
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), firstPage(1)).Return(&domain.ReviewPage{PullRequests: prs}, nil)
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
@@ -44,6 +49,41 @@ func TestGetUsersGetReview(t *testing.T) {
 		assert.Len(t, resp.PullRequests, 2)
 	})
 
+	t.Run("user token reads other user reviews", func(t *testing.T) {
+		params := api.GetUsersGetReviewParams{UserId: "u1"}
+
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), firstPage(1)).Return(nil, domain.ErrForbidden)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("latest verdict in reviews", func(t *testing.T) {
+		params := api.GetUsersGetReviewParams{UserId: "u1"}
+		approved := domain.VerdictApproved
+		prs := []domain.PullRequest{
+			{ID: 1, Name: "Fix Bug", Status: domain.PRStatusOpen, Verdict: &approved},
+			{ID: 2, Name: "Add Feature", Status: domain.PRStatusOpen},
+		}
+
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), firstPage(1)).Return(&domain.ReviewPage{PullRequests: prs}, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.UserReviews
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.APPROVED, *resp.PullRequests[0].Verdict)
+		assert.Nil(t, resp.PullRequests[1].Verdict)
+	})
+
 	t.Run("bad userId: validation error", func(t *testing.T) {
 		params := api.GetUsersGetReviewParams{
 			UserId: "INVALID",
@@ -62,7 +102,7 @@ func TestGetUsersGetReview(t *testing.T) {
 			UserId: "u1234",
 		}
 
-		usecase.EXPECT().GetUserPullRequests(gomock.Any(), 1234).Return(nil, domain.ErrUserNotFound)
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), firstPage(1234)).Return(nil, domain.ErrUserNotFound)
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/users/u123/reviews", nil)
@@ -71,6 +111,80 @@ func TestGetUsersGetReview(t *testing.T) {
 
 		assert.Equal(t, rec.Code, http.StatusNotFound)
 	})
+
+	t.Run("filters, page and next cursor", func(t *testing.T) {
+		status := api.GetUsersGetReviewParamsStatus(domain.PRStatusOpen)
+		authorID, teamName, include, limit := "u3", "backend", true, 1
+		cursor := &domain.PageCursor{Sort: domain.SortCreatedAtDesc, CreatedAt: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), PullRequestID: 7}
+		encoded := cursor.Encode()
+		params := api.GetUsersGetReviewParams{
+			UserId: "u1", Status: &status, AuthorId: &authorID, TeamName: &teamName,
+			IncludeReviewers: &include, Limit: &limit, Cursor: &encoded,
+		}
+
+		open, author := domain.PRStatusOpen, 3
+		filter := &domain.ReviewFilter{
+			UserID: 1, Status: &open, AuthorID: &author, TeamName: &teamName,
+			IncludeReviewers: true, Limit: 1, Cursor: cursor,
+		}
+		next := &domain.PageCursor{Sort: domain.SortCreatedAtDesc, CreatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), PullRequestID: 5}
+		page := &domain.ReviewPage{
+			PullRequests: []domain.PullRequest{{ID: 5, Name: "Fix Bug", AuthorID: 3, Status: domain.PRStatusOpen, CreatedAt: next.CreatedAt, AssignedReviewers: []int{4}}},
+			NextCursor:   next,
+		}
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), filter).Return(page, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.UserReviews
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []string{"u4"}, *resp.PullRequests[0].OtherReviewers)
+		assert.Equal(t, next.Encode(), *resp.NextCursor)
+	})
+
+	t.Run("last page has no cursor and no reviewers", func(t *testing.T) {
+		params := api.GetUsersGetReviewParams{UserId: "u1"}
+		page := &domain.ReviewPage{PullRequests: []domain.PullRequest{{ID: 5, AssignedReviewers: []int{4}}}}
+
+		usecase.EXPECT().GetUserPullRequests(gomock.Any(), firstPage(1)).Return(page, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "next_cursor")
+		assert.NotContains(t, rec.Body.String(), "other_reviewers")
+	})
+
+	t.Run("bad cursor", func(t *testing.T) {
+		cursor := "garbage"
+		params := api.GetUsersGetReviewParams{UserId: "u1", Cursor: &cursor}
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		limit := domain.MaxPageLimit + 1
+		params := api.GetUsersGetReviewParams{UserId: "u1", Limit: &limit}
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+
+		handler.GetUsersGetReview(rec, req, params)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
 }
 
 func TestPostUsersSetIsActive(t *testing.T) {
@@ -181,3 +295,217 @@ func TestPostUsersSetIsActive(t *testing.T) {
 	})
 
 }
+
+func TestPostUsersBulkDeactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockuserUC(ctrl)
+	handler := NewUserHandler(usecase)
+
+	t.Run("bulk deactivate by ids ok", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_ids":["u1","u2"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/bulkDeactivate", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().BulkDeactivate(gomock.Any(), &domain.BulkDeactivate{UserIDs: []int{1, 2}}).Return(&domain.BulkDeactivateResult{
+			Deactivated:      []int{1, 2},
+			Reassigned:       []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 1, NewReviewerID: 5}},
+			WithoutCandidate: []domain.StuckReview{{PullRequestID: 11, ReviewerID: 2}},
+		}, nil)
+
+		handler.PostUsersBulkDeactivate(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp api.BulkDeactivateResult
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []string{"u1", "u2"}, resp.Deactivated)
+		assert.Equal(t, []api.ReviewReplacement{{PullRequestId: "pr-10", OldUserId: "u1", NewUserId: "u5"}}, resp.Reassigned)
+		assert.Equal(t, []api.StuckReview{{PullRequestId: "pr-11", UserId: "u2"}}, resp.WithoutCandidate)
+	})
+
+	t.Run("both ids and team name", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_ids":["u1"],"team_name":"backend"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/bulkDeactivate", body)
+		rec := httptest.NewRecorder()
+
+		handler.PostUsersBulkDeactivate(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"team_name":"backend"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/bulkDeactivate", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().BulkDeactivate(gomock.Any(), &domain.BulkDeactivate{TeamName: "backend"}).Return(nil, domain.ErrTeamNotFound)
+
+		handler.PostUsersBulkDeactivate(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("reviewers changed concurrently", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_ids":["u1"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/bulkDeactivate", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().BulkDeactivate(gomock.Any(), &domain.BulkDeactivate{UserIDs: []int{1}}).
+			Return(nil, domain.ErrNotAssigned)
+
+		handler.PostUsersBulkDeactivate(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		var resp api.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, api.NOTASSIGNED, resp.Error.Code)
+	})
+}
+
+func TestPostUsersAvailabilityAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockuserUC(ctrl)
+	handler := NewUserHandler(usecase)
+
+	from := time.Date(2030, 1, 10, 9, 0, 0, 0, time.UTC)
+	to := from.Add(72 * time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_id":"u2","from":"2030-01-10T12:00:00+03:00","to":"2030-01-13T09:00:00Z","reason":"vacation"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/add", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().AddAvailabilityWindow(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error) {
+				assert.Equal(t, 2, w.UserID)
+				assert.Equal(t, from, w.From)
+				assert.Equal(t, to, w.To)
+				created := *w
+				created.ID = 4
+				return &created, nil
+			},
+		)
+
+		handler.PostUsersAvailabilityAdd(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp domain.AvailabilityWindowResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "av-4", resp.Window.WindowId)
+		assert.Equal(t, "u2", resp.Window.UserId)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_id":"u2","from":"2030-01-13T09:00:00Z","to":"2030-01-10T09:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/add", body)
+		rec := httptest.NewRecorder()
+
+		handler.PostUsersAvailabilityAdd(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"user_id":"u2","from":"2030-01-10T09:00:00Z","to":"2030-01-13T09:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/add", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().AddAvailabilityWindow(gomock.Any(), gomock.Any()).Return(nil, domain.ErrForbidden)
+
+		handler.PostUsersAvailabilityAdd(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestGetUsersAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockuserUC(ctrl)
+	handler := NewUserHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/availability", nil)
+
+		usecase.EXPECT().GetAvailability(gomock.Any(), 2).Return([]domain.AvailabilityWindow{{ID: 1, UserID: 2}}, nil)
+
+		handler.GetUsersAvailability(rec, req, api.GetUsersAvailabilityParams{UserId: "u2"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.UserAvailabilityResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "u2", resp.UserID)
+		assert.Len(t, resp.Windows, 1)
+		assert.Equal(t, "av-1", resp.Windows[0].WindowId)
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/availability", nil)
+
+		handler.GetUsersAvailability(rec, req, api.GetUsersAvailabilityParams{UserId: "2"})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/availability", nil)
+
+		usecase.EXPECT().GetAvailability(gomock.Any(), 9).Return(nil, domain.ErrUserNotFound)
+
+		handler.GetUsersAvailability(rec, req, api.GetUsersAvailabilityParams{UserId: "u9"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostUsersAvailabilityDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockuserUC(ctrl)
+	handler := NewUserHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"window_id":"av-3"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/delete", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().DeleteAvailabilityWindow(gomock.Any(), 3).Return(&domain.AvailabilityWindow{ID: 3, UserID: 2}, nil)
+
+		handler.PostUsersAvailabilityDelete(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid window id", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"window_id":"3"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/delete", body)
+		rec := httptest.NewRecorder()
+
+		handler.PostUsersAvailabilityDelete(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("window not found", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"window_id":"av-3"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/availability/delete", body)
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().DeleteAvailabilityWindow(gomock.Any(), 3).Return(nil, domain.ErrAvailabilityWindowNotFound)
+
+		handler.PostUsersAvailabilityDelete(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -9,5 +9,9 @@ import (
 
 type userUC interface {
 	SetUserIsActive(ctx context.Context, set *domain.SetUserIsActive) (*domain.User, error)
-	GetUserPullRequests(ctx context.Context, userID int) ([]domain.PullRequest, error)
+	GetUserPullRequests(ctx context.Context, filter *domain.ReviewFilter) (*domain.ReviewPage, error)
+	BulkDeactivate(ctx context.Context, bd *domain.BulkDeactivate) (*domain.BulkDeactivateResult, error)
+	GetAvailability(ctx context.Context, userID int) ([]domain.AvailabilityWindow, error)
+	AddAvailabilityWindow(ctx context.Context, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error)
+	DeleteAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error)
 }
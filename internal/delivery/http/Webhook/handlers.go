@@ -0,0 +1,102 @@
+// Package webhook содержит handlers для подписок на события
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+	"pr-reviewer/internal/pkg/validation"
+)
+
+// WebhookHandler Handler для подписок на события
+type WebhookHandler struct {
+	uc webhookUC
+}
+
+func NewWebhookHandler(uc webhookUC) *WebhookHandler {
+	return &WebhookHandler{
+		uc: uc,
+	}
+}
+
+func (h *WebhookHandler) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.PostWebhooksAddJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateWebhook(req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	sub := domain.APIToDomainWebhook(req)
+
+	created, err := h.uc.CreateWebhook(r.Context(), sub)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.WebhookResponse{Webhook: domain.DomainWebhookToAPI(created)}
+
+	response.SendResponse(w, http.StatusCreated, resp)
+}
+
+func (h *WebhookHandler) GetWebhooksList(w http.ResponseWriter, r *http.Request, params api.GetWebhooksListParams) {
+	if err := validation.ValidateTeamName(params.TeamName); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	subs, err := h.uc.GetTeamWebhooks(r.Context(), params.TeamName)
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.TeamWebhooks{TeamName: params.TeamName, Webhooks: domain.DomainWebhooksToAPI(subs)}
+
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostWebhooksDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	if err := validation.ValidateWebhookId(req.WebhookId); err != nil {
+		response.SendErrorResponse(w, api.BADREQUEST, http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := h.uc.DeleteWebhook(r.Context(), domain.APIToDomainWebhookID(req.WebhookId))
+	if err != nil {
+		code, status := h.mapDomainErrorToAPI(err)
+		response.SendErrorResponse(w, code, status)
+		return
+	}
+
+	resp := domain.WebhookResponse{Webhook: domain.DomainWebhookToAPI(deleted)}
+
+	response.SendResponse(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) mapDomainErrorToAPI(err error) (api.ErrorResponseErrorCode, int) {
+	switch {
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		return api.NOTFOUND, http.StatusNotFound
+	default:
+		return api.INTERNAL, http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/delivery/http/Webhook/mocks"
+	"pr-reviewer/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostWebhooksAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockwebhookUC(ctrl)
+	handler := NewWebhookHandler(usecase)
+
+	reqBody := api.PostWebhooksAddJSONRequestBody{
+		TeamName: "backend",
+		Url:      "https://bots.example.com/hooks",
+		Secret:   "0123456789abcdef",
+		Events:   []api.WebhookEventType{api.PullRequestCreated, api.PullRequestMerged},
+	}
+
+	t.Run("created ok", func(t *testing.T) {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		sub := &domain.WebhookSubscription{
+			TeamName: "backend",
+			URL:      "https://bots.example.com/hooks",
+			Secret:   "0123456789abcdef",
+			Events:   []domain.WebhookEventType{domain.WebhookPRCreated, domain.WebhookPRMerged},
+		}
+		created := *sub
+		created.ID = 3
+		created.CreatedAt = time.Now()
+		usecase.EXPECT().CreateWebhook(gomock.Any(), sub).Return(&created, nil)
+
+		handler.PostWebhooksAdd(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var resp domain.WebhookResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "wh-3", resp.Webhook.WebhookId)
+		assert.Len(t, resp.Webhook.Events, 2)
+		assert.NotContains(t, rec.Body.String(), "0123456789abcdef")
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		bad := reqBody
+		bad.Secret = "short"
+		body, _ := json.Marshal(bad)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handler.PostWebhooksAdd(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		usecase.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(nil, domain.ErrTeamNotFound)
+
+		handler.PostWebhooksAdd(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetWebhooksList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockwebhookUC(ctrl)
+	handler := NewWebhookHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		subs := []domain.WebhookSubscription{
+			{ID: 1, TeamName: "backend", URL: "https://a.example.com", Events: []domain.WebhookEventType{domain.WebhookPRCreated}},
+		}
+		usecase.EXPECT().GetTeamWebhooks(gomock.Any(), "backend").Return(subs, nil)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/list", nil)
+
+		handler.GetWebhooksList(rec, req, api.GetWebhooksListParams{TeamName: "backend"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.TeamWebhooks
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", resp.TeamName)
+		assert.Len(t, resp.Webhooks, 1)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		usecase.EXPECT().GetTeamWebhooks(gomock.Any(), "unknown").Return(nil, domain.ErrTeamNotFound)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/list", nil)
+
+		handler.GetWebhooksList(rec, req, api.GetWebhooksListParams{TeamName: "unknown"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostWebhooksDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockwebhookUC(ctrl)
+	handler := NewWebhookHandler(usecase)
+
+	t.Run("ok", func(t *testing.T) {
+		usecase.EXPECT().DeleteWebhook(gomock.Any(), 4).Return(&domain.WebhookSubscription{ID: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/delete", bytes.NewBufferString(`{"webhook_id":"wh-4"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostWebhooksDelete(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/delete", bytes.NewBufferString(`{"webhook_id":"4"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostWebhooksDelete(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		usecase.EXPECT().DeleteWebhook(gomock.Any(), 9).Return(nil, domain.ErrWebhookNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/delete", bytes.NewBufferString(`{"webhook_id":"wh-9"}`))
+		rec := httptest.NewRecorder()
+
+		handler.PostWebhooksDelete(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
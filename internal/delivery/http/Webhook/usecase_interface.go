@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source usecase_interface.go -destination=mocks/mock_webhook_usecase.go -package=mocks
+
+type webhookUC interface {
+	CreateWebhook(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetTeamWebhooks(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+}
@@ -4,22 +4,40 @@ package server
 import (
 	"net/http"
 	"pr-reviewer/internal/api"
+	auth "pr-reviewer/internal/delivery/http/Auth"
+	health "pr-reviewer/internal/delivery/http/Health"
+	ingest "pr-reviewer/internal/delivery/http/Ingest"
 	pullrequest "pr-reviewer/internal/delivery/http/PullRequest"
+	stats "pr-reviewer/internal/delivery/http/Stats"
 	team "pr-reviewer/internal/delivery/http/Team"
 	user "pr-reviewer/internal/delivery/http/User"
+	webhook "pr-reviewer/internal/delivery/http/Webhook"
 )
 
 type Server struct {
-	User *user.UserHandler
-	Team *team.TeamHandler
-	PR   *pullrequest.PRHandler
+	User    *user.UserHandler
+	Team    *team.TeamHandler
+	PR      *pullrequest.PRHandler
+	Stats   *stats.StatsHandler
+	Webhook *webhook.WebhookHandler
+	Ingest  *ingest.IngestHandler
+	Auth    *auth.AuthHandler
+	Health  *health.HealthHandler
 }
 
-func NewServer(u *user.UserHandler, t *team.TeamHandler, pr *pullrequest.PRHandler) *Server {
+func NewServer(
+	u *user.UserHandler, t *team.TeamHandler, pr *pullrequest.PRHandler, st *stats.StatsHandler, wh *webhook.WebhookHandler,
+	in *ingest.IngestHandler, a *auth.AuthHandler, hl *health.HealthHandler,
+) *Server {
 	return &Server{
-		User: u,
-		Team: t,
-		PR:   pr,
+		User:    u,
+		Team:    t,
+		PR:      pr,
+		Stats:   st,
+		Webhook: wh,
+		Ingest:  in,
+		Auth:    a,
+		Health:  hl,
 	}
 }
 
@@ -35,6 +53,34 @@ func (s *Server) PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
 	s.PR.PostPullRequestReassign(w, r)
 }
 
+func (s *Server) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	s.PR.PostPullRequestReview(w, r)
+}
+
+func (s *Server) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	s.PR.GetPullRequestGet(w, r, params)
+}
+
+func (s *Server) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params api.GetPullRequestHistoryParams) {
+	s.PR.GetPullRequestHistory(w, r, params)
+}
+
+func (s *Server) GetPullRequestList(w http.ResponseWriter, r *http.Request, params api.GetPullRequestListParams) {
+	s.PR.GetPullRequestList(w, r, params)
+}
+
+func (s *Server) PostPullRequestReady(w http.ResponseWriter, r *http.Request) {
+	s.PR.PostPullRequestReady(w, r)
+}
+
+func (s *Server) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	s.PR.PostPullRequestClose(w, r)
+}
+
+func (s *Server) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	s.PR.PostPullRequestReopen(w, r)
+}
+
 func (s *Server) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	s.Team.PostTeamAdd(w, r)
 }
@@ -43,6 +89,38 @@ func (s *Server) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.G
 	s.Team.GetTeamGet(w, r, params)
 }
 
+func (s *Server) GetTeamSettings(w http.ResponseWriter, r *http.Request, params api.GetTeamSettingsParams) {
+	s.Team.GetTeamSettings(w, r, params)
+}
+
+func (s *Server) PostTeamSettings(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamSettings(w, r)
+}
+
+func (s *Server) PostTeamSync(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamSync(w, r)
+}
+
+func (s *Server) PostTeamMembersAdd(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamMembersAdd(w, r)
+}
+
+func (s *Server) PostTeamMembersRemove(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamMembersRemove(w, r)
+}
+
+func (s *Server) PostTeamMembersMove(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamMembersMove(w, r)
+}
+
+func (s *Server) PostTeamRename(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamRename(w, r)
+}
+
+func (s *Server) PostTeamDelete(w http.ResponseWriter, r *http.Request) {
+	s.Team.PostTeamDelete(w, r)
+}
+
 func (s *Server) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
 	s.User.GetUsersGetReview(w, r, params)
 }
@@ -50,3 +128,71 @@ func (s *Server) GetUsersGetReview(w http.ResponseWriter, r *http.Request, param
 func (s *Server) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 	s.User.PostUsersSetIsActive(w, r)
 }
+
+func (s *Server) PostUsersBulkDeactivate(w http.ResponseWriter, r *http.Request) {
+	s.User.PostUsersBulkDeactivate(w, r)
+}
+
+func (s *Server) GetUsersAvailability(w http.ResponseWriter, r *http.Request, params api.GetUsersAvailabilityParams) {
+	s.User.GetUsersAvailability(w, r, params)
+}
+
+func (s *Server) PostUsersAvailabilityAdd(w http.ResponseWriter, r *http.Request) {
+	s.User.PostUsersAvailabilityAdd(w, r)
+}
+
+func (s *Server) PostUsersAvailabilityDelete(w http.ResponseWriter, r *http.Request) {
+	s.User.PostUsersAvailabilityDelete(w, r)
+}
+
+func (s *Server) GetStats(w http.ResponseWriter, r *http.Request, params api.GetStatsParams) {
+	s.Stats.GetStats(w, r, params)
+}
+
+func (s *Server) GetStatsTeam(w http.ResponseWriter, r *http.Request, params api.GetStatsTeamParams) {
+	s.Stats.GetStatsTeam(w, r, params)
+}
+
+func (s *Server) GetStatsUser(w http.ResponseWriter, r *http.Request, params api.GetStatsUserParams) {
+	s.Stats.GetStatsUser(w, r, params)
+}
+
+func (s *Server) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+	s.Webhook.PostWebhooksAdd(w, r)
+}
+
+func (s *Server) GetWebhooksList(w http.ResponseWriter, r *http.Request, params api.GetWebhooksListParams) {
+	s.Webhook.GetWebhooksList(w, r, params)
+}
+
+func (s *Server) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+	s.Webhook.PostWebhooksDelete(w, r)
+}
+
+func (s *Server) PostIngestGithub(w http.ResponseWriter, r *http.Request, params api.PostIngestGithubParams) {
+	s.Ingest.PostIngestGithub(w, r, params)
+}
+
+func (s *Server) PostIngestGitlab(w http.ResponseWriter, r *http.Request, params api.PostIngestGitlabParams) {
+	s.Ingest.PostIngestGitlab(w, r, params)
+}
+
+func (s *Server) PostAuthTokenIssue(w http.ResponseWriter, r *http.Request) {
+	s.Auth.PostAuthTokenIssue(w, r)
+}
+
+func (s *Server) PostAuthTokenRevoke(w http.ResponseWriter, r *http.Request) {
+	s.Auth.PostAuthTokenRevoke(w, r)
+}
+
+func (s *Server) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	s.Health.GetHealthz(w, r)
+}
+
+func (s *Server) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	s.Health.GetReadyz(w, r)
+}
+
+func (s *Server) GetVersion(w http.ResponseWriter, r *http.Request) {
+	s.Health.GetVersion(w, r)
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// TokenRole роль владельца токена
+type TokenRole string
+
+// Роли токенов
+const (
+	RoleAdmin TokenRole = "admin"
+	RoleUser  TokenRole = "user"
+)
+
+// tokenSize количество случайных байт в токене
+const tokenSize = 32
+
+// ApiToken выпущенный токен доступа, сам токен не хранится
+type ApiToken struct {
+	ID   int
+	Role TokenRole
+	// UserID пользователь токена, обязателен для RoleUser
+	UserID    *int
+	Hash      string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// IssueToken domain запрос на выпуск токена
+type IssueToken struct {
+	Role   TokenRole
+	UserID *int
+}
+
+// IssuedToken выпущенный токен вместе с его значением, которое показывается один раз
+type IssuedToken struct {
+	Token    string
+	ApiToken *ApiToken
+}
+
+// Actor владелец токена, выполняющий запрос
+type Actor struct {
+	TokenID int
+	Role    TokenRole
+	UserID  *int
+}
+
+type actorKey struct{}
+
+// WithActor сохраняет владельца токена в контексте запроса
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает владельца токена запроса, nil для запросов без токена
+func ActorFromContext(ctx context.Context) *Actor {
+	actor, _ := ctx.Value(actorKey{}).(*Actor)
+	return actor
+}
+
+// ActorUserID пользователь, выполняющий запрос, для записи в историю назначений
+func ActorUserID(ctx context.Context) *int {
+	if actor := ActorFromContext(ctx); actor != nil && actor.UserID != nil {
+		id := *actor.UserID
+		return &id
+	}
+	return nil
+}
+
+// CheckActorUser запрещает токену роли user действовать за другого пользователя
+func CheckActorUser(ctx context.Context, userID int) error {
+	actor := ActorFromContext(ctx)
+	if actor == nil || actor.Role != RoleUser {
+		return nil
+	}
+	if actor.UserID == nil || *actor.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// GenerateToken возвращает новый случайный токен
+func GenerateToken() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken SHA-256 токена в hex, под которым он хранится
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIToDomainIssueToken маппит api PostAuthTokenIssueJSONRequestBody в domain IssueToken
+func APIToDomainIssueToken(req api.PostAuthTokenIssueJSONRequestBody) *IssueToken {
+	issue := &IssueToken{Role: TokenRole(req.Role)}
+	if req.UserId != nil {
+		userID, _ := strconv.Atoi((*req.UserId)[1:])
+		issue.UserID = &userID
+	}
+	return issue
+}
+
+// APIToDomainTokenID маппит api token_id в id токена
+func APIToDomainTokenID(tokenID string) int {
+	id, _ := strconv.Atoi(tokenID[4:])
+	return id
+}
+
+// TokenResponse возвращаемое значение
+type TokenResponse struct {
+	ApiToken api.ApiToken `json:"api_token"`
+}
+
+// IssuedTokenResponse возвращаемое значение, token показывается один раз
+type IssuedTokenResponse struct {
+	Token    string       `json:"token"`
+	ApiToken api.ApiToken `json:"api_token"`
+}
+
+// DomainApiTokenToAPI маппит domain ApiToken в api ApiToken
+func DomainApiTokenToAPI(t *ApiToken) api.ApiToken {
+	var userID *string
+	if t.UserID != nil {
+		id := fmt.Sprintf("u%d", *t.UserID)
+		userID = &id
+	}
+	return api.ApiToken{
+		TokenId:   fmt.Sprintf("tok-%d", t.ID),
+		Role:      api.TokenRole(t.Role),
+		UserId:    userID,
+		CreatedAt: t.CreatedAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// AvailabilityWindow окно недоступности пользователя [From, To), время в UTC
+type AvailabilityWindow struct {
+	ID     int
+	UserID int
+	From   time.Time
+	To     time.Time
+	// Reason отпуск, больничный и т.п.
+	Reason    *string
+	CreatedAt time.Time
+}
+
+// UserAvailabilityResponse ответ с окнами недоступности пользователя
+type UserAvailabilityResponse struct {
+	UserID  string                   `json:"user_id"`
+	Windows []api.AvailabilityWindow `json:"windows"`
+}
+
+// AvailabilityWindowResponse ответ с окном недоступности
+type AvailabilityWindowResponse struct {
+	Window api.AvailabilityWindow `json:"window"`
+}
+
+// APIToDomainAvailabilityWindow маппит api PostUsersAvailabilityAddJSONRequestBody в domain AvailabilityWindow
+func APIToDomainAvailabilityWindow(req api.PostUsersAvailabilityAddJSONRequestBody) *AvailabilityWindow {
+	userID, _ := strconv.Atoi(req.UserId[1:])
+	return &AvailabilityWindow{
+		UserID: userID,
+		From:   req.From.UTC(),
+		To:     req.To.UTC(),
+		Reason: req.Reason,
+	}
+}
+
+// APIToDomainAvailabilityUserID маппит api GetUsersAvailabilityParams в id пользователя
+func APIToDomainAvailabilityUserID(params api.GetUsersAvailabilityParams) int {
+	userID, _ := strconv.Atoi(params.UserId[1:])
+	return userID
+}
+
+// APIToDomainAvailabilityWindowID маппит api window_id вида av-<N> в id окна
+func APIToDomainAvailabilityWindowID(id string) int {
+	windowID, _ := strconv.Atoi(id[3:])
+	return windowID
+}
+
+// DomainAvailabilityWindowToAPI маппит domain AvailabilityWindow в api AvailabilityWindow
+func DomainAvailabilityWindowToAPI(w *AvailabilityWindow) api.AvailabilityWindow {
+	return api.AvailabilityWindow{
+		WindowId:  fmt.Sprintf("av-%d", w.ID),
+		UserId:    fmt.Sprintf("u%d", w.UserID),
+		From:      w.From,
+		To:        w.To,
+		Reason:    w.Reason,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// DomainUserAvailabilityToAPI маппит окна недоступности пользователя в ответ API
+func DomainUserAvailabilityToAPI(userID int, windows []AvailabilityWindow) UserAvailabilityResponse {
+	windowsAPI := make([]api.AvailabilityWindow, 0, len(windows))
+	for i := range windows {
+		windowsAPI = append(windowsAPI, DomainAvailabilityWindowToAPI(&windows[i]))
+	}
+	return UserAvailabilityResponse{
+		UserID:  fmt.Sprintf("u%d", userID),
+		Windows: windowsAPI,
+	}
+}
@@ -10,11 +10,60 @@ var (
 	ErrTeamEmptyMembers = errors.New("team members cannot be empty")
 	ErrTeamExists       = errors.New("team_name already exists")
 	ErrTeamNotFound     = errors.New("team not found")
+	ErrInvalidStrategy  = errors.New("invalid reviewer strategy")
+	ErrInvalidSettings  = errors.New("invalid team settings")
+	ErrUserInOtherTeam  = errors.New("user belongs to another team")
+	ErrNotTeamMember    = errors.New("user is not a member of the team")
 )
 
 // Ошибки для User
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrInvalidAvailability        = errors.New("invalid availability window")
+	ErrAvailabilityWindowNotFound = errors.New("availability window not found")
+)
+
+// Ошибки для Webhook
+var (
+	ErrInvalidWebhook  = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+)
+
+// Ошибки для токенов доступа
+var (
+	ErrUnauthorized  = errors.New("missing or invalid access token")
+	ErrForbidden     = errors.New("access token role is not allowed")
+	ErrInvalidToken  = errors.New("invalid access token request")
+	ErrTokenNotFound = errors.New("access token not found")
+)
+
+// Ошибки для приема событий GitHub/GitLab
+var (
+	ErrInvalidSignature    = errors.New("invalid webhook signature")
+	ErrInvalidPayload      = errors.New("invalid webhook payload")
+	ErrUnknownExternalUser = errors.New("external user is not mapped")
+	// ErrExternalPullRequestLinked внешний PR уже связан с PullRequest параллельной доставкой
+	ErrExternalPullRequestLinked = errors.New("external pull request is already linked")
+)
+
+// Ошибки для статистики
+var (
+	ErrInvalidTimeWindow = errors.New("time window start must be before end")
+)
+
+// Ошибки для постраничных списков
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("invalid page limit")
+)
+
+// Ошибки для проверок готовности, подробности пишутся только в лог
+var (
+	ErrShuttingDown        = errors.New("service is shutting down")
+	ErrDatabaseUnavailable = errors.New("database is unavailable")
+	ErrSchemaUnknown       = errors.New("schema version is unavailable")
+	ErrSchemaDirty         = errors.New("schema migration is dirty")
+	ErrSchemaOutdated      = errors.New("schema version is behind the build")
 )
 
 // Ошибки для PullRequest
@@ -25,4 +74,11 @@ var (
 	ErrNoAvailableCandidats = errors.New("no active replacement candidate in team")
 	ErrPullRequestIsMerged  = errors.New("pull_request merged already")
 	ErrNotAssigned          = errors.New("reviewer is not assigned to this PR")
+	ErrNotEnoughReviewers   = errors.New("not enough active reviewers in team")
+	ErrPullRequestIsClosed  = errors.New("pull_request closed")
+	ErrInvalidTransition    = errors.New("pull_request status transition is not allowed")
+	ErrInvalidVerdict       = errors.New("invalid review verdict")
+	ErrNotApproved          = errors.New("pull_request does not satisfy team approval policy")
+	// ErrRoundRobinMoved очередь ROUND_ROBIN сдвинута после того, как по ней выбрали ревьюверов
+	ErrRoundRobinMoved = errors.New("round robin position moved concurrently")
 )
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// EscalationPolicy действие с просроченным ревью
+type EscalationPolicy string
+
+// Политики эскалации просроченных ревью
+const (
+	// EscalationNotify только событие pull_request.review_stale
+	EscalationNotify EscalationPolicy = "NOTIFY"
+	// EscalationAddReviewer назначить дополнительного ревьювера
+	EscalationAddReviewer EscalationPolicy = "ADD_REVIEWER"
+	// EscalationReassign заменить просрочившего ревьювера
+	EscalationReassign EscalationPolicy = "REASSIGN"
+)
+
+// MapStringToEscalationPolicy маппинг string в domain EscalationPolicy
+var MapStringToEscalationPolicy = map[string]EscalationPolicy{
+	"NOTIFY":       EscalationNotify,
+	"ADD_REVIEWER": EscalationAddReviewer,
+	"REASSIGN":     EscalationReassign,
+}
+
+// ReviewSLA сколько ревьювер может молчать и что делать после
+type ReviewSLA struct {
+	// ReviewSLAMinutes 0 - ревью команды не эскалируются
+	ReviewSLAMinutes int
+	EscalationPolicy EscalationPolicy
+}
+
+// StaleReview назначение ревьювера OPEN PullRequest без вердикта дольше SLA команды автора
+type StaleReview struct {
+	PullRequestID int
+	ReviewerID    int
+	AuthorID      int
+	Policy        EscalationPolicy
+	// Since с какого момента ревьювер назначен: последнее назначение или переоткрытие PR
+	Since time.Time
+}
+
+// ReviewEscalation запись об эскалации просроченного ревью
+type ReviewEscalation struct {
+	ID            int
+	PullRequestID int
+	ReviewerID    int
+	AuthorID      int
+	Policy        EscalationPolicy
+	// Action выполненное действие: отличается от Policy, если кандидатов не нашлось
+	Action        EscalationPolicy
+	NewReviewerID *int
+	StaleSince    time.Time
+	CreatedAt     time.Time
+}
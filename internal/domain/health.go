@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"pr-reviewer/internal/api"
+)
+
+// ReadinessCheckName проверка готовности сервиса
+type ReadinessCheckName string
+
+const (
+	ReadinessDatabase   ReadinessCheckName = "database"
+	ReadinessMigrations ReadinessCheckName = "migrations"
+	ReadinessShutdown   ReadinessCheckName = "shutdown"
+)
+
+// readinessErrors причины неготовности, которые /readyz отдает как есть;
+// остальные ошибки заменяются errReadinessFailed
+var readinessErrors = []error{
+	ErrShuttingDown, ErrDatabaseUnavailable, ErrSchemaUnknown, ErrSchemaDirty, ErrSchemaOutdated,
+}
+
+var errReadinessFailed = errors.New("check failed")
+
+// ReadinessCheck результат одной проверки, Err == nil - проверка пройдена
+type ReadinessCheck struct {
+	Name ReadinessCheckName
+	Err  error
+}
+
+// Readiness результаты проверок готовности
+type Readiness struct {
+	Checks []ReadinessCheck
+}
+
+// Ready готов ли сервис принимать запросы
+func (r *Readiness) Ready() bool {
+	for _, c := range r.Checks {
+		if c.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SchemaVersion версия схемы БД по таблице миграций
+type SchemaVersion struct {
+	Version int
+	// Dirty миграция упала на середине
+	Dirty bool
+}
+
+// BuildInfo данные сборки
+type BuildInfo struct {
+	Commit    string
+	BuildTime string
+	GoVersion string
+}
+
+// DomainReadinessToAPI маппит domain Readiness в api Readiness
+func DomainReadinessToAPI(r *Readiness) api.Readiness {
+	status := api.Ready
+	if !r.Ready() {
+		status = api.NotReady
+	}
+
+	checks := make([]api.ReadinessCheck, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		check := api.ReadinessCheck{Name: api.ReadinessCheckName(c.Name), Ok: c.Err == nil}
+		if c.Err != nil {
+			msg := readinessMessage(c.Err)
+			check.Error = &msg
+		}
+		checks = append(checks, check)
+	}
+
+	return api.Readiness{Status: status, Checks: checks}
+}
+
+// readinessMessage фиксированный текст причины неготовности без подробностей ошибки
+func readinessMessage(err error) string {
+	for _, known := range readinessErrors {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return errReadinessFailed.Error()
+}
+
+// DomainBuildInfoToAPI маппит domain BuildInfo в api BuildInfo
+func DomainBuildInfoToAPI(b BuildInfo) api.BuildInfo {
+	return api.BuildInfo{
+		Commit:    b.Commit,
+		BuildTime: b.BuildTime,
+		GoVersion: b.GoVersion,
+	}
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"time"
+)
+
+// AssignmentReason причина события в журнале назначений
+type AssignmentReason string
+
+// Причины событий журнала назначений
+const (
+	ReasonAssigned    AssignmentReason = "ASSIGNED"
+	ReasonReassigned  AssignmentReason = "REASSIGNED"
+	ReasonDeactivated AssignmentReason = "DEACTIVATED"
+	ReasonMerged      AssignmentReason = "MERGED"
+	ReasonClosed      AssignmentReason = "CLOSED"
+	ReasonReopened    AssignmentReason = "REOPENED"
+	// ReasonActivated назначенный ревьювер снова активен
+	ReasonActivated AssignmentReason = "ACTIVATED"
+	// ReasonTeamChanged ревьювер заменен, потому что ушел из команды автора
+	ReasonTeamChanged AssignmentReason = "TEAM_CHANGED"
+	// ReasonEscalated ревьювер добавлен или заменен эскалацией просроченного ревью
+	ReasonEscalated AssignmentReason = "ESCALATED"
+)
+
+// MapStringToAssignmentReason маппинг string в domain AssignmentReason
+var MapStringToAssignmentReason = map[string]AssignmentReason{
+	"ASSIGNED":     ReasonAssigned,
+	"REASSIGNED":   ReasonReassigned,
+	"DEACTIVATED":  ReasonDeactivated,
+	"ACTIVATED":    ReasonActivated,
+	"MERGED":       ReasonMerged,
+	"CLOSED":       ReasonClosed,
+	"REOPENED":     ReasonReopened,
+	"TEAM_CHANGED": ReasonTeamChanged,
+	"ESCALATED":    ReasonEscalated,
+}
+
+// AssignmentEvent запись журнала назначений PullRequest
+type AssignmentEvent struct {
+	PullRequestID int
+	// ActorID кто выполнил действие, nil - система или токен без пользователя
+	ActorID *int
+	// OldReviewerID снятый ревьювер, NewReviewerID назначенный;
+	// для смены статуса оба nil
+	OldReviewerID *int
+	NewReviewerID *int
+	Reason        AssignmentReason
+	CreatedAt     time.Time
+}
+
+// PullRequestHistory ответ с журналом назначений PullRequest
+type PullRequestHistory struct {
+	PullRequestID string                `json:"pull_request_id"`
+	Events        []api.AssignmentEvent `json:"events"`
+}
+
+// NewAssignedEvents события ASSIGNED для каждого из reviewers
+func NewAssignedEvents(prID int, reviewers []int, actorID *int, at time.Time) []AssignmentEvent {
+	events := make([]AssignmentEvent, 0, len(reviewers))
+	for _, id := range reviewers {
+		reviewerID := id
+		events = append(events, AssignmentEvent{
+			PullRequestID: prID,
+			ActorID:       actorID,
+			NewReviewerID: &reviewerID,
+			Reason:        ReasonAssigned,
+			CreatedAt:     at,
+		})
+	}
+	return events
+}
+
+// NewStatusEvent событие смены статуса PullRequest без изменения ревьюверов
+func NewStatusEvent(prID int, reason AssignmentReason, actorID *int, at time.Time) AssignmentEvent {
+	return AssignmentEvent{
+		PullRequestID: prID,
+		ActorID:       actorID,
+		Reason:        reason,
+		CreatedAt:     at,
+	}
+}
+
+// NewReplacementEvent событие замены ревьювера oldID на newID
+func NewReplacementEvent(prID, oldID, newID int, reason AssignmentReason, actorID *int, at time.Time) AssignmentEvent {
+	return AssignmentEvent{
+		PullRequestID: prID,
+		ActorID:       actorID,
+		OldReviewerID: &oldID,
+		NewReviewerID: &newID,
+		Reason:        reason,
+		CreatedAt:     at,
+	}
+}
+
+// NewAddedEvent событие назначения дополнительного ревьювера newID
+func NewAddedEvent(prID, newID int, reason AssignmentReason, actorID *int, at time.Time) AssignmentEvent {
+	return AssignmentEvent{
+		PullRequestID: prID,
+		ActorID:       actorID,
+		NewReviewerID: &newID,
+		Reason:        reason,
+		CreatedAt:     at,
+	}
+}
+
+// NewActivityEvents события смены активности ревьювера userID на PullRequest prIDs без замены:
+// ACTIVATED с новым ревьювером или DEACTIVATED с прежним
+func NewActivityEvents(prIDs []int, userID int, isActive bool, actorID *int, at time.Time) []AssignmentEvent {
+	events := make([]AssignmentEvent, 0, len(prIDs))
+	for _, prID := range prIDs {
+		reviewerID := userID
+		e := AssignmentEvent{
+			PullRequestID: prID,
+			ActorID:       actorID,
+			Reason:        ReasonDeactivated,
+			OldReviewerID: &reviewerID,
+			CreatedAt:     at,
+		}
+		if isActive {
+			e.Reason, e.OldReviewerID, e.NewReviewerID = ReasonActivated, nil, &reviewerID
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// DomainPullRequestHistoryToAPI маппит журнал назначений PullRequest в ответ API
+func DomainPullRequestHistoryToAPI(prID int, events []AssignmentEvent) PullRequestHistory {
+	toUserID := func(id *int) *string {
+		if id == nil {
+			return nil
+		}
+		userID := fmt.Sprintf("u%d", *id)
+		return &userID
+	}
+
+	eventsAPI := make([]api.AssignmentEvent, 0, len(events))
+	for _, e := range events {
+		eventsAPI = append(eventsAPI, api.AssignmentEvent{
+			ActorId:       toUserID(e.ActorID),
+			OldReviewerId: toUserID(e.OldReviewerID),
+			NewReviewerId: toUserID(e.NewReviewerID),
+			Reason:        api.AssignmentReason(e.Reason),
+			CreatedAt:     e.CreatedAt,
+		})
+	}
+
+	return PullRequestHistory{
+		PullRequestID: fmt.Sprintf("pr-%d", prID),
+		Events:        eventsAPI,
+	}
+}
@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"strings"
+)
+
+// ExternalProvider внешний сервис, присылающий события PullRequest
+type ExternalProvider string
+
+// Внешние сервисы
+const (
+	ProviderGitHub ExternalProvider = "github"
+	ProviderGitLab ExternalProvider = "gitlab"
+)
+
+// События внешних сервисов, которые принимает сервис
+const (
+	GitHubPullRequestEvent  = "pull_request"
+	GitLabMergeRequestEvent = "Merge Request Hook"
+)
+
+// ExternalAction действие над PullRequest во внешнем сервисе
+type ExternalAction string
+
+// Действия над внешним PullRequest
+const (
+	ExternalOpened   ExternalAction = "OPENED"
+	ExternalReopened ExternalAction = "REOPENED"
+	ExternalMerged   ExternalAction = "MERGED"
+	ExternalClosed   ExternalAction = "CLOSED"
+)
+
+// IngestOutcome результат обработки события внешнего сервиса
+type IngestOutcome string
+
+// Результаты обработки события
+const (
+	IngestCreated  IngestOutcome = "created"
+	IngestReopened IngestOutcome = "reopened"
+	IngestMerged   IngestOutcome = "merged"
+	IngestClosed   IngestOutcome = "closed"
+	IngestIgnored  IngestOutcome = "ignored"
+)
+
+// IngestDelivery запрос внешнего сервиса: тип события, подпись и сырое тело
+type IngestDelivery struct {
+	Provider ExternalProvider
+	Event    string
+	// Signature X-Hub-Signature-256 для GitHub, X-Gitlab-Token для GitLab
+	Signature string
+	Body      []byte
+}
+
+// ExternalPullRequestRef ключ PullRequest во внешнем сервисе
+type ExternalPullRequestRef struct {
+	Provider   ExternalProvider
+	Repository string
+	Number     int
+}
+
+// ExternalPullRequestEvent событие внешнего PullRequest, приведенное к общему виду
+type ExternalPullRequestEvent struct {
+	Provider   ExternalProvider
+	Repository string
+	Number     int
+	Title      string
+	// Author автор во внешнем сервисе: логин на GitHub, числовой id пользователя на GitLab
+	Author string
+	// Action пустой, если действие не обрабатывается
+	Action ExternalAction
+}
+
+// IngestResult результат обработки события, PullRequest nil для ignored
+type IngestResult struct {
+	Outcome     IngestOutcome
+	PullRequest *PullRequest
+}
+
+// IngestConfig секреты внешних сервисов и соответствие их логинов пользователям
+type IngestConfig struct {
+	GitHubSecret string
+	GitLabToken  string
+	Users        ExternalUserMap
+}
+
+// ExternalUserMap авторы во внешних сервисах -> id пользователей
+type ExternalUserMap map[ExternalProvider]map[string]int
+
+// ParseExternalUserMap разбирает соответствие авторов в формате
+// "github:octocat=u1,gitlab:42=u2": логин на GitHub, id пользователя на GitLab
+func ParseExternalUserMap(s string) (ExternalUserMap, error) {
+	users := ExternalUserMap{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		account, userID, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid user mapping %q: expected provider:login=u<N>", entry)
+		}
+		provider, login, ok := strings.Cut(account, ":")
+		if !ok || login == "" {
+			return nil, fmt.Errorf("invalid user mapping %q: expected provider:login=u<N>", entry)
+		}
+
+		p := ExternalProvider(provider)
+		if p != ProviderGitHub && p != ProviderGitLab {
+			return nil, fmt.Errorf("invalid user mapping %q: unknown provider %s", entry, provider)
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(userID, "u"))
+		if !strings.HasPrefix(userID, "u") || err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid user mapping %q: invalid user_id %s", entry, userID)
+		}
+
+		if users[p] == nil {
+			users[p] = map[string]int{}
+		}
+		users[p][login] = id
+	}
+	return users, nil
+}
+
+// Lookup возвращает id пользователя для автора во внешнем сервисе
+func (m ExternalUserMap) Lookup(provider ExternalProvider, login string) (int, bool) {
+	id, ok := m[provider][login]
+	return id, ok
+}
+
+// VerifyGitHubSignature проверяет X-Hub-Signature-256: sha256=<hex HMAC-SHA256 тела>
+func VerifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(SignWebhook(secret, body)), []byte(signature))
+}
+
+// VerifyGitLabToken проверяет X-Gitlab-Token: GitLab передает секрет как есть
+func VerifyGitLabToken(secret string, token string) bool {
+	if secret == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// gitHubPullRequestPayload нужные поля события pull_request GitHub
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseGitHubPullRequestEvent разбирает событие pull_request GitHub
+func ParseGitHubPullRequestEvent(body []byte) (*ExternalPullRequestEvent, error) {
+	var payload gitHubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	event := &ExternalPullRequestEvent{
+		Provider:   ProviderGitHub,
+		Repository: payload.Repository.FullName,
+		Number:     payload.PullRequest.Number,
+		Title:      payload.PullRequest.Title,
+		Author:     payload.PullRequest.User.Login,
+	}
+
+	switch payload.Action {
+	case "opened":
+		event.Action = ExternalOpened
+	case "reopened":
+		event.Action = ExternalReopened
+	case "closed":
+		event.Action = ExternalClosed
+		if payload.PullRequest.Merged {
+			event.Action = ExternalMerged
+		}
+	}
+
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// gitLabMergeRequestPayload нужные поля события Merge Request Hook GitLab
+type gitLabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		Action   string `json:"action"`
+		AuthorID int    `json:"author_id"`
+	} `json:"object_attributes"`
+}
+
+// ParseGitLabMergeRequestEvent разбирает событие Merge Request Hook GitLab;
+// автор - создатель merge request (object_attributes.author_id), а не вызвавший событие
+func ParseGitLabMergeRequestEvent(body []byte) (*ExternalPullRequestEvent, error) {
+	var payload gitLabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if payload.ObjectKind != "merge_request" {
+		return nil, fmt.Errorf("%w: unexpected object_kind %q", ErrInvalidPayload, payload.ObjectKind)
+	}
+
+	event := &ExternalPullRequestEvent{
+		Provider:   ProviderGitLab,
+		Repository: payload.Project.PathWithNamespace,
+		Number:     payload.ObjectAttributes.IID,
+		Title:      payload.ObjectAttributes.Title,
+	}
+	if payload.ObjectAttributes.AuthorID > 0 {
+		event.Author = strconv.Itoa(payload.ObjectAttributes.AuthorID)
+	}
+
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		event.Action = ExternalOpened
+	case "reopen":
+		event.Action = ExternalReopened
+	case "merge":
+		event.Action = ExternalMerged
+	case "close":
+		event.Action = ExternalClosed
+	}
+
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Ref ключ внешнего PullRequest события
+func (e *ExternalPullRequestEvent) Ref() *ExternalPullRequestRef {
+	return &ExternalPullRequestRef{Provider: e.Provider, Repository: e.Repository, Number: e.Number}
+}
+
+func (e *ExternalPullRequestEvent) validate() error {
+	if e.Repository == "" || e.Number <= 0 {
+		return fmt.Errorf("%w: repository and number are required", ErrInvalidPayload)
+	}
+	opens := e.Action == ExternalOpened || e.Action == ExternalReopened
+	if opens && (e.Title == "" || e.Author == "") {
+		return fmt.Errorf("%w: title and author are required", ErrInvalidPayload)
+	}
+	return nil
+}
+
+// DomainIngestResultToAPI маппит domain IngestResult в api IngestResult
+func DomainIngestResultToAPI(res *IngestResult) api.IngestResult {
+	resp := api.IngestResult{Action: api.IngestAction(res.Outcome)}
+	if res.PullRequest != nil {
+		pr := DomainPRToAPI(res.PullRequest)
+		resp.Pr = &pr
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExternalUserMap(t *testing.T) {
+	users, err := ParseExternalUserMap("github:octocat=u1, gitlab:42=u2,,github:hubot=u3")
+	require.NoError(t, err)
+
+	id, ok := users.Lookup(ProviderGitHub, "octocat")
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	id, ok = users.Lookup(ProviderGitLab, "42")
+	assert.True(t, ok)
+	assert.Equal(t, 2, id)
+
+	_, ok = users.Lookup(ProviderGitLab, "octocat")
+	assert.False(t, ok)
+
+	empty, err := ParseExternalUserMap("")
+	require.NoError(t, err)
+	_, ok = empty.Lookup(ProviderGitHub, "octocat")
+	assert.False(t, ok)
+
+	for _, bad := range []string{"octocat=u1", "github:octocat", "bitbucket:octocat=u1", "github:octocat=1", "github:=u1"} {
+		_, err := ParseExternalUserMap(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := "sha256=6146142a2ce0159e84c0767881e4ec80bc397da62526e7d19f70795eb79460c0"
+
+	assert.True(t, VerifyGitHubSignature("secret", body, signature))
+	assert.False(t, VerifyGitHubSignature("other", body, signature))
+	assert.False(t, VerifyGitHubSignature("secret", body, ""))
+	assert.False(t, VerifyGitHubSignature("", body, SignWebhook("", body)))
+}
+
+func TestVerifyGitLabToken(t *testing.T) {
+	assert.True(t, VerifyGitLabToken("token", "token"))
+	assert.False(t, VerifyGitLabToken("token", "tokem"))
+	assert.False(t, VerifyGitLabToken("", ""))
+}
+
+func TestParseGitHubPullRequestEvent(t *testing.T) {
+	payload := func(action string, merged bool) []byte {
+		m := "false"
+		if merged {
+			m = "true"
+		}
+		return []byte(`{"action":"` + action + `","pull_request":{"number":42,"title":"Add search","merged":` + m +
+			`,"user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`)
+	}
+
+	tests := []struct {
+		name   string
+		body   []byte
+		action ExternalAction
+	}{
+		{"opened", payload("opened", false), ExternalOpened},
+		{"reopened", payload("reopened", false), ExternalReopened},
+		{"closed merged", payload("closed", true), ExternalMerged},
+		{"closed", payload("closed", false), ExternalClosed},
+		{"labeled", payload("labeled", false), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := ParseGitHubPullRequestEvent(tt.body)
+			require.NoError(t, err)
+			assert.Equal(t, &ExternalPullRequestEvent{
+				Provider:   ProviderGitHub,
+				Repository: "acme/api",
+				Number:     42,
+				Title:      "Add search",
+				Author:     "octocat",
+				Action:     tt.action,
+			}, ev)
+		})
+	}
+
+	_, err := ParseGitHubPullRequestEvent([]byte(`{"action":"opened"}`))
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+
+	_, err = ParseGitHubPullRequestEvent([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestParseGitLabMergeRequestEvent(t *testing.T) {
+	// Автор - создатель merge request, а не пользователь, вызвавший событие
+	body := []byte(`{"object_kind":"merge_request","user":{"id":8,"username":"maintainer"},
+		"project":{"path_with_namespace":"acme/web"},
+		"object_attributes":{"iid":7,"title":"Fix login","action":"merge","author_id":42}}`)
+
+	ev, err := ParseGitLabMergeRequestEvent(body)
+	require.NoError(t, err)
+	assert.Equal(t, &ExternalPullRequestEvent{
+		Provider:   ProviderGitLab,
+		Repository: "acme/web",
+		Number:     7,
+		Title:      "Fix login",
+		Author:     "42",
+		Action:     ExternalMerged,
+	}, ev)
+
+	_, err = ParseGitLabMergeRequestEvent([]byte(`{"object_kind":"push"}`))
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
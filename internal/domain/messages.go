@@ -10,12 +10,21 @@ const (
 
 // Messages Сообщения об ошибке, соответствующие ErrorResponseErrorCode
 var Messages = map[api.ErrorResponseErrorCode]string{
-	api.NOCANDIDATE: "no active replacement candidate in team",
-	api.NOTASSIGNED: "reviewer is not assigned to this PR",
-	api.TEAMEXISTS:  "team_name already exists",
-	api.NOTFOUND:    "resource not found",
-	api.PREXISTS:    "PR id already exists",
-	api.PRMERGED:    "cannot reassign on merged PR",
-	api.BADREQUEST:  "invalid body request",
-	api.INTERNAL:    "internal server error",
+	api.NOCANDIDATE:        "no active replacement candidate in team",
+	api.NOTASSIGNED:        "reviewer is not assigned to this PR",
+	api.TEAMEXISTS:         "team_name already exists",
+	api.NOTFOUND:           "resource not found",
+	api.PREXISTS:           "PR id already exists",
+	api.PRMERGED:           "cannot reassign on merged PR",
+	api.NOTENOUGHREVIEWERS: "not enough active reviewers in team",
+	api.PRCLOSED:           "cannot reassign on closed PR",
+	api.INVALIDTRANSITION:  "pull_request status transition is not allowed",
+	api.NOTAPPROVED:        "pull_request does not satisfy team approval policy",
+	api.INVALIDSIGNATURE:   "invalid webhook signature",
+	api.UNKNOWNUSER:        "external user is not mapped to a user",
+	api.UNAUTHORIZED:       "missing or invalid access token",
+	api.FORBIDDEN:          "access token role is not allowed",
+	api.USERINTEAM:         "user belongs to another team",
+	api.BADREQUEST:         "invalid body request",
+	api.INTERNAL:           "internal server error",
 }
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Размер страницы постраничных списков
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 100
+)
+
+// PullRequestSort порядок постраничного списка PullRequest
+type PullRequestSort string
+
+// Порядки списка PullRequest, при равном created_at - по id
+const (
+	SortCreatedAtDesc PullRequestSort = "created_at_desc"
+	SortCreatedAtAsc  PullRequestSort = "created_at_asc"
+)
+
+// MapStringToPullRequestSort маппинг string в domain PullRequestSort
+var MapStringToPullRequestSort = map[string]PullRequestSort{
+	"created_at_desc": SortCreatedAtDesc,
+	"created_at_asc":  SortCreatedAtAsc,
+}
+
+// PageCursor позиция страницы: порядок списка, для которого выдан курсор,
+// и created_at и id последнего PR предыдущей страницы
+type PageCursor struct {
+	Sort          PullRequestSort
+	CreatedAt     time.Time
+	PullRequestID int
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c *PageCursor) Encode() string {
+	raw := fmt.Sprintf("%s|%s|%d", c.Sort, c.CreatedAt.UTC().Format(time.RFC3339Nano), c.PullRequestID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodePageCursor разбирает курсор, полученный от клиента; курсор,
+// выданный для другого порядка, отклоняется
+func DecodePageCursor(s string, sort PullRequestSort) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || PullRequestSort(parts[0]) != sort {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &PageCursor{Sort: sort, CreatedAt: t, PullRequestID: id}, nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"pr-reviewer/internal/api"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := &PageCursor{Sort: SortCreatedAtAsc, CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 123456000, time.UTC), PullRequestID: 1001}
+
+	decoded, err := DecodePageCursor(cursor.Encode(), SortCreatedAtAsc)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	// Курсор другого порядка пропустил бы или повторил строки
+	_, err = DecodePageCursor(cursor.Encode(), SortCreatedAtDesc)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	for _, s := range []string{"", "not base64!", "MTAwMQ", "eHx5", "MjAyNi0xMC0wMVQxMjowMDowMFp8MTAwMQ"} {
+		_, err := DecodePageCursor(s, SortCreatedAtDesc)
+		assert.Equal(t, ErrInvalidCursor, err, s)
+	}
+}
+
+func TestAPIToDomainFiltersRejectInvalidCursor(t *testing.T) {
+	cursor := (&PageCursor{Sort: SortCreatedAtDesc, CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), PullRequestID: 1001}).Encode()
+	invalid := "not base64!"
+
+	filter, err := APIToDomainPullRequestFilter(api.GetPullRequestListParams{Cursor: &cursor})
+	assert.NoError(t, err)
+	assert.Equal(t, 1001, filter.Cursor.PullRequestID)
+
+	// Без валидации неверный курсор не превращается в первую страницу
+	_, err = APIToDomainPullRequestFilter(api.GetPullRequestListParams{Cursor: &invalid})
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	reviews, err := APIToDomainReviewFilter(api.GetUsersGetReviewParams{UserId: "u1", Cursor: &cursor})
+	assert.NoError(t, err)
+	assert.Equal(t, 1001, reviews.Cursor.PullRequestID)
+
+	_, err = APIToDomainReviewFilter(api.GetUsersGetReviewParams{UserId: "u1", Cursor: &invalid})
+	assert.Equal(t, ErrInvalidCursor, err)
+}
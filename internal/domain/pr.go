@@ -7,16 +7,21 @@ import (
 	"time"
 )
 
-// MaxReviewersNumber максимальное количество ревьюверов на PullRequest
-const MaxReviewersNumber = 2
+// Количество ревьюверов на PullRequest, если команда не задала своё
+const (
+	DefaultMinReviewers = 0
+	DefaultMaxReviewers = 2
+)
 
 // PullRequestStatus тип для статуса PullRequest
 type PullRequestStatus string
 
 // Статусы PullRequest
 const (
+	PRStatusDraft  PullRequestStatus = "DRAFT"
 	PRStatusOpen   PullRequestStatus = "OPEN"
 	PRStatusMerged PullRequestStatus = "MERGED"
+	PRStatusClosed PullRequestStatus = "CLOSED"
 )
 
 // PullRequest domain модель для PullRequest
@@ -26,8 +31,21 @@ type PullRequest struct {
 	AuthorID          int
 	Status            PullRequestStatus
 	AssignedReviewers []int
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	// FallbackReviewers ревьюверы из AssignedReviewers, назначенные из резервных команд
+	FallbackReviewers []int
+	// Reviews последний вердикт каждого назначенного ревьювера
+	Reviews []Review
+	// Verdict последний вердикт ревьювера, для которого загружен список PR
+	Verdict *ReviewVerdict
+	// AuthorTeamName и Reviewers заполняются при загрузке одного PR по id
+	AuthorTeamName string
+	// Reviewers назначенные ревьюверы в порядке AssignedReviewers
+	Reviewers []User
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	ClosedAt  *time.Time
+	// External внешний PR, из которого создается PullRequest; связь сохраняется вместе с ним
+	External *ExternalPullRequestRef
 }
 
 // CreatePullRequest domain модель для создания PullRequest
@@ -35,6 +53,10 @@ type CreatePullRequest struct {
 	PullRequestId int
 	Name          string
 	AuthorId      int
+	// Draft PR создается в статусе DRAFT без ревьюверов
+	Draft bool
+	// External PR из внешнего сервиса: id выдает последовательность, PullRequestId не используется
+	External *ExternalPullRequestRef
 }
 
 // APIToDomainPullRequestCreate маппит API запрос в domain CreatePullRequest
@@ -46,25 +68,32 @@ func APIToDomainPullRequestCreate(pr api.PostPullRequestCreateJSONRequestBody) *
 		Name:          pr.PullRequestName,
 		AuthorId:      authorId,
 		PullRequestId: prId,
+		Draft:         pr.Draft != nil && *pr.Draft,
 	}
 }
 
 // MapDomainStatusToAPI маппинг domain PullRequestStatus в api PullRequestStatus
 var MapDomainStatusToAPI = map[PullRequestStatus]api.PullRequestStatus{
+	PRStatusDraft:  api.PullRequestStatusDRAFT,
 	PRStatusOpen:   api.PullRequestStatusOPEN,
 	PRStatusMerged: api.PullRequestStatusMERGED,
+	PRStatusClosed: api.PullRequestStatusCLOSED,
 }
 
 // MapStringToPullRequestStatusShort маппинг domain PullRequestStatus в api PullRequestStatusShort
 var MapStringToPullRequestStatusShort = map[PullRequestStatus]api.PullRequestShortStatus{
+	PRStatusDraft:  api.PullRequestShortStatusDRAFT,
 	PRStatusOpen:   api.PullRequestShortStatusOPEN,
 	PRStatusMerged: api.PullRequestShortStatusMERGED,
+	PRStatusClosed: api.PullRequestShortStatusCLOSED,
 }
 
 // MapStringToPullRequestStatus маппинг string Status в domain PullRequestStatus
 var MapStringToPullRequestStatus = map[string]PullRequestStatus{
+	"DRAFT":  PRStatusDraft,
 	"OPEN":   PRStatusOpen,
 	"MERGED": PRStatusMerged,
+	"CLOSED": PRStatusClosed,
 }
 
 // PullRequestResponse возвращаемое значение
@@ -78,24 +107,78 @@ func DomainPRToAPI(pr *PullRequest) api.PullRequest {
 	for _, id := range pr.AssignedReviewers {
 		reviewers = append(reviewers, fmt.Sprintf("u%d", id))
 	}
+
+	var fallbackReviewers *[]string
+	if len(pr.FallbackReviewers) > 0 {
+		fallback := make([]string, 0, len(pr.FallbackReviewers))
+		for _, id := range pr.FallbackReviewers {
+			fallback = append(fallback, fmt.Sprintf("u%d", id))
+		}
+		fallbackReviewers = &fallback
+	}
+
+	var reviews *[]api.Review
+	if len(pr.Reviews) > 0 {
+		reviewsAPI := DomainReviewsToAPI(pr.Reviews)
+		reviews = &reviewsAPI
+	}
+
 	return api.PullRequest{
 		PullRequestId:     fmt.Sprintf("pr-%d", pr.ID),
 		AuthorId:          fmt.Sprintf("u%d", pr.AuthorID),
 		CreatedAt:         &pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
 		Status:            MapDomainStatusToAPI[pr.Status],
 		PullRequestName:   pr.Name,
 		AssignedReviewers: reviewers,
+		FallbackReviewers: fallbackReviewers,
+		Reviews:           reviews,
 	}
 }
 
+// DomainPRDetailsToAPI маппит domain PullRequest в api PullRequest вместе с
+// командой автора и ревьюверами
+func DomainPRDetailsToAPI(pr *PullRequest) api.PullRequest {
+	prAPI := DomainPRToAPI(pr)
+
+	if pr.AuthorTeamName != "" {
+		teamName := pr.AuthorTeamName
+		prAPI.AuthorTeamName = &teamName
+	}
+	reviewers := make([]api.PullRequestReviewer, 0, len(pr.Reviewers))
+	for _, u := range pr.Reviewers {
+		reviewers = append(reviewers, api.PullRequestReviewer{
+			UserId:   fmt.Sprintf("u%d", u.ID),
+			Username: u.Username,
+			IsActive: u.IsActive,
+		})
+	}
+	prAPI.Reviewers = &reviewers
+
+	return prAPI
+}
+
 // DomainPRToAPI маппит domain PullRequest в api PullRequestShort
 func DomainPRToAPIShort(pr *PullRequest) api.PullRequestShort {
+	var verdict *api.ReviewVerdict
+	if pr.Verdict != nil {
+		v := api.ReviewVerdict(*pr.Verdict)
+		verdict = &v
+	}
+
+	var createdAt *time.Time
+	if !pr.CreatedAt.IsZero() {
+		createdAt = &pr.CreatedAt
+	}
+
 	return api.PullRequestShort{
 		PullRequestId:   fmt.Sprintf("pr-%d", pr.ID),
 		PullRequestName: pr.Name,
 		AuthorId:        fmt.Sprintf("u%d", pr.AuthorID),
 		Status:          MapStringToPullRequestStatusShort[pr.Status],
+		Verdict:         verdict,
+		CreatedAt:       createdAt,
 	}
 }
 
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// PullRequestFilter фильтр, порядок и страница списка PullRequest;
+// пустые поля - без ограничения
+type PullRequestFilter struct {
+	Status     *PullRequestStatus
+	AuthorID   *int
+	ReviewerID *int
+	// TeamName команда автора PullRequest
+	TeamName *string
+	// Query подстрока названия без учета регистра
+	Query *string
+	// From, To окно по created_at PullRequest: [From, To)
+	From  *time.Time
+	To    *time.Time
+	Sort  PullRequestSort
+	Limit int
+	// Cursor nil для первой страницы
+	Cursor *PageCursor
+}
+
+// PullRequestPage страница списка PullRequest, NextCursor nil на последней странице
+type PullRequestPage struct {
+	PullRequests []PullRequest
+	NextCursor   *PageCursor
+}
+
+// PullRequestList ответ со страницей списка PullRequest
+type PullRequestList struct {
+	PullRequests []api.PullRequestShort `json:"pull_requests"`
+	NextCursor   *string                `json:"next_cursor,omitempty"`
+}
+
+// APIToDomainPullRequestFilter маппит api GetPullRequestListParams в domain PullRequestFilter;
+// неверный курсор - ErrInvalidCursor
+func APIToDomainPullRequestFilter(params api.GetPullRequestListParams) (*PullRequestFilter, error) {
+	filter := &PullRequestFilter{
+		TeamName: params.TeamName,
+		Query:    params.Q,
+		From:     params.From,
+		To:       params.To,
+		Sort:     SortCreatedAtDesc,
+		Limit:    DefaultPageLimit,
+	}
+
+	if params.Status != nil {
+		status := MapStringToPullRequestStatus[string(*params.Status)]
+		filter.Status = &status
+	}
+	if params.AuthorId != nil {
+		authorID, _ := strconv.Atoi((*params.AuthorId)[1:])
+		filter.AuthorID = &authorID
+	}
+	if params.ReviewerId != nil {
+		reviewerID, _ := strconv.Atoi((*params.ReviewerId)[1:])
+		filter.ReviewerID = &reviewerID
+	}
+	if params.Sort != nil {
+		filter.Sort = MapStringToPullRequestSort[string(*params.Sort)]
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		cursor, err := DecodePageCursor(*params.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+// DomainPullRequestPageToAPI маппит domain PullRequestPage в PullRequestList
+func DomainPullRequestPageToAPI(page *PullRequestPage) PullRequestList {
+	prs := make([]api.PullRequestShort, 0, len(page.PullRequests))
+	for _, pr := range page.PullRequests {
+		prAPI := DomainPRToAPIShort(&pr)
+		reviewers := make([]string, 0, len(pr.AssignedReviewers))
+		for _, id := range pr.AssignedReviewers {
+			reviewers = append(reviewers, fmt.Sprintf("u%d", id))
+		}
+		prAPI.AssignedReviewers = &reviewers
+		prs = append(prs, prAPI)
+	}
+
+	resp := PullRequestList{PullRequests: prs}
+	if page.NextCursor != nil {
+		cursor := page.NextCursor.Encode()
+		resp.NextCursor = &cursor
+	}
+	return resp
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "fmt"
+
+// PullRequestAction действие, меняющее статус PullRequest
+type PullRequestAction string
+
+// Действия над PullRequest
+const (
+	PRActionReady  PullRequestAction = "READY"
+	PRActionMerge  PullRequestAction = "MERGE"
+	PRActionClose  PullRequestAction = "CLOSE"
+	PRActionReopen PullRequestAction = "REOPEN"
+)
+
+// prTransitions допустимые переходы: статус -> действие -> новый статус
+var prTransitions = map[PullRequestStatus]map[PullRequestAction]PullRequestStatus{
+	PRStatusDraft: {
+		PRActionReady: PRStatusOpen,
+		PRActionClose: PRStatusClosed,
+	},
+	PRStatusOpen: {
+		PRActionMerge: PRStatusMerged,
+		PRActionClose: PRStatusClosed,
+	},
+	PRStatusClosed: {
+		PRActionReopen: PRStatusOpen,
+	},
+}
+
+// TransitionError недопустимый переход статуса PullRequest,
+// сравнивается с ErrInvalidTransition через errors.Is
+type TransitionError struct {
+	From   PullRequestStatus
+	Action PullRequestAction
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot %s pull_request in status %s", ErrInvalidTransition, e.Action, e.From)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// NextStatus возвращает статус PullRequest после действия action
+func NextStatus(from PullRequestStatus, action PullRequestAction) (PullRequestStatus, error) {
+	next, ok := prTransitions[from][action]
+	if !ok {
+		return from, &TransitionError{From: from, Action: action}
+	}
+	return next, nil
+}
+
+// CanTransition проверяет, допустимо ли действие action в статусе from
+func CanTransition(from PullRequestStatus, action PullRequestAction) bool {
+	_, ok := prTransitions[from][action]
+	return ok
+}
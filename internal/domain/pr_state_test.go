@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    PullRequestStatus
+		action  PullRequestAction
+		want    PullRequestStatus
+		wantErr bool
+	}{
+		{"draft ready", PRStatusDraft, PRActionReady, PRStatusOpen, false},
+		{"draft close", PRStatusDraft, PRActionClose, PRStatusClosed, false},
+		{"draft merge", PRStatusDraft, PRActionMerge, PRStatusDraft, true},
+		{"open merge", PRStatusOpen, PRActionMerge, PRStatusMerged, false},
+		{"open close", PRStatusOpen, PRActionClose, PRStatusClosed, false},
+		{"open ready", PRStatusOpen, PRActionReady, PRStatusOpen, true},
+		{"open reopen", PRStatusOpen, PRActionReopen, PRStatusOpen, true},
+		{"closed reopen", PRStatusClosed, PRActionReopen, PRStatusOpen, false},
+		{"closed merge", PRStatusClosed, PRActionMerge, PRStatusClosed, true},
+		{"merged close", PRStatusMerged, PRActionClose, PRStatusMerged, true},
+		{"merged reopen", PRStatusMerged, PRActionReopen, PRStatusMerged, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextStatus(tt.from, tt.action)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, !tt.wantErr, CanTransition(tt.from, tt.action))
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+
+			var trErr *TransitionError
+			require.True(t, errors.As(err, &trErr))
+			assert.Equal(t, tt.from, trErr.From)
+			assert.Equal(t, tt.action, trErr.Action)
+		})
+	}
+}
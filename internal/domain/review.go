@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// ReviewVerdict вердикт ревьювера по PullRequest
+type ReviewVerdict string
+
+// Вердикты ревьювера
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	VerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+// MapStringToReviewVerdict маппинг string в domain ReviewVerdict
+var MapStringToReviewVerdict = map[string]ReviewVerdict{
+	"APPROVED":          VerdictApproved,
+	"CHANGES_REQUESTED": VerdictChangesRequested,
+	"COMMENTED":         VerdictCommented,
+}
+
+// Review вердикт ревьювера с временем отправки
+type Review struct {
+	ReviewerID  int
+	Verdict     ReviewVerdict
+	SubmittedAt time.Time
+}
+
+// SubmitReview domain запрос на отправку вердикта
+type SubmitReview struct {
+	PullRequestID int
+	UserID        int
+	Verdict       ReviewVerdict
+}
+
+// APIToDomainSubmitReview маппит api PostPullRequestReviewJSONRequestBody в domain SubmitReview
+func APIToDomainSubmitReview(req api.PostPullRequestReviewJSONRequestBody) *SubmitReview {
+	prID, _ := strconv.Atoi(req.PullRequestId[3:])
+	userID, _ := strconv.Atoi(req.UserId[1:])
+	return &SubmitReview{
+		PullRequestID: prID,
+		UserID:        userID,
+		Verdict:       MapStringToReviewVerdict[string(req.Verdict)],
+	}
+}
+
+// DomainReviewsToAPI маппит domain []Review в api []Review
+func DomainReviewsToAPI(reviews []Review) []api.Review {
+	reviewsAPI := make([]api.Review, 0, len(reviews))
+	for _, r := range reviews {
+		reviewsAPI = append(reviewsAPI, api.Review{
+			UserId:      fmt.Sprintf("u%d", r.ReviewerID),
+			Verdict:     api.ReviewVerdict(r.Verdict),
+			SubmittedAt: r.SubmittedAt,
+		})
+	}
+	return reviewsAPI
+}
+
+// MergePolicy политика слияния PullRequest в команде автора
+type MergePolicy struct {
+	// RequiredApprovals сколько APPROVED нужно для слияния, 0 - не требуется
+	RequiredApprovals int
+	// BlockOnChangesRequested запрещать слияние при действующем CHANGES_REQUESTED
+	BlockOnChangesRequested bool
+}
+
+// NotApprovedError PullRequest не удовлетворяет политике слияния,
+// сравнивается с ErrNotApproved через errors.Is
+type NotApprovedError struct {
+	RequiredApprovals  int
+	Approvals          int
+	MissingApprovals   []int
+	ChangesRequestedBy []int
+}
+
+func (e *NotApprovedError) Error() string {
+	return fmt.Sprintf("%s: %d of %d approvals, changes requested by %v",
+		ErrNotApproved, e.Approvals, e.RequiredApprovals, e.ChangesRequestedBy)
+}
+
+func (e *NotApprovedError) Is(target error) bool {
+	return target == ErrNotApproved
+}
+
+// Check проверяет последние вердикты назначенных ревьюверов pr
+func (p MergePolicy) Check(pr *PullRequest) error {
+	verdicts := make(map[int]ReviewVerdict, len(pr.Reviews))
+	for _, r := range pr.Reviews {
+		verdicts[r.ReviewerID] = r.Verdict
+	}
+
+	var approvals int
+	var missing, changesRequested []int
+	for _, id := range pr.AssignedReviewers {
+		switch verdicts[id] {
+		case VerdictApproved:
+			approvals++
+			continue
+		case VerdictChangesRequested:
+			if p.BlockOnChangesRequested {
+				changesRequested = append(changesRequested, id)
+			}
+		}
+		missing = append(missing, id)
+	}
+
+	if approvals >= p.RequiredApprovals && len(changesRequested) == 0 {
+		return nil
+	}
+
+	notApproved := &NotApprovedError{
+		RequiredApprovals:  p.RequiredApprovals,
+		Approvals:          approvals,
+		ChangesRequestedBy: changesRequested,
+	}
+	if approvals < p.RequiredApprovals {
+		notApproved.MissingApprovals = missing
+	}
+	return notApproved
+}
+
+// NotApprovedErrorToAPI маппит NotApprovedError в api ErrorDetails
+func NotApprovedErrorToAPI(e *NotApprovedError) *api.ErrorDetails {
+	toUserIDs := func(ids []int) *[]string {
+		userIDs := make([]string, 0, len(ids))
+		for _, id := range ids {
+			userIDs = append(userIDs, fmt.Sprintf("u%d", id))
+		}
+		return &userIDs
+	}
+
+	required, approvals := e.RequiredApprovals, e.Approvals
+	return &api.ErrorDetails{
+		RequiredApprovals:  &required,
+		Approvals:          &approvals,
+		MissingApprovals:   toUserIDs(e.MissingApprovals),
+		ChangesRequestedBy: toUserIDs(e.ChangesRequestedBy),
+	}
+}
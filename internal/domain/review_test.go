@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePolicyCheck(t *testing.T) {
+	pr := func(reviews ...Review) *PullRequest {
+		return &PullRequest{AssignedReviewers: []int{1, 2, 3}, Reviews: reviews}
+	}
+
+	tests := []struct {
+		name   string
+		policy MergePolicy
+		pr     *PullRequest
+		want   error
+	}{
+		{"no policy", MergePolicy{}, pr(Review{ReviewerID: 1, Verdict: VerdictChangesRequested}), nil},
+		{
+			"enough approvals",
+			MergePolicy{RequiredApprovals: 2},
+			pr(Review{ReviewerID: 1, Verdict: VerdictApproved}, Review{ReviewerID: 3, Verdict: VerdictApproved}),
+			nil,
+		},
+		{
+			"missing approvals",
+			MergePolicy{RequiredApprovals: 2},
+			pr(Review{ReviewerID: 2, Verdict: VerdictApproved}, Review{ReviewerID: 3, Verdict: VerdictCommented}),
+			&NotApprovedError{RequiredApprovals: 2, Approvals: 1, MissingApprovals: []int{1, 3}},
+		},
+		{
+			"changes requested blocks merge",
+			MergePolicy{RequiredApprovals: 1, BlockOnChangesRequested: true},
+			pr(Review{ReviewerID: 1, Verdict: VerdictApproved}, Review{ReviewerID: 2, Verdict: VerdictChangesRequested}),
+			&NotApprovedError{RequiredApprovals: 1, Approvals: 1, ChangesRequestedBy: []int{2}},
+		},
+		{
+			"changes requested ignored without block",
+			MergePolicy{RequiredApprovals: 1},
+			pr(Review{ReviewerID: 1, Verdict: VerdictApproved}, Review{ReviewerID: 2, Verdict: VerdictChangesRequested}),
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.pr)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrNotApproved)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+// RoundRobinAttempts сколько раз операция выбирает ревьюверов заново,
+// если очередь ROUND_ROBIN сдвинул параллельный запрос
+const RoundRobinAttempts = 3
+
+// RoundRobinMove сдвиг очереди ROUND_ROBIN команды: ревьюверы выбраны от позиции From,
+// после назначения позиция To. Сохраняется в транзакции назначения, только если
+// позиция все еще From, иначе назначение отменяется с ErrRoundRobinMoved
+type RoundRobinMove struct {
+	TeamID int
+	From   int
+	To     int
+}
+
+// RoundRobinMoves сдвиги очередей за одну операцию, не больше одного на команду
+type RoundRobinMoves []RoundRobinMove
+
+// Apply подставляет в sel позицию очереди, до которой ее сдвинули прежние выборы операции
+func (m RoundRobinMoves) Apply(sel *ReviewerSelection) {
+	idx := slices.IndexFunc(m, func(move RoundRobinMove) bool { return move.TeamID == sel.TeamID })
+	if idx >= 0 {
+		sel.LastReviewerID = m[idx].To
+	}
+}
+
+// Advance запоминает сдвиг очереди после назначения reviewers по sel;
+// sel должен быть получен из базы и пропущен через Apply
+func (m *RoundRobinMoves) Advance(sel *ReviewerSelection, reviewers []User) {
+	if sel.Strategy != StrategyRoundRobin || len(reviewers) == 0 {
+		return
+	}
+
+	last := reviewers[len(reviewers)-1].ID
+	idx := slices.IndexFunc(*m, func(move RoundRobinMove) bool { return move.TeamID == sel.TeamID })
+	if idx >= 0 {
+		(*m)[idx].To = last
+		return
+	}
+	*m = append(*m, RoundRobinMove{TeamID: sel.TeamID, From: sel.LastReviewerID, To: last})
+}
+
+// RetryOnRoundRobinMoved выполняет fn заново, пока она завершается с ErrRoundRobinMoved,
+// но не больше RoundRobinAttempts раз
+func RetryOnRoundRobinMoved(fn func() error) error {
+	var err error
+	for range RoundRobinAttempts {
+		if err = fn(); !errors.Is(err, ErrRoundRobinMoved) {
+			return err
+		}
+	}
+	return err
+}
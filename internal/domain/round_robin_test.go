@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundRobinMoves(t *testing.T) {
+	var moves RoundRobinMoves
+
+	// Не ROUND_ROBIN очередь не ведет
+	moves.Advance(&ReviewerSelection{TeamID: 1, Strategy: StrategyRandom}, []User{{ID: 5}})
+	assert.Empty(t, moves)
+
+	sel := &ReviewerSelection{TeamID: 2, Strategy: StrategyRoundRobin, LastReviewerID: 3}
+	moves.Apply(sel)
+	assert.Equal(t, 3, sel.LastReviewerID)
+	moves.Advance(sel, []User{{ID: 4}, {ID: 6}})
+
+	// Следующий выбор той же операции идет от сдвинутой позиции, From сохраняется
+	sel = &ReviewerSelection{TeamID: 2, Strategy: StrategyRoundRobin, LastReviewerID: 3}
+	moves.Apply(sel)
+	assert.Equal(t, 6, sel.LastReviewerID)
+	moves.Advance(sel, []User{{ID: 7}})
+
+	assert.Equal(t, RoundRobinMoves{{TeamID: 2, From: 3, To: 7}}, moves)
+}
+
+func TestRetryOnRoundRobinMoved(t *testing.T) {
+	calls := 0
+	err := RetryOnRoundRobinMoved(func() error {
+		calls++
+		if calls < 2 {
+			return fmt.Errorf("failed to assign: %w", ErrRoundRobinMoved)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = RetryOnRoundRobinMoved(func() error {
+		calls++
+		return ErrRoundRobinMoved
+	})
+	assert.ErrorIs(t, err, ErrRoundRobinMoved)
+	assert.Equal(t, RoundRobinAttempts, calls)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"time"
+)
+
+// StatsFilter область и временное окно статистики, пустые поля - без ограничения
+type StatsFilter struct {
+	// TeamName PR авторов из команды
+	TeamName *string
+	// UserID PR, где пользователь был назначен ревьювером (по журналу назначений)
+	UserID *int
+	// From, To окно по created_at PullRequest: [From, To)
+	From *time.Time
+	To   *time.Time
+}
+
+// PullRequestCounts количество PullRequest по статусам
+type PullRequestCounts struct {
+	Total  int
+	Draft  int
+	Open   int
+	Merged int
+	Closed int
+}
+
+// ReviewerStats статистика назначений ревьювера
+type ReviewerStats struct {
+	UserID                int
+	Username              string
+	Assignments           int
+	OpenReviews           int
+	ReassignmentsReceived int
+}
+
+// Stats статистика назначений ревьюверов
+type Stats struct {
+	PullRequests PullRequestCounts
+	// AvgMergeTime nil, если в окне нет слитых PullRequest
+	AvgMergeTime  *time.Duration
+	Reassignments int
+	Reviewers     []ReviewerStats
+}
+
+// DomainStatsToAPI маппит domain Stats в api Stats
+func DomainStatsToAPI(st *Stats) api.Stats {
+	var avgMergeSeconds *float64
+	if st.AvgMergeTime != nil {
+		seconds := st.AvgMergeTime.Seconds()
+		avgMergeSeconds = &seconds
+	}
+
+	reviewers := make([]api.ReviewerStats, 0, len(st.Reviewers))
+	for _, r := range st.Reviewers {
+		reviewers = append(reviewers, api.ReviewerStats{
+			UserId:                fmt.Sprintf("u%d", r.UserID),
+			Username:              r.Username,
+			Assignments:           r.Assignments,
+			OpenReviews:           r.OpenReviews,
+			ReassignmentsReceived: r.ReassignmentsReceived,
+		})
+	}
+
+	return api.Stats{
+		PullRequests: api.PullRequestCounts{
+			Total:  st.PullRequests.Total,
+			Draft:  st.PullRequests.Draft,
+			Open:   st.PullRequests.Open,
+			Merged: st.PullRequests.Merged,
+			Closed: st.PullRequests.Closed,
+		},
+		AvgMergeSeconds: avgMergeSeconds,
+		Reassignments:   st.Reassignments,
+		Reviewers:       reviewers,
+	}
+}
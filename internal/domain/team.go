@@ -3,19 +3,134 @@ package domain
 import (
 	"fmt"
 	"pr-reviewer/internal/api"
+	"slices"
 	"strconv"
 )
 
 type Team struct {
-	ID      int
-	Name    string
-	Members []TeamMember
+	ID               int
+	Name             string
+	Members          []TeamMember
+	ReviewerStrategy ReviewerStrategy
 }
 
 type TeamMember struct {
-	IsActive bool
-	UserID   int
-	Username string
+	IsActive     bool
+	UserID       int
+	Username     string
+	ReviewWeight int
+}
+
+// DefaultReviewWeight вес пользователя, если он не задан явно
+const DefaultReviewWeight = 1
+
+// ReviewerStrategy стратегия выбора ревьюверов команды
+type ReviewerStrategy string
+
+// Стратегии выбора ревьюверов
+const (
+	StrategyRandom      ReviewerStrategy = "RANDOM"
+	StrategyLeastLoaded ReviewerStrategy = "LEAST_LOADED"
+	StrategyRoundRobin  ReviewerStrategy = "ROUND_ROBIN"
+	StrategyWeighted    ReviewerStrategy = "WEIGHTED"
+)
+
+// MapStringToReviewerStrategy маппинг string в domain ReviewerStrategy
+var MapStringToReviewerStrategy = map[string]ReviewerStrategy{
+	"RANDOM":       StrategyRandom,
+	"LEAST_LOADED": StrategyLeastLoaded,
+	"ROUND_ROBIN":  StrategyRoundRobin,
+	"WEIGHTED":     StrategyWeighted,
+}
+
+// ReviewerSelection настройки выбора ревьюверов в команде автора PullRequest
+type ReviewerSelection struct {
+	TeamID         int
+	TeamName       string
+	Strategy       ReviewerStrategy
+	LastReviewerID int
+	MinReviewers   int
+	MaxReviewers   int
+	// FallbackTeamIDs резервные команды по порядку приоритета
+	FallbackTeamIDs []int
+}
+
+// TeamSettings настройки назначения ревьюверов команды
+type TeamSettings struct {
+	TeamName         string
+	ReviewerStrategy ReviewerStrategy
+	MinReviewers     int
+	MaxReviewers     int
+	FallbackTeams    []string
+	MergePolicy
+	ReviewSLA
+}
+
+// UpdateTeamSettings domain запрос на изменение настроек команды, nil - не менять
+type UpdateTeamSettings struct {
+	TeamName         string
+	ReviewerStrategy *ReviewerStrategy
+	MinReviewers     *int
+	MaxReviewers     *int
+	FallbackTeams    *[]string
+	// RequiredApprovals и BlockOnChangesRequested - политика слияния
+	RequiredApprovals       *int
+	BlockOnChangesRequested *bool
+	// ReviewSLAMinutes и EscalationPolicy - эскалация просроченных ревью
+	ReviewSLAMinutes *int
+	EscalationPolicy *EscalationPolicy
+}
+
+// APIToDomainUpdateTeamSettings маппит api TeamSettings в domain UpdateTeamSettings
+func APIToDomainUpdateTeamSettings(ts api.PostTeamSettingsJSONRequestBody) *UpdateTeamSettings {
+	upd := &UpdateTeamSettings{
+		TeamName:      ts.TeamName,
+		MinReviewers:  ts.MinReviewers,
+		MaxReviewers:  ts.MaxReviewers,
+		FallbackTeams: ts.FallbackTeams,
+
+		RequiredApprovals:       ts.RequiredApprovals,
+		BlockOnChangesRequested: ts.BlockOnChangesRequested,
+
+		ReviewSLAMinutes: ts.ReviewSlaMinutes,
+	}
+	if ts.ReviewerStrategy != nil {
+		strategy := ReviewerStrategy(*ts.ReviewerStrategy)
+		upd.ReviewerStrategy = &strategy
+	}
+	if ts.EscalationPolicy != nil {
+		policy := EscalationPolicy(*ts.EscalationPolicy)
+		upd.EscalationPolicy = &policy
+	}
+	return upd
+}
+
+// TeamSettingsResponse ответ на изменение настроек команды
+type TeamSettingsResponse struct {
+	Settings api.TeamSettings `json:"settings"`
+}
+
+// DomainTeamSettingsToAPI маппит domain TeamSettings в api TeamSettings
+func DomainTeamSettingsToAPI(ts *TeamSettings) api.TeamSettings {
+	strategy := api.ReviewerStrategy(ts.ReviewerStrategy)
+	minReviewers, maxReviewers := ts.MinReviewers, ts.MaxReviewers
+	fallbackTeams := append([]string{}, ts.FallbackTeams...)
+	requiredApprovals, blockOnChanges := ts.RequiredApprovals, ts.BlockOnChangesRequested
+	reviewSLA, policy := ts.ReviewSLAMinutes, api.EscalationPolicy(ts.EscalationPolicy)
+
+	return api.TeamSettings{
+		TeamName:         ts.TeamName,
+		ReviewerStrategy: &strategy,
+		MinReviewers:     &minReviewers,
+		MaxReviewers:     &maxReviewers,
+		FallbackTeams:    &fallbackTeams,
+
+		RequiredApprovals:       &requiredApprovals,
+		BlockOnChangesRequested: &blockOnChanges,
+
+		ReviewSlaMinutes: &reviewSLA,
+		EscalationPolicy: &policy,
+	}
 }
 
 func APIToDomainTeam(ta api.Team) *Team {
@@ -24,16 +139,28 @@ func APIToDomainTeam(ta api.Team) *Team {
 	for _, m := range ta.Members {
 		id, _ := strconv.Atoi(m.UserId[1:])
 
+		weight := DefaultReviewWeight
+		if m.ReviewWeight != nil {
+			weight = *m.ReviewWeight
+		}
+
 		members = append(members, TeamMember{
-			IsActive: m.IsActive,
-			UserID:   id,
-			Username: m.Username,
+			IsActive:     m.IsActive,
+			UserID:       id,
+			Username:     m.Username,
+			ReviewWeight: weight,
 		})
 	}
 
+	strategy := StrategyRandom
+	if ta.ReviewerStrategy != nil {
+		strategy = ReviewerStrategy(*ta.ReviewerStrategy)
+	}
+
 	return &Team{
-		Name:    ta.TeamName,
-		Members: members,
+		Name:             ta.TeamName,
+		Members:          members,
+		ReviewerStrategy: strategy,
 	}
 }
 
@@ -45,15 +172,215 @@ func DomainTeamToAPI(team *Team) api.Team {
 	members := make([]api.TeamMember, 0, len(team.Members))
 
 	for _, m := range team.Members {
+		weight := m.ReviewWeight
 		members = append(members, api.TeamMember{
-			UserId:   fmt.Sprintf("u%d", m.UserID),
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserId:       fmt.Sprintf("u%d", m.UserID),
+			Username:     m.Username,
+			IsActive:     m.IsActive,
+			ReviewWeight: &weight,
 		})
 	}
 
+	strategy := api.ReviewerStrategy(team.ReviewerStrategy)
+
 	return api.Team{
+		TeamName:         team.Name,
+		Members:          members,
+		ReviewerStrategy: &strategy,
+	}
+}
+
+// AddTeamMembers domain запрос на добавление участников в существующую команду
+type AddTeamMembers struct {
+	TeamName string
+	Members  []TeamMember
+}
+
+// RemoveTeamMembers domain запрос на исключение участников из команды
+type RemoveTeamMembers struct {
+	TeamName string
+	UserIDs  []int
+}
+
+// MoveTeamMember domain запрос на перевод пользователя в команду ToTeamName
+type MoveTeamMember struct {
+	UserID     int
+	ToTeamName string
+}
+
+// RenameTeam domain запрос на переименование команды
+type RenameTeam struct {
+	TeamName    string
+	NewTeamName string
+}
+
+// TeamMembershipResult команда после изменения состава и замены
+// OPEN ревью ушедших участников
+type TeamMembershipResult struct {
+	Team             *Team
+	Reassigned       []ReviewReplacement
+	WithoutCandidate []StuckReview
+}
+
+// APIToDomainAddTeamMembers маппит api PostTeamMembersAddJSONRequestBody в domain AddTeamMembers
+func APIToDomainAddTeamMembers(req api.PostTeamMembersAddJSONRequestBody) *AddTeamMembers {
+	team := APIToDomainTeam(api.Team{TeamName: req.TeamName, Members: req.Members})
+	return &AddTeamMembers{
 		TeamName: team.Name,
-		Members:  members,
+		Members:  team.Members,
+	}
+}
+
+// APIToDomainRemoveTeamMembers маппит api PostTeamMembersRemoveJSONRequestBody в domain RemoveTeamMembers
+func APIToDomainRemoveTeamMembers(req api.PostTeamMembersRemoveJSONRequestBody) *RemoveTeamMembers {
+	userIDs := make([]int, 0, len(req.UserIds))
+	for _, userID := range req.UserIds {
+		id, _ := strconv.Atoi(userID[1:])
+		userIDs = append(userIDs, id)
+	}
+	return &RemoveTeamMembers{
+		TeamName: req.TeamName,
+		UserIDs:  userIDs,
+	}
+}
+
+// APIToDomainMoveTeamMember маппит api PostTeamMembersMoveJSONRequestBody в domain MoveTeamMember
+func APIToDomainMoveTeamMember(req api.PostTeamMembersMoveJSONRequestBody) *MoveTeamMember {
+	userID, _ := strconv.Atoi(req.UserId[1:])
+	return &MoveTeamMember{
+		UserID:     userID,
+		ToTeamName: req.TeamName,
+	}
+}
+
+// DomainTeamMembershipResultToAPI маппит domain TeamMembershipResult в api TeamMembershipResult
+func DomainTeamMembershipResultToAPI(res *TeamMembershipResult) api.TeamMembershipResult {
+	replacements := DomainBulkDeactivateResultToAPI(&BulkDeactivateResult{
+		Reassigned:       res.Reassigned,
+		WithoutCandidate: res.WithoutCandidate,
+	})
+	return api.TeamMembershipResult{
+		Team:             DomainTeamToAPI(res.Team),
+		Reassigned:       replacements.Reassigned,
+		WithoutCandidate: replacements.WithoutCandidate,
+	}
+}
+
+// SyncTeam domain запрос на приведение состава команды к Team.Members.
+// ReviewWeight участника 0 - вес не передан и не меняется
+type SyncTeam struct {
+	Team   *Team
+	DryRun bool
+}
+
+// TeamSyncResult разница между текущим и переданным составом команды
+type TeamSyncResult struct {
+	Team        *Team
+	TeamCreated bool
+	DryRun      bool
+	// Added новые участники, в том числе переведенные из других команд
+	Added   []TeamMember
+	Updated []TeamMember
+	// Removed id участников, которых нет в переданном составе
+	Removed []int
+	// Reassigned и WithoutCandidate при DryRun - замены, которые были бы выполнены
+	Reassigned       []ReviewReplacement
+	WithoutCandidate []StuckReview
+}
+
+// Changed есть ли разница между составами
+func (r *TeamSyncResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// ResolveReviewWeights возвращает копию members, где не переданный вес (0) заменен
+// текущим весом пользователя из weights, а для новых пользователей - DefaultReviewWeight
+func ResolveReviewWeights(members []TeamMember, weights map[int]int) []TeamMember {
+	resolved := make([]TeamMember, 0, len(members))
+	for _, m := range members {
+		if m.ReviewWeight == 0 {
+			m.ReviewWeight = DefaultReviewWeight
+			if weight, ok := weights[m.UserID]; ok {
+				m.ReviewWeight = weight
+			}
+		}
+		resolved = append(resolved, m)
+	}
+	return resolved
+}
+
+// DiffTeamMembers сравнивает текущий состав команды с желаемым: added - нет в current,
+// updated - изменились username, is_active или review_weight, removed - нет в desired.
+// Сравниваются только поля, которые задает синхронизация
+func DiffTeamMembers(current, desired []TeamMember) (added, updated []TeamMember, removed []int) {
+	added, updated, removed = make([]TeamMember, 0), make([]TeamMember, 0), make([]int, 0)
+
+	byID := make(map[int]TeamMember, len(current))
+	for _, m := range current {
+		byID[m.UserID] = m
+	}
+
+	desiredIDs := make(map[int]struct{}, len(desired))
+	for _, m := range desired {
+		desiredIDs[m.UserID] = struct{}{}
+
+		cur, ok := byID[m.UserID]
+		switch {
+		case !ok:
+			added = append(added, m)
+		case cur.Username != m.Username || cur.IsActive != m.IsActive || cur.ReviewWeight != m.ReviewWeight:
+			updated = append(updated, m)
+		}
+	}
+
+	for _, m := range current {
+		if _, ok := desiredIDs[m.UserID]; !ok {
+			removed = append(removed, m.UserID)
+		}
+	}
+	slices.Sort(removed)
+
+	return added, updated, removed
+}
+
+// APIToDomainSyncTeam маппит api PostTeamSyncJSONRequestBody в domain SyncTeam
+func APIToDomainSyncTeam(req api.PostTeamSyncJSONRequestBody) *SyncTeam {
+	team := APIToDomainTeam(api.Team{
+		TeamName:         req.TeamName,
+		Members:          req.Members,
+		ReviewerStrategy: req.ReviewerStrategy,
+	})
+	for i, m := range req.Members {
+		if m.ReviewWeight == nil {
+			team.Members[i].ReviewWeight = 0
+		}
+	}
+	return &SyncTeam{
+		Team:   team,
+		DryRun: req.DryRun != nil && *req.DryRun,
+	}
+}
+
+// DomainTeamSyncResultToAPI маппит domain TeamSyncResult в api TeamSyncResult
+func DomainTeamSyncResultToAPI(res *TeamSyncResult) api.TeamSyncResult {
+	removed := make([]string, 0, len(res.Removed))
+	for _, id := range res.Removed {
+		removed = append(removed, fmt.Sprintf("u%d", id))
+	}
+
+	replacements := DomainBulkDeactivateResultToAPI(&BulkDeactivateResult{
+		Reassigned:       res.Reassigned,
+		WithoutCandidate: res.WithoutCandidate,
+	})
+
+	return api.TeamSyncResult{
+		Team:             DomainTeamToAPI(res.Team),
+		TeamCreated:      res.TeamCreated,
+		DryRun:           res.DryRun,
+		Added:            DomainTeamToAPI(&Team{Members: res.Added}).Members,
+		Updated:          DomainTeamToAPI(&Team{Members: res.Updated}).Members,
+		Removed:          removed,
+		Reassigned:       replacements.Reassigned,
+		WithoutCandidate: replacements.WithoutCandidate,
 	}
 }
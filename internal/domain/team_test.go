@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTeamMembers(t *testing.T) {
+	current := []TeamMember{
+		{UserID: 3, Username: "carol", IsActive: true, ReviewWeight: 1},
+		{UserID: 1, Username: "alice", IsActive: true, ReviewWeight: 1},
+		{UserID: 2, Username: "bob", IsActive: true, ReviewWeight: 1},
+		{UserID: 4, Username: "dave", IsActive: false, ReviewWeight: 2},
+	}
+	desired := []TeamMember{
+		{UserID: 1, Username: "alice", IsActive: true, ReviewWeight: 1},
+		{UserID: 2, Username: "bob", IsActive: false, ReviewWeight: 1},
+		{UserID: 7, Username: "grace", IsActive: true, ReviewWeight: 1},
+	}
+
+	added, updated, removed := DiffTeamMembers(current, desired)
+	assert.Equal(t, []TeamMember{desired[2]}, added)
+	assert.Equal(t, []TeamMember{desired[1]}, updated)
+	assert.Equal(t, []int{3, 4}, removed)
+
+	// Повторная синхронизация с тем же составом ничего не меняет
+	added, updated, removed = DiffTeamMembers(desired, desired)
+	assert.Empty(t, added)
+	assert.Empty(t, updated)
+	assert.Empty(t, removed)
+}
+
+func TestResolveReviewWeights(t *testing.T) {
+	members := []TeamMember{
+		{UserID: 1, Username: "alice", IsActive: true},
+		{UserID: 2, Username: "bob", IsActive: true, ReviewWeight: 3},
+		{UserID: 7, Username: "grace", IsActive: true},
+	}
+
+	resolved := ResolveReviewWeights(members, map[int]int{1: 2, 2: 1})
+	assert.Equal(t, []int{2, 3, DefaultReviewWeight}, []int{resolved[0].ReviewWeight, resolved[1].ReviewWeight, resolved[2].ReviewWeight})
+	// Исходный состав не меняется
+	assert.Zero(t, members[0].ReviewWeight)
+}
@@ -7,10 +7,31 @@ import (
 )
 
 type User struct {
-	ID       int
-	Username string
-	TeamName string
-	IsActive bool
+	ID           int
+	Username     string
+	TeamName     string
+	IsActive     bool
+	ReviewWeight int
+	// OpenReviews количество OPEN PullRequest, где пользователь ревьювер
+	OpenReviews int
+}
+
+// BatchLoad ревью, назначенные пользователям прежними выборами одной операции
+// и еще не сохраненные: id пользователя -> количество
+type BatchLoad map[int]int
+
+// Apply добавляет к OpenReviews кандидатов ревью, выбранные им раньше в операции
+func (l BatchLoad) Apply(candidates []User) {
+	for i := range candidates {
+		candidates[i].OpenReviews += l[candidates[i].ID]
+	}
+}
+
+// Add запоминает ревью, назначенное userID в операции
+func (l BatchLoad) Add(userID int) {
+	if l != nil {
+		l[userID]++
+	}
 }
 
 type SetUserIsActive struct {
@@ -43,4 +64,72 @@ func DomainUserToAPI(u *User) api.User {
 type UserReviews struct {
 	UserID       string                 `json:"user_id"`
 	PullRequests []api.PullRequestShort `json:"pull_requests"`
+	NextCursor   *string                `json:"next_cursor,omitempty"`
+}
+
+// BulkDeactivate domain запрос на массовую деактивацию: список пользователей или команда
+type BulkDeactivate struct {
+	UserIDs  []int
+	TeamName string
+}
+
+// APIToDomainBulkDeactivate маппит api PostUsersBulkDeactivateJSONRequestBody в domain BulkDeactivate
+func APIToDomainBulkDeactivate(req api.PostUsersBulkDeactivateJSONRequestBody) *BulkDeactivate {
+	bd := &BulkDeactivate{}
+	if req.TeamName != nil {
+		bd.TeamName = *req.TeamName
+	}
+	if req.UserIds != nil {
+		for _, userID := range *req.UserIds {
+			id, _ := strconv.Atoi(userID[1:])
+			bd.UserIDs = append(bd.UserIDs, id)
+		}
+	}
+	return bd
+}
+
+// ReviewReplacement замена ревьювера на PullRequest
+type ReviewReplacement struct {
+	PullRequestID int
+	OldReviewerID int
+	NewReviewerID int
+}
+
+// StuckReview PullRequest, на котором деактивированному ревьюверу не нашлось замены
+type StuckReview struct {
+	PullRequestID int
+	ReviewerID    int
+}
+
+// BulkDeactivateResult отчет о массовой деактивации
+type BulkDeactivateResult struct {
+	Deactivated      []int
+	Reassigned       []ReviewReplacement
+	WithoutCandidate []StuckReview
+}
+
+// DomainBulkDeactivateResultToAPI маппит domain BulkDeactivateResult в api BulkDeactivateResult
+func DomainBulkDeactivateResultToAPI(res *BulkDeactivateResult) api.BulkDeactivateResult {
+	resAPI := api.BulkDeactivateResult{
+		Deactivated:      make([]string, 0, len(res.Deactivated)),
+		Reassigned:       make([]api.ReviewReplacement, 0, len(res.Reassigned)),
+		WithoutCandidate: make([]api.StuckReview, 0, len(res.WithoutCandidate)),
+	}
+	for _, id := range res.Deactivated {
+		resAPI.Deactivated = append(resAPI.Deactivated, fmt.Sprintf("u%d", id))
+	}
+	for _, r := range res.Reassigned {
+		resAPI.Reassigned = append(resAPI.Reassigned, api.ReviewReplacement{
+			PullRequestId: fmt.Sprintf("pr-%d", r.PullRequestID),
+			OldUserId:     fmt.Sprintf("u%d", r.OldReviewerID),
+			NewUserId:     fmt.Sprintf("u%d", r.NewReviewerID),
+		})
+	}
+	for _, s := range res.WithoutCandidate {
+		resAPI.WithoutCandidate = append(resAPI.WithoutCandidate, api.StuckReview{
+			PullRequestId: fmt.Sprintf("pr-%d", s.PullRequestID),
+			UserId:        fmt.Sprintf("u%d", s.ReviewerID),
+		})
+	}
+	return resAPI
 }
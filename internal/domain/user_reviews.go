@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// ReviewFilter фильтр и страница PullRequest, где пользователь назначен ревьювером;
+// пустые поля - без ограничения
+type ReviewFilter struct {
+	UserID   int
+	Status   *PullRequestStatus
+	AuthorID *int
+	// TeamName команда автора PullRequest
+	TeamName *string
+	// From, To окно по created_at PullRequest: [From, To)
+	From *time.Time
+	To   *time.Time
+	// IncludeReviewers заполнить AssignedReviewers остальными ревьюверами
+	IncludeReviewers bool
+	Limit            int
+	// Cursor nil для первой страницы
+	Cursor *PageCursor
+}
+
+// ReviewPage страница ревью пользователя, NextCursor nil на последней странице
+type ReviewPage struct {
+	PullRequests []PullRequest
+	NextCursor   *PageCursor
+}
+
+// APIToDomainReviewFilter маппит api GetUsersGetReviewParams в domain ReviewFilter;
+// неверный курсор - ErrInvalidCursor
+func APIToDomainReviewFilter(params api.GetUsersGetReviewParams) (*ReviewFilter, error) {
+	userID, _ := strconv.Atoi(params.UserId[1:])
+	filter := &ReviewFilter{
+		UserID:   userID,
+		TeamName: params.TeamName,
+		From:     params.From,
+		To:       params.To,
+		Limit:    DefaultPageLimit,
+	}
+
+	if params.Status != nil {
+		status := MapStringToPullRequestStatus[string(*params.Status)]
+		filter.Status = &status
+	}
+	if params.AuthorId != nil {
+		authorID, _ := strconv.Atoi((*params.AuthorId)[1:])
+		filter.AuthorID = &authorID
+	}
+	if params.IncludeReviewers != nil {
+		filter.IncludeReviewers = *params.IncludeReviewers
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		cursor, err := DecodePageCursor(*params.Cursor, SortCreatedAtDesc)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+// DomainReviewPageToAPI маппит domain ReviewPage в UserReviews
+func DomainReviewPageToAPI(userID string, page *ReviewPage, includeReviewers bool) UserReviews {
+	prs := make([]api.PullRequestShort, 0, len(page.PullRequests))
+	for _, pr := range page.PullRequests {
+		prAPI := DomainPRToAPIShort(&pr)
+		if includeReviewers {
+			others := make([]string, 0, len(pr.AssignedReviewers))
+			for _, id := range pr.AssignedReviewers {
+				others = append(others, fmt.Sprintf("u%d", id))
+			}
+			prAPI.OtherReviewers = &others
+		}
+		prs = append(prs, prAPI)
+	}
+
+	resp := UserReviews{UserID: userID, PullRequests: prs}
+	if page.NextCursor != nil {
+		cursor := page.NextCursor.Encode()
+		resp.NextCursor = &cursor
+	}
+	return resp
+}
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"pr-reviewer/internal/api"
+	"strconv"
+	"time"
+)
+
+// WebhookEventType тип события для подписчиков
+type WebhookEventType string
+
+// События, на которые можно подписаться
+const (
+	WebhookPRCreated           WebhookEventType = "pull_request.created"
+	WebhookPRReassigned        WebhookEventType = "pull_request.reassigned"
+	WebhookPRMerged            WebhookEventType = "pull_request.merged"
+	WebhookPRReviewStale       WebhookEventType = "pull_request.review_stale"
+	WebhookUserActivityChanged WebhookEventType = "user.activity_changed"
+)
+
+// MapStringToWebhookEventType маппинг string в domain WebhookEventType
+var MapStringToWebhookEventType = map[string]WebhookEventType{
+	"pull_request.created":      WebhookPRCreated,
+	"pull_request.reassigned":   WebhookPRReassigned,
+	"pull_request.merged":       WebhookPRMerged,
+	"pull_request.review_stale": WebhookPRReviewStale,
+	"user.activity_changed":     WebhookUserActivityChanged,
+}
+
+// Доставка событий подписчикам
+const (
+	// WebhookMaxAttempts после стольких неудачных попыток событие больше не отправляется
+	WebhookMaxAttempts = 8
+	// WebhookBaseBackoff задержка перед первым повтором, далее удваивается
+	WebhookBaseBackoff = 10 * time.Second
+	// WebhookMaxBackoff верхняя граница задержки между попытками
+	WebhookMaxBackoff = time.Hour
+)
+
+// WebhookSubscription подписка команды на события
+type WebhookSubscription struct {
+	ID       int
+	TeamName string
+	URL      string
+	Secret   string
+	Events   []WebhookEventType
+	// CreatedAt время создания подписки
+	CreatedAt time.Time
+}
+
+// WebhookEvent событие для подписчиков команды пользователя UserID,
+// сохраняется в outbox в транзакции изменения
+type WebhookEvent struct {
+	Type WebhookEventType
+	// UserID автор PullRequest или пользователь, чья активность изменилась
+	UserID int
+	// Data тело события, сериализуется в JSON
+	Data      any
+	CreatedAt time.Time
+}
+
+// OutboxMessage событие из outbox, ожидающее доставки
+type OutboxMessage struct {
+	ID        int64
+	URL       string
+	Secret    string
+	EventType WebhookEventType
+	Payload   []byte
+	// Attempts количество уже сделанных попыток доставки
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// ReassignedPayload тело события pull_request.reassigned
+type ReassignedPayload struct {
+	PullRequest api.PullRequest `json:"pr"`
+	OldUserID   string          `json:"old_user_id"`
+	ReplacedBy  string          `json:"replaced_by"`
+}
+
+// ReviewStalePayload тело события pull_request.review_stale
+type ReviewStalePayload struct {
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	StaleSince    time.Time `json:"stale_since"`
+	// Action выполненное действие, NewReviewerID - добавленный или назначенный вместо ReviewerID
+	Action        EscalationPolicy `json:"action"`
+	NewReviewerID *string          `json:"new_reviewer_id,omitempty"`
+}
+
+// UserActivityPayload тело события user.activity_changed
+type UserActivityPayload struct {
+	UserID   string `json:"user_id"`
+	IsActive bool   `json:"is_active"`
+}
+
+// WebhookEnvelope тело POST запроса подписчику
+type WebhookEnvelope struct {
+	ID        string           `json:"id"`
+	Event     WebhookEventType `json:"event"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      any              `json:"data"`
+}
+
+// WebhookResponse ответ с подпиской
+type WebhookResponse struct {
+	Webhook api.WebhookSubscription `json:"webhook"`
+}
+
+// TeamWebhooks ответ со списком подписок команды
+type TeamWebhooks struct {
+	TeamName string                    `json:"team_name"`
+	Webhooks []api.WebhookSubscription `json:"webhooks"`
+}
+
+// NewPRWebhookEvent событие t с текущим состоянием pr для подписчиков команды автора
+func NewPRWebhookEvent(t WebhookEventType, pr *PullRequest, at time.Time) WebhookEvent {
+	return WebhookEvent{
+		Type:      t,
+		UserID:    pr.AuthorID,
+		Data:      DomainPRToAPI(pr),
+		CreatedAt: at,
+	}
+}
+
+// NewReassignedWebhookEvent событие замены ревьювера oldID на newID в pr
+func NewReassignedWebhookEvent(pr *PullRequest, oldID, newID int, at time.Time) WebhookEvent {
+	return WebhookEvent{
+		Type:   WebhookPRReassigned,
+		UserID: pr.AuthorID,
+		Data: ReassignedPayload{
+			PullRequest: DomainPRToAPI(pr),
+			OldUserID:   fmt.Sprintf("u%d", oldID),
+			ReplacedBy:  fmt.Sprintf("u%d", newID),
+		},
+		CreatedAt: at,
+	}
+}
+
+// NewReviewStaleWebhookEvent событие эскалации просроченного ревью для подписчиков команды автора
+func NewReviewStaleWebhookEvent(esc *ReviewEscalation, at time.Time) WebhookEvent {
+	payload := ReviewStalePayload{
+		PullRequestID: fmt.Sprintf("pr-%d", esc.PullRequestID),
+		ReviewerID:    fmt.Sprintf("u%d", esc.ReviewerID),
+		StaleSince:    esc.StaleSince,
+		Action:        esc.Action,
+	}
+	if esc.NewReviewerID != nil {
+		newReviewerID := fmt.Sprintf("u%d", *esc.NewReviewerID)
+		payload.NewReviewerID = &newReviewerID
+	}
+	return WebhookEvent{
+		Type:      WebhookPRReviewStale,
+		UserID:    esc.AuthorID,
+		Data:      payload,
+		CreatedAt: at,
+	}
+}
+
+// NewUserActivityWebhookEvent событие смены активности пользователя
+func NewUserActivityWebhookEvent(userID int, isActive bool, at time.Time) WebhookEvent {
+	return WebhookEvent{
+		Type:   WebhookUserActivityChanged,
+		UserID: userID,
+		Data: UserActivityPayload{
+			UserID:   fmt.Sprintf("u%d", userID),
+			IsActive: isActive,
+		},
+		CreatedAt: at,
+	}
+}
+
+// SignWebhook подпись тела события секретом подписки: sha256=<hex HMAC-SHA256>
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff задержка перед повтором после attempts неудачных попыток
+func WebhookBackoff(attempts int) time.Duration {
+	backoff := WebhookBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= WebhookMaxBackoff {
+			return WebhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// APIToDomainWebhook маппит api PostWebhooksAddJSONRequestBody в domain WebhookSubscription
+func APIToDomainWebhook(req api.PostWebhooksAddJSONRequestBody) *WebhookSubscription {
+	events := make([]WebhookEventType, 0, len(req.Events))
+	for _, e := range req.Events {
+		events = append(events, MapStringToWebhookEventType[string(e)])
+	}
+	return &WebhookSubscription{
+		TeamName: req.TeamName,
+		URL:      req.Url,
+		Secret:   req.Secret,
+		Events:   events,
+	}
+}
+
+// APIToDomainWebhookID маппит api webhook_id вида wh-<N> в id подписки
+func APIToDomainWebhookID(id string) int {
+	webhookID, _ := strconv.Atoi(id[3:])
+	return webhookID
+}
+
+// DomainWebhookToAPI маппит domain WebhookSubscription в api WebhookSubscription без секрета
+func DomainWebhookToAPI(sub *WebhookSubscription) api.WebhookSubscription {
+	events := make([]api.WebhookEventType, 0, len(sub.Events))
+	for _, e := range sub.Events {
+		events = append(events, api.WebhookEventType(e))
+	}
+	return api.WebhookSubscription{
+		WebhookId: fmt.Sprintf("wh-%d", sub.ID),
+		TeamName:  sub.TeamName,
+		Url:       sub.URL,
+		Events:    events,
+		CreatedAt: sub.CreatedAt,
+	}
+}
+
+// DomainWebhooksToAPI маппит domain []WebhookSubscription в api []WebhookSubscription
+func DomainWebhooksToAPI(subs []WebhookSubscription) []api.WebhookSubscription {
+	subsAPI := make([]api.WebhookSubscription, 0, len(subs))
+	for i := range subs {
+		subsAPI = append(subsAPI, DomainWebhookToAPI(&subs[i]))
+	}
+	return subsAPI
+}
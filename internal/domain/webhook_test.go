@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWebhook(t *testing.T) {
+	// Контрольное значение: echo -n '{"id":"1"}' | openssl dgst -sha256 -hmac secret
+	got := SignWebhook("secret", []byte(`{"id":"1"}`))
+	assert.Equal(t, "sha256=6146142a2ce0159e84c0767881e4ec80bc397da62526e7d19f70795eb79460c0", got)
+	assert.NotEqual(t, got, SignWebhook("other", []byte(`{"id":"1"}`)))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{8, 1280 * time.Second},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, WebhookBackoff(tt.attempts), "attempts=%d", tt.attempts)
+	}
+}
@@ -0,0 +1,10 @@
+// Package buildinfo данные сборки, подставляемые через ldflags:
+// -X pr-reviewer/internal/pkg/buildinfo.Commit=<sha> -X pr-reviewer/internal/pkg/buildinfo.BuildTime=<RFC3339>
+package buildinfo
+
+const unknown = "unknown"
+
+var (
+	Commit    = unknown
+	BuildTime = unknown
+)
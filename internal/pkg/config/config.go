@@ -0,0 +1,235 @@
+// Package config собирает настройки сервиса из значений по умолчанию, YAML файла,
+// переменных окружения и флагов; каждый следующий источник перекрывает предыдущий
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"pr-reviewer/internal/pkg/tracing"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv переменная окружения с путем к YAML файлу, флаг -config важнее
+const ConfigFileEnv = "CONFIG_FILE"
+
+// logLevels уровни, которые понимает логгер
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// Config настройки сервиса
+type Config struct {
+	HTTP    HTTPConfig    `yaml:"http"`
+	DB      DBConfig      `yaml:"db"`
+	Log     LogConfig     `yaml:"log"`
+	Tracing TracingConfig `yaml:"tracing"`
+	Ingest  IngestConfig  `yaml:"ingest"`
+	Auth    AuthConfig    `yaml:"auth"`
+}
+
+// HTTPConfig настройки HTTP сервера
+type HTTPConfig struct {
+	Addr string `yaml:"addr"`
+	// TLSCertFile и TLSKeyFile задаются вместе, иначе сервер работает без TLS
+	TLSCertFile       string        `yaml:"tls_cert_file"`
+	TLSKeyFile        string        `yaml:"tls_key_file"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout сколько ждать завершения запросов при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// DrainDelay сколько /readyz отвечает 503 перед закрытием сервера
+	DrainDelay time.Duration `yaml:"drain_delay"`
+}
+
+// TLSEnabled заданы ли сертификат и ключ
+func (c HTTPConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// DBConfig настройки пула соединений PostgreSQL
+type DBConfig struct {
+	URL             string        `yaml:"url"`
+	MaxConns        int32         `yaml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
+}
+
+// LogConfig настройки логгера
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+// TracingConfig настройки трассировки
+type TracingConfig struct {
+	Exporter string `yaml:"exporter"`
+}
+
+// IngestConfig настройки приема событий GitHub/GitLab
+type IngestConfig struct {
+	GitHubWebhookSecret string `yaml:"github_webhook_secret"`
+	GitLabWebhookToken  string `yaml:"gitlab_webhook_token"`
+	// UserMap авторы внешних сервисов -> пользователи (логин GitHub, id GitLab): github:octocat=u1,gitlab:42=u2
+	UserMap string `yaml:"user_map"`
+}
+
+// AuthConfig настройки доступа
+type AuthConfig struct {
+	AdminToken string `yaml:"admin_token"`
+}
+
+// Default настройки по умолчанию
+func Default() *Config {
+	return &Config{
+		HTTP: HTTPConfig{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       time.Minute,
+			ShutdownTimeout:   5 * time.Second,
+			DrainDelay:        5 * time.Second,
+		},
+		DB: DBConfig{
+			MaxConns:        10,
+			MinConns:        1,
+			ConnectTimeout:  5 * time.Second,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Log:     LogConfig{Level: "info"},
+		Tracing: TracingConfig{Exporter: tracing.ExporterNone},
+	}
+}
+
+// Load собирает настройки по аргументам командной строки args (без имени программы)
+// и переменным окружения из lookupEnv; -help возвращает flag.ErrHelp
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	fs := flag.NewFlagSet("pr-reviewer", flag.ContinueOnError)
+	configFile := fs.String("config", "", fmt.Sprintf("путь к YAML файлу настроек (env %s)", ConfigFileEnv))
+	// Флаги разбираются до файла и окружения, а применяются после них
+	flagValues := make([]*rawValue, len(settings))
+	for i, s := range settings {
+		flagValues[i] = &rawValue{def: s.value.String()}
+		fs.Var(flagValues[i], s.key, fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("config: unexpected arguments %v", fs.Args())
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		// Пустая переменная, как DB_URL= в .env файле, считается незаданной
+		if v, ok := lookupEnv(s.env); ok && v != "" {
+			if err := s.value.Set(v); err != nil {
+				return nil, fmt.Errorf("config: env %s: %w", s.env, err)
+			}
+		}
+	}
+
+	for i, s := range settings {
+		if !flagValues[i].set {
+			continue
+		}
+		if err := s.value.Set(flagValues[i].value); err != nil {
+			return nil, fmt.Errorf("config: flag -%s: %w", s.key, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile перекрывает настройки значениями из YAML файла; неизвестные ключи - ошибка
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate проверяет настройки и возвращает все найденные ошибки разом
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("config: %s (%s): %s", key, c.envOf(key), fmt.Sprintf(format, args...)))
+	}
+
+	if c.HTTP.Addr == "" {
+		fail("http.addr", "must not be empty")
+	}
+	if (c.HTTP.TLSCertFile == "") != (c.HTTP.TLSKeyFile == "") {
+		fail("http.tls_cert_file", "must be set together with http.tls_key_file")
+	}
+	for key, d := range map[string]time.Duration{
+		"http.read_header_timeout": c.HTTP.ReadHeaderTimeout,
+		"http.read_timeout":        c.HTTP.ReadTimeout,
+		"http.write_timeout":       c.HTTP.WriteTimeout,
+		"http.idle_timeout":        c.HTTP.IdleTimeout,
+		"http.drain_delay":         c.HTTP.DrainDelay,
+		"db.max_conn_lifetime":     c.DB.MaxConnLifetime,
+		"db.max_conn_idle_time":    c.DB.MaxConnIdleTime,
+	} {
+		if d < 0 {
+			fail(key, "must not be negative, got %s", d)
+		}
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		fail("http.shutdown_timeout", "must be positive, got %s", c.HTTP.ShutdownTimeout)
+	}
+
+	if c.DB.URL == "" {
+		fail("db.url", "is required")
+	}
+	if c.DB.MaxConns < 1 {
+		fail("db.max_conns", "must be at least 1, got %d", c.DB.MaxConns)
+	}
+	if c.DB.MinConns < 0 || c.DB.MinConns > c.DB.MaxConns {
+		fail("db.min_conns", "must be between 0 and db.max_conns (%d), got %d", c.DB.MaxConns, c.DB.MinConns)
+	}
+	if c.DB.ConnectTimeout <= 0 {
+		fail("db.connect_timeout", "must be positive, got %s", c.DB.ConnectTimeout)
+	}
+
+	if !slices.Contains(logLevels, c.Log.Level) {
+		fail("log.level", "must be one of %v, got %q", logLevels, c.Log.Level)
+	}
+
+	exporters := []string{tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP}
+	if !slices.Contains(exporters, c.Tracing.Exporter) {
+		fail("tracing.exporter", "must be one of %v, got %q", exporters, c.Tracing.Exporter)
+	}
+
+	return errors.Join(errs...)
+}
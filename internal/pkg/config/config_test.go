@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envOf(m map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(nil, envOf(map[string]string{"DB_URL": "postgres://db"}))
+	require.NoError(t, err)
+
+	want := Default()
+	want.DB.URL = "postgres://db"
+	assert.Equal(t, want, cfg)
+	assert.False(t, cfg.HTTP.TLSEnabled())
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, `
+http:
+  addr: ":7000"
+  shutdown_timeout: 10s
+db:
+  url: postgres://file
+  max_conns: 20
+log:
+  level: debug
+`)
+	env := envOf(map[string]string{
+		"CONFIG_FILE":  path,
+		"DB_URL":       "postgres://env",
+		"DB_MAX_CONNS": "30",
+		"LOG_LEVEL":    "", // пустая переменная не перекрывает файл
+	})
+
+	cfg, err := Load([]string{"-db.max_conns", "40", "-http.drain_delay=1s"}, env)
+	require.NoError(t, err)
+
+	assert.Equal(t, ":7000", cfg.HTTP.Addr)
+	assert.Equal(t, 10*time.Second, cfg.HTTP.ShutdownTimeout)
+	assert.Equal(t, time.Second, cfg.HTTP.DrainDelay)
+	assert.Equal(t, "postgres://env", cfg.DB.URL)
+	assert.Equal(t, int32(40), cfg.DB.MaxConns)
+	assert.Equal(t, int32(1), cfg.DB.MinConns)
+	assert.Equal(t, "debug", cfg.Log.Level)
+}
+
+func TestLoad_ConfigFlagOverridesEnv(t *testing.T) {
+	path := writeFile(t, "db:\n  url: postgres://flag-file\n")
+
+	cfg, err := Load([]string{"-config", path}, envOf(map[string]string{"CONFIG_FILE": "/missing.yml"}))
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://flag-file", cfg.DB.URL)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		file string
+		want string
+	}{
+		{
+			name: "missing db url",
+			want: "db.url (env DB_URL): is required",
+		},
+		{
+			name: "bad env value",
+			env:  map[string]string{"DB_URL": "postgres://db", "HTTP_READ_TIMEOUT": "soon"},
+			want: "env HTTP_READ_TIMEOUT",
+		},
+		{
+			name: "bad flag value",
+			args: []string{"-db.max_conns", "many"},
+			env:  map[string]string{"DB_URL": "postgres://db"},
+			want: "flag -db.max_conns",
+		},
+		{
+			name: "unknown file key",
+			file: "http:\n  adr: \":8080\"\n",
+			env:  map[string]string{"DB_URL": "postgres://db"},
+			want: "field adr not found",
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"serve"},
+			want: "unexpected arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, tt.file)}, args...)
+			}
+			_, err := Load(args, envOf(tt.env))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoad_Help(t *testing.T) {
+	_, err := Load([]string{"-help"}, envOf(nil))
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := Default()
+		cfg.DB.URL = "postgres://db"
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   []string
+	}{
+		{
+			name:   "tls cert without key",
+			modify: func(c *Config) { c.HTTP.TLSCertFile = "cert.pem" },
+			want:   []string{"http.tls_cert_file"},
+		},
+		{
+			name: "pool bounds",
+			modify: func(c *Config) {
+				c.DB.MaxConns = 2
+				c.DB.MinConns = 3
+			},
+			want: []string{"db.min_conns"},
+		},
+		{
+			name: "all errors at once",
+			modify: func(c *Config) {
+				c.DB.MaxConns = 0
+				c.HTTP.ShutdownTimeout = 0
+				c.HTTP.ReadTimeout = -time.Second
+				c.Log.Level = "verbose"
+				c.Tracing.Exporter = "jaeger"
+			},
+			want: []string{
+				"db.max_conns", "http.shutdown_timeout", "http.read_timeout", "log.level", "tracing.exporter",
+			},
+		},
+	}
+
+	require.NoError(t, valid().Validate())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			require.Error(t, err)
+			for _, w := range tt.want {
+				assert.Contains(t, err.Error(), w)
+			}
+		})
+	}
+}
+
+func TestExampleFile(t *testing.T) {
+	cfg, err := Load([]string{"-config", "../../../config.example.yml"}, envOf(nil))
+	require.NoError(t, err)
+
+	want := Default()
+	want.DB.URL = cfg.DB.URL
+	assert.Equal(t, want, cfg, "config.example.yml должен содержать значения по умолчанию")
+}
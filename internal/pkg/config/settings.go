@@ -0,0 +1,111 @@
+// settings.go описание каждой настройки: ключ YAML и имя флага, переменная окружения, назначение
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// setting одна настройка; value указывает на поле Config
+type setting struct {
+	// key путь в YAML файле и имя флага
+	key   string
+	env   string
+	usage string
+	value value
+}
+
+type value interface {
+	String() string
+	Set(string) error
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"http.addr", "HTTP_ADDR", "адрес, на котором слушает HTTP сервер", (*stringValue)(&c.HTTP.Addr)},
+		{"http.tls_cert_file", "HTTP_TLS_CERT_FILE", "PEM сертификат TLS, вместе с http.tls_key_file", (*stringValue)(&c.HTTP.TLSCertFile)},
+		{"http.tls_key_file", "HTTP_TLS_KEY_FILE", "PEM ключ TLS, вместе с http.tls_cert_file", (*stringValue)(&c.HTTP.TLSKeyFile)},
+		{"http.read_header_timeout", "HTTP_READ_HEADER_TIMEOUT", "таймаут чтения заголовков запроса", (*durationValue)(&c.HTTP.ReadHeaderTimeout)},
+		{"http.read_timeout", "HTTP_READ_TIMEOUT", "таймаут чтения запроса целиком, 0 - без ограничения", (*durationValue)(&c.HTTP.ReadTimeout)},
+		{"http.write_timeout", "HTTP_WRITE_TIMEOUT", "таймаут записи ответа, 0 - без ограничения", (*durationValue)(&c.HTTP.WriteTimeout)},
+		{"http.idle_timeout", "HTTP_IDLE_TIMEOUT", "сколько держать keep-alive соединение без запросов", (*durationValue)(&c.HTTP.IdleTimeout)},
+		{"http.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", "сколько ждать завершения запросов при остановке", (*durationValue)(&c.HTTP.ShutdownTimeout)},
+		{"http.drain_delay", "HTTP_DRAIN_DELAY", "сколько /readyz отвечает 503 перед закрытием сервера", (*durationValue)(&c.HTTP.DrainDelay)},
+		{"db.url", "DB_URL", "строка подключения PostgreSQL, обязательна", (*stringValue)(&c.DB.URL)},
+		{"db.max_conns", "DB_MAX_CONNS", "максимум соединений в пуле", (*int32Value)(&c.DB.MaxConns)},
+		{"db.min_conns", "DB_MIN_CONNS", "сколько соединений пул держит открытыми", (*int32Value)(&c.DB.MinConns)},
+		{"db.connect_timeout", "DB_CONNECT_TIMEOUT", "таймаут подключения к БД при старте", (*durationValue)(&c.DB.ConnectTimeout)},
+		{"db.max_conn_lifetime", "DB_MAX_CONN_LIFETIME", "через сколько соединение пересоздается", (*durationValue)(&c.DB.MaxConnLifetime)},
+		{"db.max_conn_idle_time", "DB_MAX_CONN_IDLE_TIME", "через сколько простоя соединение закрывается", (*durationValue)(&c.DB.MaxConnIdleTime)},
+		{"log.level", "LOG_LEVEL", "уровень логов: debug, info, warn, error; access-лог пишется на info", (*stringValue)(&c.Log.Level)},
+		{"tracing.exporter", "TRACING_EXPORTER", "экспорт спанов: otlp, stdout, none; адрес OTLP - OTEL_EXPORTER_OTLP_ENDPOINT", (*stringValue)(&c.Tracing.Exporter)},
+		{"ingest.github_webhook_secret", "GITHUB_WEBHOOK_SECRET", "секрет подписи событий GitHub, пустой - события отклоняются", (*stringValue)(&c.Ingest.GitHubWebhookSecret)},
+		{"ingest.gitlab_webhook_token", "GITLAB_WEBHOOK_TOKEN", "токен событий GitLab, пустой - события отклоняются", (*stringValue)(&c.Ingest.GitLabWebhookToken)},
+		{"ingest.user_map", "INGEST_USER_MAP", "авторы внешних сервисов -> пользователи (логин GitHub, id GitLab): github:octocat=u1,gitlab:42=u2", (*stringValue)(&c.Ingest.UserMap)},
+		{"auth.admin_token", "AUTH_ADMIN_TOKEN", "токен администратора для выпуска первых токенов", (*stringValue)(&c.Auth.AdminToken)},
+	}
+}
+
+// envOf переменная окружения настройки key
+func (c *Config) envOf(key string) string {
+	for _, s := range c.settings() {
+		if s.key == key {
+			return "env " + s.env
+		}
+	}
+	return key
+}
+
+// rawValue значение флага, которое применяется после файла и окружения
+type rawValue struct {
+	def   string
+	value string
+	set   bool
+}
+
+func (v *rawValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.def
+}
+
+func (v *rawValue) Set(s string) error {
+	v.value, v.set = s, true
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) String() string { return string(*v) }
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+type int32Value int32
+
+func (v *int32Value) String() string { return strconv.Itoa(int(*v)) }
+
+func (v *int32Value) Set(s string) error {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	*v = int32Value(n)
+	return nil
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package pgtest поднимает схему из миграций в PostgreSQL для тестов репозиториев.
+// База задается TEST_DB_URL, без нее тесты пропускаются
+package pgtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"pr-reviewer/migrations"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBURLEnv переменная окружения со строкой подключения к тестовой базе
+const DBURLEnv = "TEST_DB_URL"
+
+// New создает отдельную схему, применяет к ней все миграции и возвращает пул,
+// запросы которого идут в эту схему; схема удаляется по завершении теста
+func New(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv(DBURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", DBURLEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("pgtest: failed to connect: %v", err)
+	}
+
+	schema := pgx.Identifier{fmt.Sprintf("pgtest_%d", time.Now().UnixNano())}.Sanitize()
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close(ctx)
+		t.Fatalf("pgtest: failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("pgtest: failed to drop schema: %v", err)
+		}
+		admin.Close(ctx)
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("pgtest: invalid %s: %v", DBURLEnv, err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("pgtest: failed to create pool: %v", err)
+	}
+	// Cleanup выполняются в обратном порядке: пул закрывается до удаления схемы
+	t.Cleanup(pool.Close)
+
+	scripts, err := migrations.Up()
+	if err != nil {
+		t.Fatalf("pgtest: %v", err)
+	}
+	for i, script := range scripts {
+		// Без аргументов Exec идет простым протоколом, который принимает несколько команд
+		if _, err := pool.Exec(ctx, script); err != nil {
+			t.Fatalf("pgtest: failed to apply migration %d: %v", i+1, err)
+		}
+	}
+
+	return pool
+}
+
+// Prepare проверяет запросы на схеме: PREPARE разбирает запрос и находит
+// несуществующие таблицы и колонки без выполнения
+func Prepare(t *testing.T, pool *pgxpool.Pool, queries map[string]string) {
+	t.Helper()
+
+	ctx := context.Background()
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("pgtest: failed to acquire conn: %v", err)
+	}
+	defer conn.Release()
+
+	for name, query := range queries {
+		if _, err := conn.Conn().Prepare(ctx, name, query); err != nil {
+			t.Errorf("query %s does not match schema: %v", name, err)
+		}
+	}
+}
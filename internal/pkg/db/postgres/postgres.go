@@ -3,27 +3,36 @@ package postgres
 import (
 	"context"
 	"fmt"
-	"os"
+	"pr-reviewer/internal/pkg/tracing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewPool() (*pgxpool.Pool, error) {
-	dsn := os.Getenv("DB_URL")
-	if dsn == "" {
-		return nil, fmt.Errorf("DATABASE URL is not set")
-	}
+// Options параметры пула подключений
+type Options struct {
+	URL             string
+	MaxConns        int32
+	MinConns        int32
+	ConnectTimeout  time.Duration
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func NewPool(o Options) (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.ConnectTimeout)
 	defer cancel()
 
-	cfg, err := pgxpool.ParseConfig(dsn)
+	cfg, err := pgxpool.ParseConfig(o.URL)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: invalid dsn config: %w", err)
 	}
-	cfg.MaxConns = 10
-	cfg.MinConns = 1
+	cfg.MaxConns = o.MaxConns
+	cfg.MinConns = o.MinConns
+	cfg.MaxConnLifetime = o.MaxConnLifetime
+	cfg.MaxConnIdleTime = o.MaxConnIdleTime
+	// Спаны запросов становятся дочерними к span HTTP запроса из контекста
+	cfg.ConnConfig.Tracer = tracing.NewQueryTracer()
 
 	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
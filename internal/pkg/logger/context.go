@@ -0,0 +1,18 @@
+package logger
+
+import "context"
+
+type loggerKey struct{}
+
+// WithContext сохраняет логгер запроса в контексте
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext возвращает логгер запроса из контекста или fallback, если его нет
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+	return fallback
+}
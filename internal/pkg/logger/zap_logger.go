@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"log"
+	"slices"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -47,6 +48,8 @@ func (l *ZapLogger) Debug(msg string) {
 
 func (l *ZapLogger) WithFields(fields LoggerFields) Logger {
 	newLogger := *l
+	// Производные логгеры не должны писать в общий массив полей родителя
+	newLogger.fields = slices.Clip(l.fields)
 	for key, value := range fields {
 		if subMap, ok := value.(LoggerFields); ok {
 			newLogger.fields = append(newLogger.fields, zap.Any(key, subMap))
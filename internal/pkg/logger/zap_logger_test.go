@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZapLogger_WithFieldsDoesNotShareParentFields(t *testing.T) {
+	base, err := NewZapLogger("error")
+	assert.NoError(t, err)
+
+	request := base.WithFields(LoggerFields{"request_id": "r1", "route": "/team/get", "method": "GET"}).(*ZapLogger)
+	first := request.WithFields(LoggerFields{"prID": 1}).(*ZapLogger)
+	second := request.WithFields(LoggerFields{"prID": 2}).(*ZapLogger)
+
+	assert.Len(t, request.fields, 3)
+	assert.Equal(t, int64(1), first.fields[3].Integer)
+	assert.Equal(t, int64(2), second.fields[3].Integer)
+}
+
+func TestFromContext(t *testing.T) {
+	fallback, err := NewZapLogger("error")
+	assert.NoError(t, err)
+	request := fallback.WithFields(LoggerFields{"request_id": "r1"})
+
+	assert.Equal(t, fallback, FromContext(context.Background(), fallback))
+	assert.Equal(t, request, FromContext(WithContext(context.Background(), request), fallback))
+}
@@ -0,0 +1,84 @@
+// Package metrics содержит метрики Prometheus сервиса
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pr_reviewer"
+
+// noTeam значение метки team для автора без команды
+const noTeam = "none"
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Длительность обработки HTTP запросов по маршруту, методу и коду ответа",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	pullRequestsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Созданные PullRequest по команде автора",
+	}, []string{"team"})
+
+	pullRequestsMerged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_merged_total",
+		Help:      "Слитые PullRequest по команде автора",
+	}, []string{"team"})
+
+	reviewerReassignments = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewer_reassignments_total",
+		Help:      "Замены ревьюверов по команде автора и причине",
+	}, []string{"team", "reason"})
+
+	noCandidateFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_failures_total",
+		Help:      "Отказы NO_CANDIDATE при подборе ревьювера по команде автора",
+	}, []string{"team"})
+)
+
+// ObserveHTTPRequest учитывает обработанный HTTP запрос
+func ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(route, method, statusLabel(status)).Observe(duration.Seconds())
+}
+
+// PullRequestCreated учитывает созданный PullRequest команды team
+func PullRequestCreated(team string) {
+	pullRequestsCreated.WithLabelValues(teamLabel(team)).Inc()
+}
+
+// PullRequestMerged учитывает слитый PullRequest команды team
+func PullRequestMerged(team string) {
+	pullRequestsMerged.WithLabelValues(teamLabel(team)).Inc()
+}
+
+// ReviewerReassigned учитывает замену ревьювера в PullRequest команды team
+func ReviewerReassigned(team, reason string) {
+	reviewerReassignments.WithLabelValues(teamLabel(team), reason).Inc()
+}
+
+// NoCandidate учитывает отказ NO_CANDIDATE в команде team
+func NoCandidate(team string) {
+	noCandidateFailures.WithLabelValues(teamLabel(team)).Inc()
+}
+
+func teamLabel(team string) string {
+	if team == "" {
+		return noTeam
+	}
+	return team
+}
+
+func statusLabel(status int) string {
+	return strconv.Itoa(status)
+}
@@ -0,0 +1,79 @@
+// pool.go метрики пула соединений pgxpool
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStater источник статистики пула, *pgxpool.Pool
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector отдает статистику pgxpool в момент сбора метрик
+type PoolCollector struct {
+	pool PoolStater
+
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+	lifetimeDestroyCount *prometheus.Desc
+	idleDestroyCount     *prometheus.Desc
+}
+
+func NewPoolCollector(pool PoolStater) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &PoolCollector{
+		pool:                 pool,
+		acquireCount:         desc("acquire_total", "Успешные получения соединения из пула"),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Суммарное время получения соединений из пула"),
+		canceledAcquireCount: desc("canceled_acquire_total", "Получения соединения, отмененные контекстом"),
+		emptyAcquireCount:    desc("empty_acquire_total", "Получения соединения с ожиданием при пустом пуле"),
+		acquiredConns:        desc("acquired_connections", "Соединения, занятые в данный момент"),
+		constructingConns:    desc("constructing_connections", "Соединения, открываемые в данный момент"),
+		idleConns:            desc("idle_connections", "Свободные соединения"),
+		totalConns:           desc("total_connections", "Все соединения пула"),
+		maxConns:             desc("max_connections", "Максимальный размер пула"),
+		newConnsCount:        desc("new_connections_total", "Открытые пулом соединения"),
+		lifetimeDestroyCount: desc("max_lifetime_destroy_total", "Соединения, закрытые по MaxConnLifetime"),
+		idleDestroyCount:     desc("max_idle_destroy_total", "Соединения, закрытые по MaxConnIdleTime"),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v)
+	}
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v)
+	}
+
+	counter(c.acquireCount, float64(s.AcquireCount()))
+	counter(c.acquireDuration, s.AcquireDuration().Seconds())
+	counter(c.canceledAcquireCount, float64(s.CanceledAcquireCount()))
+	counter(c.emptyAcquireCount, float64(s.EmptyAcquireCount()))
+	gauge(c.acquiredConns, float64(s.AcquiredConns()))
+	gauge(c.constructingConns, float64(s.ConstructingConns()))
+	gauge(c.idleConns, float64(s.IdleConns()))
+	gauge(c.totalConns, float64(s.TotalConns()))
+	gauge(c.maxConns, float64(s.MaxConns()))
+	counter(c.newConnsCount, float64(s.NewConnsCount()))
+	counter(c.lifetimeDestroyCount, float64(s.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroyCount, float64(s.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,63 @@
+// Package middleware auth.go middleware для проверки bearer токена и роли
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/response"
+	"slices"
+	"strings"
+)
+
+// Authenticator проверяет токен и возвращает его владельца
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*domain.Actor, error)
+}
+
+// AuthMiddleware пропускает запрос, если роль владельца токена входит в scopes операции;
+// операции без security в спецификации не проверяются
+func AuthMiddleware(auth Authenticator) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			roles, ok := r.Context().Value(api.BearerAuthScopes).([]string)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				response.SendErrorResponse(w, api.UNAUTHORIZED, http.StatusUnauthorized)
+				return
+			}
+
+			actor, err := auth.Authenticate(r.Context(), token)
+			if errors.Is(err, domain.ErrUnauthorized) {
+				response.SendErrorResponse(w, api.UNAUTHORIZED, http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				response.SendErrorResponse(w, api.INTERNAL, http.StatusInternalServerError)
+				return
+			}
+
+			if !slices.Contains(roles, string(actor.Role)) {
+				response.SendErrorResponse(w, api.FORBIDDEN, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withActor(r.Context(), actor)))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type authenticatorFunc func(ctx context.Context, token string) (*domain.Actor, error)
+
+func (f authenticatorFunc) Authenticate(ctx context.Context, token string) (*domain.Actor, error) {
+	return f(ctx, token)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	userID := 2
+	auth := authenticatorFunc(func(ctx context.Context, token string) (*domain.Actor, error) {
+		switch token {
+		case "admin-token":
+			return &domain.Actor{TokenID: 1, Role: domain.RoleAdmin}, nil
+		case "user-token":
+			return &domain.Actor{TokenID: 2, Role: domain.RoleUser, UserID: &userID}, nil
+		case "broken-token":
+			return nil, fmt.Errorf("db error")
+		default:
+			return nil, domain.ErrUnauthorized
+		}
+	})
+
+	var gotActor *domain.Actor
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotActor = domain.ActorFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthMiddleware(auth)(next)
+
+	request := func(scopes []string, authorization string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if scopes != nil {
+			req = req.WithContext(context.WithValue(req.Context(), api.BearerAuthScopes, scopes))
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return req
+	}
+
+	tests := []struct {
+		name          string
+		scopes        []string
+		authorization string
+		wantCode      int
+		wantActor     bool
+	}{
+		{"public operation", nil, "", http.StatusOK, false},
+		{"missing token", []string{"admin"}, "", http.StatusUnauthorized, false},
+		{"wrong scheme", []string{"admin"}, "Basic admin-token", http.StatusUnauthorized, false},
+		{"unknown token", []string{"admin"}, "Bearer nope", http.StatusUnauthorized, false},
+		{"admin allowed", []string{"admin"}, "Bearer admin-token", http.StatusOK, true},
+		{"user forbidden", []string{"admin"}, "Bearer user-token", http.StatusForbidden, false},
+		{"user allowed", []string{"admin", "user"}, "bearer user-token", http.StatusOK, true},
+		{"authenticator error", []string{"admin"}, "Bearer broken-token", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotActor = nil
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, request(tt.scopes, tt.authorization))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantActor, gotActor != nil)
+		})
+	}
+}
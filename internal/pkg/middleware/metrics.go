@@ -0,0 +1,70 @@
+// metrics.go middleware для метрик HTTP запросов
+package middleware
+
+import (
+	"net/http"
+	"pr-reviewer/internal/pkg/metrics"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// unknownRoute метка для запроса, не сопоставленного с маршрутом
+const unknownRoute = "unknown"
+
+// statusRecorder запоминает код ответа handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// statusCode код ответа; handler, ничего не записавший, отвечает 200
+func (rec *statusRecorder) statusCode() int {
+	if rec.status == 0 {
+		return http.StatusOK
+	}
+	return rec.status
+}
+
+// MetricsMiddleware учитывает длительность и код ответа запроса по шаблону маршрута oapi;
+// регистрируется последним, чтобы видеть ответы RecoverMiddleware и AuthMiddleware
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		metrics.ObserveHTTPRequest(routeTemplate(r), r.Method, rec.statusCode(), time.Since(start))
+	})
+}
+
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return unknownRoute
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return unknownRoute
+	}
+	return tpl
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// requestCount число наблюдений гистограммы HTTP запросов с заданными метками
+func requestCount(t *testing.T, route, method, status string) uint64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+
+	want := map[string]string{"route": route, "method": method, "status": status}
+	for _, mf := range families {
+		if mf.GetName() != "pr_reviewer_http_request_duration_seconds" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if labelsMatch(m, want) {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func labelsMatch(m *dto.Metric, want map[string]string) bool {
+	for _, l := range m.GetLabel() {
+		if want[l.GetName()] != l.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	r := mux.NewRouter()
+	r.Handle("/pullRequest/get", MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))).Methods(http.MethodGet)
+	r.Handle("/team/get", MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))).Methods(http.MethodGet)
+	r.Handle("/panic", MetricsMiddleware(RecoverMiddleware(newRecordLogger())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))).Methods(http.MethodGet)
+
+	tests := []struct {
+		name   string
+		path   string
+		status string
+	}{
+		{name: "explicit status", path: "/pullRequest/get", status: "404"},
+		{name: "implicit 200", path: "/team/get", status: "200"},
+		{name: "recovered panic", path: "/panic", status: "500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := requestCount(t, tt.path, http.MethodGet, tt.status)
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path+"?pull_request_id=pr-1", nil))
+
+			assert.Equal(t, before+1, requestCount(t, tt.path, http.MethodGet, tt.status))
+		})
+	}
+}
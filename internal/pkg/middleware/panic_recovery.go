@@ -3,21 +3,27 @@
 package middleware
 
 import (
-	"log"
+	"fmt"
 	"net/http"
 	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/pkg/logger"
 	"pr-reviewer/internal/pkg/response"
 	"runtime/debug"
 )
 
-func RecoverMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				log.Printf("panic recovered: %v\n%s", err, debug.Stack())
-				response.SendErrorResponse(w, api.INTERNAL, http.StatusInternalServerError)
-			}
-		}()
-		next.ServeHTTP(w, r)
-	})
+// RecoverMiddleware отвечает 500 на panic в handler и пишет ее в логгер запроса
+func RecoverMiddleware(l logger.Logger) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if err := recover(); err != nil {
+					logger.FromContext(r.Context(), l).
+						WithFields(logger.LoggerFields{"panic": fmt.Sprint(err), "stack": string(debug.Stack())}).
+						Error("panic recovered")
+					response.SendErrorResponse(w, api.INTERNAL, http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -0,0 +1,111 @@
+// request_logger.go middleware для логгера запроса и access-лога
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader заголовок с идентификатором запроса
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen максимальная длина X-Request-ID, принимаемого от клиента
+const maxRequestIDLen = 128
+
+// requestState данные запроса, которые внутренние middleware сообщают access-логу
+type requestState struct {
+	actor *domain.Actor
+}
+
+type requestStateKey struct{}
+
+// RequestLoggerMiddleware принимает X-Request-ID клиента или выдает новый, кладет в контекст
+// логгер с request_id и маршрутом и пишет access-лог с кодом ответа и длительностью
+func RequestLoggerMiddleware(l logger.Logger) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			fields := logger.LoggerFields{"request_id": requestID, "method": r.Method, "route": routeTemplate(r)}
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				fields["trace_id"] = sc.TraceID().String()
+			}
+			reqLogger := l.WithFields(fields)
+
+			state := &requestState{}
+			ctx := context.WithValue(r.Context(), requestStateKey{}, state)
+			ctx = logger.WithContext(ctx, reqLogger)
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			status := rec.statusCode()
+			accessFields := logger.LoggerFields{"status": status, "latency": time.Since(start)}
+			for k, v := range actorFields(state.actor) {
+				accessFields[k] = v
+			}
+			access := reqLogger.WithFields(accessFields)
+			if status >= http.StatusInternalServerError {
+				access.Error("http request")
+				return
+			}
+			access.Info("http request")
+		})
+	}
+}
+
+// withActor сохраняет владельца токена в контексте и добавляет его в логгер и access-лог запроса
+func withActor(ctx context.Context, actor *domain.Actor) context.Context {
+	if state, ok := ctx.Value(requestStateKey{}).(*requestState); ok {
+		state.actor = actor
+	}
+	if l := logger.FromContext(ctx, nil); l != nil {
+		ctx = logger.WithContext(ctx, l.WithFields(actorFields(actor)))
+	}
+	return domain.WithActor(ctx, actor)
+}
+
+func actorFields(actor *domain.Actor) logger.LoggerFields {
+	if actor == nil {
+		return nil
+	}
+	fields := logger.LoggerFields{"token_id": actor.TokenID, "role": string(actor.Role)}
+	if actor.UserID != nil {
+		fields["user_id"] = fmt.Sprintf("u%d", *actor.UserID)
+	}
+	return fields
+}
+
+// validRequestID принимает непустой X-Request-ID из видимых ASCII символов разумной длины
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
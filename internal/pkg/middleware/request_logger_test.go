@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// logEntry запись recordLogger
+type logEntry struct {
+	level  string
+	msg    string
+	fields logger.LoggerFields
+}
+
+// recordLogger logger.Logger, запоминающий записи вместе с накопленными полями
+type recordLogger struct {
+	mu      *sync.Mutex
+	entries *[]logEntry
+	fields  logger.LoggerFields
+}
+
+func newRecordLogger() *recordLogger {
+	return &recordLogger{mu: &sync.Mutex{}, entries: &[]logEntry{}, fields: logger.LoggerFields{}}
+}
+
+func (l *recordLogger) write(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.entries = append(*l.entries, logEntry{level: level, msg: msg, fields: l.fields})
+}
+
+func (l *recordLogger) Info(msg string)  { l.write("info", msg) }
+func (l *recordLogger) Warn(msg string)  { l.write("warn", msg) }
+func (l *recordLogger) Error(msg string) { l.write("error", msg) }
+func (l *recordLogger) Debug(msg string) { l.write("debug", msg) }
+
+func (l *recordLogger) WithFields(fields logger.LoggerFields) logger.Logger {
+	merged := logger.LoggerFields{}
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &recordLogger{mu: l.mu, entries: l.entries, fields: merged}
+}
+
+func (l *recordLogger) last() logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return (*l.entries)[len(*l.entries)-1]
+}
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	userID := 2
+	auth := authenticatorFunc(func(ctx context.Context, token string) (*domain.Actor, error) {
+		return &domain.Actor{TokenID: 5, Role: domain.RoleUser, UserID: &userID}, nil
+	})
+
+	base := newRecordLogger()
+	var handlerLogger logger.Logger
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		handlerLogger = logger.FromContext(r.Context(), nil)
+		if r.URL.Query().Get("panic") != "" {
+			panic("boom")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	r := mux.NewRouter()
+	var h http.Handler = http.HandlerFunc(handler)
+	for _, m := range []api.MiddlewareFunc{AuthMiddleware(auth), RecoverMiddleware(base), RequestLoggerMiddleware(base)} {
+		h = m(h)
+	}
+	r.Handle("/pullRequest/create", withScopes(h, []string{"admin", "user"})).Methods(http.MethodPost)
+
+	t.Run("propagates request id and logs access", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil)
+		req.Header.Set(RequestIDHeader, "req-42")
+		req.Header.Set("Authorization", "Bearer user-token")
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "req-42", rec.Header().Get(RequestIDHeader))
+
+		fields := handlerLogger.(*recordLogger).fields
+		assert.Equal(t, "req-42", fields["request_id"])
+		assert.Equal(t, "/pullRequest/create", fields["route"])
+		assert.Equal(t, "u2", fields["user_id"])
+
+		access := base.last()
+		assert.Equal(t, "info", access.level)
+		assert.Equal(t, "http request", access.msg)
+		assert.Equal(t, http.StatusCreated, access.fields["status"])
+		assert.Equal(t, "u2", access.fields["user_id"])
+		assert.Contains(t, access.fields, "latency")
+	})
+
+	t.Run("generates request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil)
+		req.Header.Set(RequestIDHeader, "bad id with spaces")
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		requestID := rec.Header().Get(RequestIDHeader)
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, base.last().fields["request_id"])
+		assert.NotContains(t, base.last().fields, "user_id")
+	})
+
+	t.Run("panic logged with request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create?panic=1", nil)
+		req.Header.Set(RequestIDHeader, "req-500")
+		req.Header.Set("Authorization", "Bearer user-token")
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		entries := *base.entries
+		panicEntry := entries[len(entries)-2]
+		assert.Equal(t, "panic recovered", panicEntry.msg)
+		assert.Equal(t, "req-500", panicEntry.fields["request_id"])
+		assert.Equal(t, "error", base.last().level)
+		assert.Equal(t, http.StatusInternalServerError, base.last().fields["status"])
+	})
+}
+
+// withScopes кладет scopes операции в контекст, как это делает сгенерированный роутер
+func withScopes(next http.Handler, scopes []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), api.BearerAuthScopes, scopes)))
+	})
+}
@@ -0,0 +1,36 @@
+// tracing.go middleware для трассировки HTTP запросов
+package middleware
+
+import (
+	"net/http"
+	"pr-reviewer/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware открывает span запроса по шаблону маршрута oapi и кладет его в контекст,
+// откуда его подхватывают usecase и запросы pgx; регистрируется последним, чтобы охватить всю цепочку
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		ctx, span := tracing.StartRequest(r.Context(), propagation.HeaderCarrier(r.Header), r.Method+" "+route,
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		status := rec.statusCode()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
@@ -1,10 +1,13 @@
 package response
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"pr-reviewer/internal/api"
 	"pr-reviewer/internal/domain"
+	"strings"
 )
 
 func SendResponse(w http.ResponseWriter, statusCode int, data interface{}) {
@@ -15,10 +18,50 @@ func SendResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	_ = json.NewEncoder(w).Encode(data)
 }
 
+// SendResponseWithETag отправляет 200 с ETag тела либо 304 без тела,
+// если ifNoneMatch содержит текущий ETag
+func SendResponseWithETag(w http.ResponseWriter, ifNoneMatch *string, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		SendErrorResponse(w, api.INTERNAL, http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if ifNoneMatch != nil && etagMatches(*ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// etagMatches слабое сравнение If-None-Match со списком ETag или "*"
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func SendErrorResponse(w http.ResponseWriter, code api.ErrorResponseErrorCode, statusCode int) {
+	SendErrorResponseWithDetails(w, code, statusCode, nil)
+}
+
+// SendErrorResponseWithDetails отправляет ошибку с подробностями в error.details
+func SendErrorResponseWithDetails(w http.ResponseWriter, code api.ErrorResponseErrorCode, statusCode int, details *api.ErrorDetails) {
 	var errResp api.ErrorResponse
 
 	errResp.Error.Code = code
+	errResp.Error.Details = details
 
 	msg, ok := domain.Messages[code]
 	if !ok {
@@ -26,6 +26,42 @@ func TestSendResponse(t *testing.T) {
 	assert.Equal(t, "bar", resp["foo"])
 }
 
+func TestSendResponseWithETag(t *testing.T) {
+	data := map[string]string{"foo": "bar"}
+
+	rec := httptest.NewRecorder()
+	SendResponseWithETag(rec, nil, data)
+
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, etag)
+	assert.JSONEq(t, `{"foo":"bar"}`, rec.Body.String())
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantCode    int
+	}{
+		{"same etag", etag, http.StatusNotModified},
+		{"weak etag in list", `"other", W/` + etag, http.StatusNotModified},
+		{"any", "*", http.StatusNotModified},
+		{"stale etag", `"other"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			SendResponseWithETag(rec, &tt.ifNoneMatch, data)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tt.wantCode == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestSendErrorResponse(t *testing.T) {
 	rec := httptest.NewRecorder()
 
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var recorder = tracetest.NewSpanRecorder()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	os.Exit(m.Run())
+}
+
+func TestStart(t *testing.T) {
+	t.Run("no parent span", func(t *testing.T) {
+		ctx := context.Background()
+
+		spanCtx, span := Start(ctx, "PullRequestUsecase.CreatePullRequest")
+		span.End()
+
+		assert.Equal(t, ctx, spanCtx)
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("child of request span", func(t *testing.T) {
+		ctx, parent := StartRequest(context.Background(), nil, "POST /pullRequest/create")
+
+		_, span := Start(ctx, "PullRequestUsecase.CreatePullRequest")
+		assert.True(t, span.IsRecording())
+		span.End()
+		parent.End()
+
+		assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+	})
+}
+
+func TestQueryTracer(t *testing.T) {
+	qt := NewQueryTracer()
+
+	t.Run("untraced query", func(t *testing.T) {
+		ctx := context.Background()
+
+		queryCtx := qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+		qt.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{})
+
+		assert.Equal(t, ctx, queryCtx)
+	})
+
+	t.Run("query span with error", func(t *testing.T) {
+		ctx, parent := StartRequest(context.Background(), nil, "POST /pullRequest/create")
+		defer parent.End()
+
+		queryCtx := qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t\tinsert INTO pull_request VALUES ($1)"})
+		querySpan := trace.SpanFromContext(queryCtx)
+		qt.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{Err: errors.New("duplicate key")})
+
+		var ended sdktrace.ReadOnlySpan
+		for _, s := range recorder.Ended() {
+			if s.SpanContext().SpanID() == querySpan.SpanContext().SpanID() {
+				ended = s
+			}
+		}
+		if assert.NotNil(t, ended) {
+			assert.Equal(t, "postgres INSERT", ended.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), ended.Parent().SpanID())
+			assert.Equal(t, codes.Error, ended.Status().Code)
+		}
+	})
+}
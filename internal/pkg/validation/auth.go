@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"unicode"
+)
+
+// ValidateIssueToken проверяет запрос на выпуск токена: токену роли user нужен пользователь
+func ValidateIssueToken(req api.PostAuthTokenIssueJSONRequestBody) error {
+	switch domain.TokenRole(req.Role) {
+	case domain.RoleAdmin:
+	case domain.RoleUser:
+		if req.UserId == nil {
+			return domain.ErrInvalidToken
+		}
+	default:
+		return domain.ErrInvalidToken
+	}
+
+	if req.UserId != nil {
+		if err := ValidateUserId(*req.UserId); err != nil {
+			return domain.ErrInvalidToken
+		}
+	}
+
+	return nil
+}
+
+func ValidateTokenId(id string) error {
+	if len(id) < 5 || id[:4] != "tok-" {
+		return domain.ErrInvalidToken
+	}
+	for _, r := range id[4:] {
+		if !unicode.IsDigit(r) {
+			return domain.ErrInvalidToken
+		}
+	}
+	return nil
+}
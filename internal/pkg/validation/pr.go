@@ -40,3 +40,63 @@ func ValidatePRId(id string) error {
 
 	return nil
 }
+
+func ValidateReview(req api.PostPullRequestReviewJSONRequestBody) error {
+	if err := ValidatePRId(req.PullRequestId); err != nil {
+		return domain.ErrInvalidPullRequest
+	}
+
+	if err := ValidateUserId(req.UserId); err != nil {
+		return domain.ErrInvalidUser
+	}
+
+	if _, ok := domain.MapStringToReviewVerdict[string(req.Verdict)]; !ok {
+		return domain.ErrInvalidVerdict
+	}
+
+	return nil
+}
+
+func ValidatePullRequestFilter(params api.GetPullRequestListParams) error {
+	if params.Status != nil {
+		if _, ok := domain.MapStringToPullRequestStatus[string(*params.Status)]; !ok {
+			return domain.ErrInvalidPullRequest
+		}
+	}
+	if params.AuthorId != nil {
+		if err := ValidateUserId(*params.AuthorId); err != nil {
+			return err
+		}
+	}
+	if params.ReviewerId != nil {
+		if err := ValidateUserId(*params.ReviewerId); err != nil {
+			return err
+		}
+	}
+	if params.TeamName != nil {
+		if err := ValidateTeamName(*params.TeamName); err != nil {
+			return err
+		}
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return domain.ErrInvalidTimeWindow
+	}
+	sort := domain.SortCreatedAtDesc
+	if params.Sort != nil {
+		var ok bool
+		if sort, ok = domain.MapStringToPullRequestSort[string(*params.Sort)]; !ok {
+			return domain.ErrInvalidPullRequest
+		}
+	}
+
+	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > domain.MaxPageLimit) {
+		return domain.ErrInvalidLimit
+	}
+	if params.Cursor != nil {
+		if _, err := domain.DecodePageCursor(*params.Cursor, sort); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -15,7 +15,17 @@ func ValidateTeam(team api.PostTeamAddJSONRequestBody) error {
 		return domain.ErrTeamEmptyMembers
 	}
 
-	for _, m := range team.Members {
+	if team.ReviewerStrategy != nil {
+		if err := ValidateReviewerStrategy(string(*team.ReviewerStrategy)); err != nil {
+			return err
+		}
+	}
+
+	return validateTeamMembers(team.Members)
+}
+
+func validateTeamMembers(members []api.TeamMember) error {
+	for _, m := range members {
 		// Проверка правильности написания user_id
 		if err := ValidateUserId(m.UserId); err != nil {
 			return err
@@ -24,6 +34,10 @@ func ValidateTeam(team api.PostTeamAddJSONRequestBody) error {
 		if strings.TrimSpace(m.Username) == "" {
 			return domain.ErrInvalidUser
 		}
+
+		if m.ReviewWeight != nil && *m.ReviewWeight < 1 {
+			return domain.ErrInvalidUser
+		}
 	}
 
 	return nil
@@ -35,3 +49,122 @@ func ValidateTeamName(name string) error {
 	}
 	return nil
 }
+
+func ValidateReviewerStrategy(strategy string) error {
+	if _, ok := domain.MapStringToReviewerStrategy[strategy]; !ok {
+		return domain.ErrInvalidStrategy
+	}
+	return nil
+}
+
+func ValidateTeamSettings(ts api.PostTeamSettingsJSONRequestBody) error {
+	if err := ValidateTeamName(ts.TeamName); err != nil {
+		return err
+	}
+
+	if ts.ReviewerStrategy != nil {
+		if err := ValidateReviewerStrategy(string(*ts.ReviewerStrategy)); err != nil {
+			return err
+		}
+	}
+
+	if ts.MinReviewers != nil && *ts.MinReviewers < 0 {
+		return domain.ErrInvalidSettings
+	}
+	if ts.MaxReviewers != nil && *ts.MaxReviewers < 1 {
+		return domain.ErrInvalidSettings
+	}
+	if ts.MinReviewers != nil && ts.MaxReviewers != nil && *ts.MinReviewers > *ts.MaxReviewers {
+		return domain.ErrInvalidSettings
+	}
+	if ts.RequiredApprovals != nil && *ts.RequiredApprovals < 0 {
+		return domain.ErrInvalidSettings
+	}
+	if ts.ReviewSlaMinutes != nil && *ts.ReviewSlaMinutes < 0 {
+		return domain.ErrInvalidSettings
+	}
+	if ts.EscalationPolicy != nil {
+		if _, ok := domain.MapStringToEscalationPolicy[string(*ts.EscalationPolicy)]; !ok {
+			return domain.ErrInvalidSettings
+		}
+	}
+
+	if ts.FallbackTeams != nil {
+		for _, name := range *ts.FallbackTeams {
+			if err := ValidateTeamName(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAddTeamMembers проверяет имя команды и добавляемых участников
+func ValidateAddTeamMembers(req api.PostTeamMembersAddJSONRequestBody) error {
+	if err := ValidateTeamName(req.TeamName); err != nil {
+		return err
+	}
+
+	if len(req.Members) == 0 {
+		return domain.ErrTeamEmptyMembers
+	}
+
+	return validateTeamMembers(req.Members)
+}
+
+// ValidateSyncTeam проверяет желаемый состав команды: user_id не должны повторяться
+func ValidateSyncTeam(req api.PostTeamSyncJSONRequestBody) error {
+	err := ValidateTeam(api.Team{
+		TeamName:         req.TeamName,
+		Members:          req.Members,
+		ReviewerStrategy: req.ReviewerStrategy,
+	})
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(req.Members))
+	for _, m := range req.Members {
+		if _, ok := seen[m.UserId]; ok {
+			return domain.ErrInvalidUser
+		}
+		seen[m.UserId] = struct{}{}
+	}
+
+	return nil
+}
+
+// ValidateRemoveTeamMembers проверяет имя команды и id исключаемых участников
+func ValidateRemoveTeamMembers(req api.PostTeamMembersRemoveJSONRequestBody) error {
+	if err := ValidateTeamName(req.TeamName); err != nil {
+		return err
+	}
+
+	if len(req.UserIds) == 0 {
+		return domain.ErrInvalidUser
+	}
+	for _, id := range req.UserIds {
+		if err := ValidateUserId(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateMoveTeamMember проверяет id пользователя и имя команды назначения
+func ValidateMoveTeamMember(req api.PostTeamMembersMoveJSONRequestBody) error {
+	if err := ValidateUserId(req.UserId); err != nil {
+		return err
+	}
+	return ValidateTeamName(req.TeamName)
+}
+
+// ValidateRenameTeam проверяет старое и новое имя команды
+func ValidateRenameTeam(req api.PostTeamRenameJSONRequestBody) error {
+	if err := ValidateTeamName(req.TeamName); err != nil {
+		return err
+	}
+	return ValidateTeamName(req.NewTeamName)
+}
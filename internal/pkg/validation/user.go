@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"pr-reviewer/internal/api"
 	"pr-reviewer/internal/domain"
 	"unicode"
 )
@@ -16,3 +17,86 @@ func ValidateUserId(id string) error {
 	}
 	return nil
 }
+
+// ValidateBulkDeactivate проверяет, что передан ровно один из user_ids и team_name
+func ValidateBulkDeactivate(req api.PostUsersBulkDeactivateJSONRequestBody) error {
+	if (req.UserIds == nil) == (req.TeamName == nil) {
+		return domain.ErrInvalidUser
+	}
+
+	if req.TeamName != nil {
+		return ValidateTeamName(*req.TeamName)
+	}
+
+	if len(*req.UserIds) == 0 {
+		return domain.ErrInvalidUser
+	}
+	for _, id := range *req.UserIds {
+		if err := ValidateUserId(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateReviewFilter проверяет фильтры и параметры страницы /users/getReview
+func ValidateReviewFilter(params api.GetUsersGetReviewParams) error {
+	if err := ValidateUserId(params.UserId); err != nil {
+		return err
+	}
+
+	if params.Status != nil {
+		if _, ok := domain.MapStringToPullRequestStatus[string(*params.Status)]; !ok {
+			return domain.ErrInvalidPullRequest
+		}
+	}
+	if params.AuthorId != nil {
+		if err := ValidateUserId(*params.AuthorId); err != nil {
+			return err
+		}
+	}
+	if params.TeamName != nil {
+		if err := ValidateTeamName(*params.TeamName); err != nil {
+			return err
+		}
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return domain.ErrInvalidTimeWindow
+	}
+
+	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > domain.MaxPageLimit) {
+		return domain.ErrInvalidLimit
+	}
+	if params.Cursor != nil {
+		if _, err := domain.DecodePageCursor(*params.Cursor, domain.SortCreatedAtDesc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAvailabilityWindow проверяет тело /users/availability/add
+func ValidateAvailabilityWindow(req api.PostUsersAvailabilityAddJSONRequestBody) error {
+	if err := ValidateUserId(req.UserId); err != nil {
+		return err
+	}
+	if req.From.IsZero() || req.To.IsZero() || !req.From.Before(req.To) {
+		return domain.ErrInvalidAvailability
+	}
+	return nil
+}
+
+// ValidateAvailabilityWindowId проверяет window_id вида av-<N>
+func ValidateAvailabilityWindowId(id string) error {
+	if len(id) < 4 || id[:3] != "av-" {
+		return domain.ErrInvalidAvailability
+	}
+	for _, r := range id[3:] {
+		if !unicode.IsDigit(r) {
+			return domain.ErrInvalidAvailability
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"pr-reviewer/internal/api"
 	"pr-reviewer/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +49,27 @@ func TestValidatePR(t *testing.T) {
 	}
 }
 
+func TestValidateReview(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       api.PostPullRequestReviewJSONRequestBody
+		wantError error
+	}{
+		{"invalid pr", api.PostPullRequestReviewJSONRequestBody{PullRequestId: "1", UserId: "u1", Verdict: api.APPROVED}, domain.ErrInvalidPullRequest},
+		{"invalid user", api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-1", UserId: "1", Verdict: api.APPROVED}, domain.ErrInvalidUser},
+		{"invalid verdict", api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-1", UserId: "u1", Verdict: "LGTM"}, domain.ErrInvalidVerdict},
+		{"empty verdict", api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-1", UserId: "u1"}, domain.ErrInvalidVerdict},
+		{"ok", api.PostPullRequestReviewJSONRequestBody{PullRequestId: "pr-1", UserId: "u1", Verdict: api.CHANGESREQUESTED}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReview(tt.req)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
 func TestValidateTeamName(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -136,3 +158,270 @@ func TestValidateUserId(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBulkDeactivate(t *testing.T) {
+	ids := func(v ...string) *[]string { return &v }
+	team := func(v string) *string { return &v }
+
+	tests := []struct {
+		name      string
+		req       api.PostUsersBulkDeactivateJSONRequestBody
+		wantError error
+	}{
+		{"nothing passed", api.PostUsersBulkDeactivateJSONRequestBody{}, domain.ErrInvalidUser},
+		{"both passed", api.PostUsersBulkDeactivateJSONRequestBody{UserIds: ids("u1"), TeamName: team("a")}, domain.ErrInvalidUser},
+		{"empty ids", api.PostUsersBulkDeactivateJSONRequestBody{UserIds: ids()}, domain.ErrInvalidUser},
+		{"bad id", api.PostUsersBulkDeactivateJSONRequestBody{UserIds: ids("u1", "x2")}, domain.ErrInvalidUser},
+		{"empty team", api.PostUsersBulkDeactivateJSONRequestBody{TeamName: team(" ")}, domain.ErrTeamNameEmpty},
+		{"ids ok", api.PostUsersBulkDeactivateJSONRequestBody{UserIds: ids("u1", "u2")}, nil},
+		{"team ok", api.PostUsersBulkDeactivateJSONRequestBody{TeamName: team("backend")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulkDeactivate(tt.req)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestValidateReviewFilter(t *testing.T) {
+	str := func(v string) *string { return &v }
+	intPtr := func(v int) *int { return &v }
+	status := func(v string) *api.GetUsersGetReviewParamsStatus {
+		s := api.GetUsersGetReviewParamsStatus(v)
+		return &s
+	}
+	from, to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		params    api.GetUsersGetReviewParams
+		wantError error
+	}{
+		{"bad user", api.GetUsersGetReviewParams{UserId: "1"}, domain.ErrInvalidUser},
+		{"bad status", api.GetUsersGetReviewParams{UserId: "u1", Status: status("open")}, domain.ErrInvalidPullRequest},
+		{"bad author", api.GetUsersGetReviewParams{UserId: "u1", AuthorId: str("x")}, domain.ErrInvalidUser},
+		{"empty team", api.GetUsersGetReviewParams{UserId: "u1", TeamName: str("")}, domain.ErrTeamNameEmpty},
+		{"from after to", api.GetUsersGetReviewParams{UserId: "u1", From: &from, To: &to}, domain.ErrInvalidTimeWindow},
+		{"zero limit", api.GetUsersGetReviewParams{UserId: "u1", Limit: intPtr(0)}, domain.ErrInvalidLimit},
+		{"bad cursor", api.GetUsersGetReviewParams{UserId: "u1", Cursor: str("???")}, domain.ErrInvalidCursor},
+		{"ok", api.GetUsersGetReviewParams{UserId: "u1", Status: status("MERGED"), Limit: intPtr(100)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReviewFilter(tt.params)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestValidatePullRequestFilter(t *testing.T) {
+	str := func(v string) *string { return &v }
+	intPtr := func(v int) *int { return &v }
+	sort := func(v string) *api.GetPullRequestListParamsSort {
+		s := api.GetPullRequestListParamsSort(v)
+		return &s
+	}
+	from, to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	descCursor := (&domain.PageCursor{Sort: domain.SortCreatedAtDesc, CreatedAt: from, PullRequestID: 1}).Encode()
+
+	tests := []struct {
+		name      string
+		params    api.GetPullRequestListParams
+		wantError error
+	}{
+		{"empty", api.GetPullRequestListParams{}, nil},
+		{"bad reviewer", api.GetPullRequestListParams{ReviewerId: str("2")}, domain.ErrInvalidUser},
+		{"bad sort", api.GetPullRequestListParams{Sort: sort("title")}, domain.ErrInvalidPullRequest},
+		{"from after to", api.GetPullRequestListParams{From: &from, To: &to}, domain.ErrInvalidTimeWindow},
+		{"big limit", api.GetPullRequestListParams{Limit: intPtr(domain.MaxPageLimit + 1)}, domain.ErrInvalidLimit},
+		{"bad cursor", api.GetPullRequestListParams{Cursor: str("???")}, domain.ErrInvalidCursor},
+		{"cursor of other sort", api.GetPullRequestListParams{Sort: sort("created_at_asc"), Cursor: &descCursor}, domain.ErrInvalidCursor},
+		{"cursor of default sort", api.GetPullRequestListParams{Cursor: &descCursor}, nil},
+		{"ok", api.GetPullRequestListParams{AuthorId: str("u1"), Q: str("fix"), Sort: sort("created_at_asc")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePullRequestFilter(tt.params)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestValidateReviewerStrategy(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		wantError error
+	}{
+		{"", domain.ErrInvalidStrategy},
+		{"random", domain.ErrInvalidStrategy},
+		{"RANDOM", nil},
+		{"LEAST_LOADED", nil},
+		{"ROUND_ROBIN", nil},
+		{"WEIGHTED", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			err := ValidateReviewerStrategy(tt.strategy)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestValidateTeamSettings(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strategy := func(v string) *api.ReviewerStrategy { s := api.ReviewerStrategy(v); return &s }
+
+	policy := func(v string) *api.EscalationPolicy {
+		p := api.EscalationPolicy(v)
+		return &p
+	}
+
+	tests := []struct {
+		name      string
+		settings  api.PostTeamSettingsJSONRequestBody
+		wantError error
+	}{
+		{"empty team name", api.PostTeamSettingsJSONRequestBody{TeamName: ""}, domain.ErrTeamNameEmpty},
+		{"bad strategy", api.PostTeamSettingsJSONRequestBody{TeamName: "a", ReviewerStrategy: strategy("FIFO")}, domain.ErrInvalidStrategy},
+		{"negative min", api.PostTeamSettingsJSONRequestBody{TeamName: "a", MinReviewers: intPtr(-1)}, domain.ErrInvalidSettings},
+		{"zero max", api.PostTeamSettingsJSONRequestBody{TeamName: "a", MaxReviewers: intPtr(0)}, domain.ErrInvalidSettings},
+		{"min greater than max", api.PostTeamSettingsJSONRequestBody{TeamName: "a", MinReviewers: intPtr(3), MaxReviewers: intPtr(2)}, domain.ErrInvalidSettings},
+		{"negative required approvals", api.PostTeamSettingsJSONRequestBody{TeamName: "a", RequiredApprovals: intPtr(-1)}, domain.ErrInvalidSettings},
+		{"negative review sla", api.PostTeamSettingsJSONRequestBody{TeamName: "a", ReviewSlaMinutes: intPtr(-1)}, domain.ErrInvalidSettings},
+		{"bad escalation policy", api.PostTeamSettingsJSONRequestBody{TeamName: "a", EscalationPolicy: policy("PAGE")}, domain.ErrInvalidSettings},
+		{"ok", api.PostTeamSettingsJSONRequestBody{TeamName: "a", MinReviewers: intPtr(1), MaxReviewers: intPtr(1)}, nil},
+		{"ok sla", api.PostTeamSettingsJSONRequestBody{TeamName: "a", ReviewSlaMinutes: intPtr(1440), EscalationPolicy: policy("REASSIGN")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTeamSettings(tt.settings)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestValidateTeamMembership(t *testing.T) {
+	member := api.TeamMember{UserId: "u1", Username: "Alice", IsActive: true}
+
+	t.Run("add", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamNameEmpty, ValidateAddTeamMembers(api.PostTeamMembersAddJSONRequestBody{Members: []api.TeamMember{member}}))
+		assert.Equal(t, domain.ErrTeamEmptyMembers, ValidateAddTeamMembers(api.PostTeamMembersAddJSONRequestBody{TeamName: "a"}))
+		assert.Equal(t, domain.ErrInvalidUser, ValidateAddTeamMembers(api.PostTeamMembersAddJSONRequestBody{
+			TeamName: "a", Members: []api.TeamMember{{UserId: "x1", Username: "Bob"}},
+		}))
+		assert.NoError(t, ValidateAddTeamMembers(api.PostTeamMembersAddJSONRequestBody{TeamName: "a", Members: []api.TeamMember{member}}))
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamEmptyMembers, ValidateSyncTeam(api.PostTeamSyncJSONRequestBody{TeamName: "a"}))
+		assert.Equal(t, domain.ErrInvalidUser, ValidateSyncTeam(api.PostTeamSyncJSONRequestBody{
+			TeamName: "a", Members: []api.TeamMember{member, member},
+		}))
+		assert.NoError(t, ValidateSyncTeam(api.PostTeamSyncJSONRequestBody{TeamName: "a", Members: []api.TeamMember{member}}))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamNameEmpty, ValidateRemoveTeamMembers(api.PostTeamMembersRemoveJSONRequestBody{UserIds: []string{"u1"}}))
+		assert.Equal(t, domain.ErrInvalidUser, ValidateRemoveTeamMembers(api.PostTeamMembersRemoveJSONRequestBody{TeamName: "a"}))
+		assert.Equal(t, domain.ErrInvalidUser, ValidateRemoveTeamMembers(api.PostTeamMembersRemoveJSONRequestBody{TeamName: "a", UserIds: []string{"u1", "2"}}))
+		assert.NoError(t, ValidateRemoveTeamMembers(api.PostTeamMembersRemoveJSONRequestBody{TeamName: "a", UserIds: []string{"u1"}}))
+	})
+
+	t.Run("move", func(t *testing.T) {
+		assert.Equal(t, domain.ErrInvalidUser, ValidateMoveTeamMember(api.PostTeamMembersMoveJSONRequestBody{UserId: "1", TeamName: "a"}))
+		assert.Equal(t, domain.ErrTeamNameEmpty, ValidateMoveTeamMember(api.PostTeamMembersMoveJSONRequestBody{UserId: "u1"}))
+		assert.NoError(t, ValidateMoveTeamMember(api.PostTeamMembersMoveJSONRequestBody{UserId: "u1", TeamName: "a"}))
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamNameEmpty, ValidateRenameTeam(api.PostTeamRenameJSONRequestBody{TeamName: "a", NewTeamName: " "}))
+		assert.NoError(t, ValidateRenameTeam(api.PostTeamRenameJSONRequestBody{TeamName: "a", NewTeamName: "b"}))
+	})
+}
+
+func TestValidateWebhook(t *testing.T) {
+	valid := func() api.PostWebhooksAddJSONRequestBody {
+		return api.PostWebhooksAddJSONRequestBody{
+			TeamName: "backend",
+			Url:      "https://bots.example.com/hooks",
+			Secret:   "0123456789abcdef",
+			Events:   []api.WebhookEventType{api.PullRequestCreated},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(req *api.PostWebhooksAddJSONRequestBody)
+		wantError error
+	}{
+		{"ok", func(req *api.PostWebhooksAddJSONRequestBody) {}, nil},
+		{"empty team name", func(req *api.PostWebhooksAddJSONRequestBody) { req.TeamName = "" }, domain.ErrTeamNameEmpty},
+		{"relative url", func(req *api.PostWebhooksAddJSONRequestBody) { req.Url = "/hooks" }, domain.ErrInvalidWebhook},
+		{"ftp url", func(req *api.PostWebhooksAddJSONRequestBody) { req.Url = "ftp://example.com" }, domain.ErrInvalidWebhook},
+		{"short secret", func(req *api.PostWebhooksAddJSONRequestBody) { req.Secret = "short" }, domain.ErrInvalidWebhook},
+		{"no events", func(req *api.PostWebhooksAddJSONRequestBody) { req.Events = nil }, domain.ErrInvalidWebhook},
+		{"unknown event", func(req *api.PostWebhooksAddJSONRequestBody) {
+			req.Events = []api.WebhookEventType{"pull_request.deleted"}
+		}, domain.ErrInvalidWebhook},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			assert.Equal(t, tt.wantError, ValidateWebhook(req))
+		})
+	}
+}
+
+func TestValidateWebhookId(t *testing.T) {
+	assert.NoError(t, ValidateWebhookId("wh-12"))
+	assert.Equal(t, domain.ErrInvalidWebhook, ValidateWebhookId("wh-"))
+	assert.Equal(t, domain.ErrInvalidWebhook, ValidateWebhookId("12"))
+	assert.Equal(t, domain.ErrInvalidWebhook, ValidateWebhookId("wh-1a"))
+}
+
+func TestValidateIssueToken(t *testing.T) {
+	userID := "u2"
+	badUserID := "2"
+
+	assert.NoError(t, ValidateIssueToken(api.PostAuthTokenIssueJSONRequestBody{Role: api.TokenRoleAdmin}))
+	assert.NoError(t, ValidateIssueToken(api.PostAuthTokenIssueJSONRequestBody{Role: api.TokenRoleUser, UserId: &userID}))
+	assert.Equal(t, domain.ErrInvalidToken, ValidateIssueToken(api.PostAuthTokenIssueJSONRequestBody{Role: api.TokenRoleUser}))
+	assert.Equal(t, domain.ErrInvalidToken, ValidateIssueToken(api.PostAuthTokenIssueJSONRequestBody{Role: "root"}))
+	assert.Equal(t, domain.ErrInvalidToken,
+		ValidateIssueToken(api.PostAuthTokenIssueJSONRequestBody{Role: api.TokenRoleAdmin, UserId: &badUserID}))
+}
+
+func TestValidateTokenId(t *testing.T) {
+	assert.NoError(t, ValidateTokenId("tok-3"))
+	assert.Equal(t, domain.ErrInvalidToken, ValidateTokenId("tok-"))
+	assert.Equal(t, domain.ErrInvalidToken, ValidateTokenId("3"))
+	assert.Equal(t, domain.ErrInvalidToken, ValidateTokenId("tok-x"))
+}
+
+func TestValidateAvailabilityWindow(t *testing.T) {
+	from := time.Date(2030, 1, 10, 9, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	assert.NoError(t, ValidateAvailabilityWindow(api.PostUsersAvailabilityAddJSONRequestBody{UserId: "u1", From: from, To: to}))
+	assert.Equal(t, domain.ErrInvalidUser,
+		ValidateAvailabilityWindow(api.PostUsersAvailabilityAddJSONRequestBody{UserId: "1", From: from, To: to}))
+	assert.Equal(t, domain.ErrInvalidAvailability,
+		ValidateAvailabilityWindow(api.PostUsersAvailabilityAddJSONRequestBody{UserId: "u1", From: to, To: from}))
+	assert.Equal(t, domain.ErrInvalidAvailability,
+		ValidateAvailabilityWindow(api.PostUsersAvailabilityAddJSONRequestBody{UserId: "u1", From: from, To: from}))
+}
+
+func TestValidateAvailabilityWindowId(t *testing.T) {
+	assert.NoError(t, ValidateAvailabilityWindowId("av-7"))
+	assert.Equal(t, domain.ErrInvalidAvailability, ValidateAvailabilityWindowId("av-"))
+	assert.Equal(t, domain.ErrInvalidAvailability, ValidateAvailabilityWindowId("7"))
+	assert.Equal(t, domain.ErrInvalidAvailability, ValidateAvailabilityWindowId("av-7x"))
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"net/url"
+	"pr-reviewer/internal/api"
+	"pr-reviewer/internal/domain"
+	"strings"
+	"unicode"
+)
+
+// minWebhookSecretLen минимальная длина секрета для подписи событий
+const minWebhookSecretLen = 16
+
+// ValidateWebhook проверяет подписку: абсолютный http(s) URL, секрет и непустой список событий
+func ValidateWebhook(req api.PostWebhooksAddJSONRequestBody) error {
+	if err := ValidateTeamName(req.TeamName); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.ErrInvalidWebhook
+	}
+
+	if len(strings.TrimSpace(req.Secret)) < minWebhookSecretLen {
+		return domain.ErrInvalidWebhook
+	}
+
+	if len(req.Events) == 0 {
+		return domain.ErrInvalidWebhook
+	}
+	for _, e := range req.Events {
+		if _, ok := domain.MapStringToWebhookEventType[string(e)]; !ok {
+			return domain.ErrInvalidWebhook
+		}
+	}
+
+	return nil
+}
+
+func ValidateWebhookId(id string) error {
+	if len(id) < 4 || id[:3] != "wh-" {
+		return domain.ErrInvalidWebhook
+	}
+	for _, r := range id[3:] {
+		if !unicode.IsDigit(r) {
+			return domain.ErrInvalidWebhook
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package assignment запись изменений назначений ревьюверов, общая для репозиториев:
+// вызывается в транзакции, которая меняет assigned_pr
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	insertEvent = `
+		INSERT INTO assignment_event (pr_id, actor_id, old_reviewer_id, new_reviewer_id, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	deleteReviewer = `
+		DELETE FROM assigned_pr WHERE pr_id = $1 AND reviewer_id = $2;
+	`
+
+	insertReplacement = `
+		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES ($1, $2);
+	`
+
+	lockLastReviewer = `
+		SELECT COALESCE(last_reviewer_id, 0) FROM team WHERE id = $1 FOR UPDATE;
+	`
+
+	updateLastReviewer = `
+		UPDATE team SET last_reviewer_id = $1 WHERE id = $2;
+	`
+)
+
+// ApplyReplacements заменяет ревьюверов PullRequest по replacements в транзакции tx.
+// Если прежний ревьювер уже снят с PR, возвращает domain.ErrNotAssigned
+func ApplyReplacements(ctx context.Context, tx pgx.Tx, replacements []domain.ReviewReplacement) error {
+	for _, rep := range replacements {
+		tag, err := tx.Exec(ctx, deleteReviewer, rep.PullRequestID, rep.OldReviewerID)
+		if err != nil {
+			return fmt.Errorf("failed to delete old reviewer: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrNotAssigned
+		}
+		if _, err := tx.Exec(ctx, insertReplacement, rep.PullRequestID, rep.NewReviewerID); err != nil {
+			return fmt.Errorf("failed to insert new reviewer: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertEvents дописывает события в журнал назначений в транзакции tx
+func InsertEvents(ctx context.Context, tx pgx.Tx, events []domain.AssignmentEvent) error {
+	for _, e := range events {
+		_, err := tx.Exec(ctx, insertEvent,
+			e.PullRequestID, e.ActorID, e.OldReviewerID, e.NewReviewerID, e.Reason, e.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert assignment event: %w", err)
+		}
+	}
+	return nil
+}
+
+// AdvanceRoundRobin сохраняет сдвиги очередей ROUND_ROBIN в транзакции tx. Строка команды
+// блокируется до конца транзакции; если очередь уже сдвинута другим запросом,
+// возвращается domain.ErrRoundRobinMoved и назначение нужно выбрать заново
+func AdvanceRoundRobin(ctx context.Context, tx pgx.Tx, moves domain.RoundRobinMoves) error {
+	// Блокировки берутся в порядке id команд, чтобы параллельные операции не ждали друг друга по кругу
+	sorted := slices.SortedFunc(slices.Values(moves), func(a, b domain.RoundRobinMove) int {
+		return a.TeamID - b.TeamID
+	})
+
+	for _, m := range sorted {
+		var current int
+		if err := tx.QueryRow(ctx, lockLastReviewer, m.TeamID).Scan(&current); err != nil {
+			return fmt.Errorf("failed to lock last reviewer: %w", err)
+		}
+		if current != m.From {
+			return domain.ErrRoundRobinMoved
+		}
+		if _, err := tx.Exec(ctx, updateLastReviewer, m.To, m.TeamID); err != nil {
+			return fmt.Errorf("failed to update last reviewer: %w", err)
+		}
+	}
+	return nil
+}
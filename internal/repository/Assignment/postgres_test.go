@@ -0,0 +1,86 @@
+package assignment
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesMatchSchema(t *testing.T) {
+	pool := pgtest.New(t)
+
+	pgtest.Prepare(t, pool, map[string]string{
+		"deleteReviewer":     deleteReviewer,
+		"insertReplacement":  insertReplacement,
+		"insertEvent":        insertEvent,
+		"lockLastReviewer":   lockLastReviewer,
+		"updateLastReviewer": updateLastReviewer,
+	})
+}
+
+func TestInsertEvents_AppendOnly(t *testing.T) {
+	pool := pgtest.New(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob');
+		INSERT INTO pull_request (id, title, author_id, status_id) VALUES (10, 'Add search', 1, 1);
+	`)
+	require.NoError(t, err)
+
+	userID := 2
+	events := domain.NewActivityEvents([]int{10}, userID, false, nil, time.Now())
+	err = pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		return InsertEvents(ctx, tx, events)
+	})
+	require.NoError(t, err)
+
+	for name, query := range map[string]string{
+		"update":    `UPDATE assignment_event SET reason = 'ASSIGNED'`,
+		"delete":    `DELETE FROM assignment_event`,
+		"truncate":  `TRUNCATE assignment_event`,
+		"delete pr": `DELETE FROM pull_request WHERE id = 10`,
+	} {
+		_, err := pool.Exec(ctx, query)
+		assert.Error(t, err, name)
+	}
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM assignment_event`).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestAdvanceRoundRobin(t *testing.T) {
+	pool := pgtest.New(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, team_id) VALUES (11, 'Alice', 1), (12, 'Bob', 1);
+	`)
+	require.NoError(t, err)
+
+	advance := func(moves domain.RoundRobinMoves) error {
+		return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			return AdvanceRoundRobin(ctx, tx, moves)
+		})
+	}
+
+	require.NoError(t, advance(domain.RoundRobinMoves{{TeamID: 1, From: 0, To: 11}}))
+
+	// Позицию 0 уже сдвинули до 11
+	err = advance(domain.RoundRobinMoves{{TeamID: 1, From: 0, To: 12}})
+	assert.ErrorIs(t, err, domain.ErrRoundRobinMoved)
+
+	require.NoError(t, advance(domain.RoundRobinMoves{{TeamID: 1, From: 11, To: 12}}))
+
+	var last int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT last_reviewer_id FROM team WHERE id = 1`).Scan(&last))
+	assert.Equal(t, 12, last)
+}
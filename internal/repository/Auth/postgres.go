@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuthRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuthRepository(pool *pgxpool.Pool) *AuthRepository {
+	return &AuthRepository{
+		pool: pool,
+	}
+}
+
+const (
+	checkUserById = `
+		SELECT EXISTS(SELECT 1 FROM users WHERE id = $1);
+	`
+
+	createToken = `
+		INSERT INTO api_token (role, user_id, token_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	getActiveTokenByHash = `
+		SELECT id, role, user_id, token_hash, created_at, revoked_at
+		FROM api_token
+		WHERE token_hash = $1 AND revoked_at IS NULL;
+	`
+
+	// revokeToken повторный отзыв сохраняет время первого
+	revokeToken = `
+		UPDATE api_token SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE id = $1
+		RETURNING id, role, user_id, token_hash, created_at, revoked_at;
+	`
+)
+
+func (r *AuthRepository) ExistsUserById(ctx context.Context, id int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.ExistsUserById")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkUserById, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user existance by id: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *AuthRepository) Create(ctx context.Context, t *domain.ApiToken) (*domain.ApiToken, error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.Create")
+	defer span.End()
+
+	err := r.pool.QueryRow(ctx, createToken, t.Role, t.UserID, t.Hash, t.CreatedAt).Scan(&t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert api token: %w", err)
+	}
+	return t, nil
+}
+
+// GetActiveByHash возвращает неотозванный токен, nil если такого нет
+func (r *AuthRepository) GetActiveByHash(ctx context.Context, hash string) (*domain.ApiToken, error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.GetActiveByHash")
+	defer span.End()
+
+	t, err := scanToken(r.pool.QueryRow(ctx, getActiveTokenByHash, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	return t, nil
+}
+
+// Revoke отзывает токен, nil если токена нет
+func (r *AuthRepository) Revoke(ctx context.Context, id int, at time.Time) (*domain.ApiToken, error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.Revoke")
+	defer span.End()
+
+	t, err := scanToken(r.pool.QueryRow(ctx, revokeToken, id, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api token: %w", err)
+	}
+	return t, nil
+}
+
+func scanToken(row pgx.Row) (*domain.ApiToken, error) {
+	var t domain.ApiToken
+	var role string
+	if err := row.Scan(&t.ID, &role, &t.UserID, &t.Hash, &t.CreatedAt, &t.RevokedAt); err != nil {
+		return nil, err
+	}
+	t.Role = domain.TokenRole(role)
+	return &t, nil
+}
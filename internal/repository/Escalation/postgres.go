@@ -0,0 +1,213 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/tracing"
+	webhook "pr-reviewer/internal/repository/Webhook"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EscalationRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewEscalationRepository(pool *pgxpool.Pool, logger logger.Logger) *EscalationRepository {
+	return &EscalationRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+const (
+	// getStaleReviews назначения OPEN PR, где ревьювер не оставил вердикт за SLA
+	// команды автора. Отсчет идет от последнего назначения ревьювера или
+	// переоткрытия PR; уже захваченные назначения пропускаются
+	getStaleReviews = `
+		WITH assigned AS (
+			SELECT a.pr_id, a.reviewer_id, pr.author_id, t.review_sla_minutes, t.escalation_policy,
+				GREATEST(pr.created_at, COALESCE((
+					SELECT MAX(e.created_at) FROM assignment_event e
+					WHERE e.pr_id = a.pr_id AND (e.new_reviewer_id = a.reviewer_id OR e.reason = 'REOPENED')
+				), pr.created_at)) AS since
+			FROM assigned_pr a
+			JOIN pull_request pr ON pr.id = a.pr_id
+			JOIN users au ON au.id = pr.author_id
+			JOIN team t ON t.id = au.team_id
+			WHERE pr.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+				AND t.review_sla_minutes > 0
+		)
+		SELECT s.pr_id, s.reviewer_id, s.author_id, s.escalation_policy, s.since
+		FROM assigned s
+		WHERE s.since + make_interval(mins => s.review_sla_minutes) <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM review_verdict v
+				WHERE v.pr_id = s.pr_id AND v.reviewer_id = s.reviewer_id AND v.created_at >= s.since
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM review_escalation x
+				WHERE x.pr_id = s.pr_id AND x.reviewer_id = s.reviewer_id AND x.stale_since = s.since
+			)
+		ORDER BY s.since, s.pr_id, s.reviewer_id
+		LIMIT $2;
+	`
+
+	claimEscalation = `
+		INSERT INTO review_escalation (pr_id, reviewer_id, policy, stale_since, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (pr_id, reviewer_id, stale_since) DO NOTHING
+		RETURNING id;
+	`
+
+	completeEscalation = `
+		UPDATE review_escalation SET action = $1, new_reviewer_id = $2 WHERE id = $3 AND action IS NULL;
+	`
+
+	// getExpiredClaims захваты без action, взятые до $1. Если действие по политике
+	// успело выполниться, new_reviewer_id берется из события ESCALATED журнала
+	getExpiredClaims = `
+		SELECT x.id, x.pr_id, x.reviewer_id, pr.author_id, x.policy, x.stale_since, x.created_at, e.new_reviewer_id
+		FROM review_escalation x
+		JOIN pull_request pr ON pr.id = x.pr_id
+		LEFT JOIN LATERAL (
+			SELECT ev.new_reviewer_id FROM assignment_event ev
+			WHERE ev.pr_id = x.pr_id AND ev.reason = 'ESCALATED' AND ev.created_at >= x.created_at
+				AND ((x.policy = 'REASSIGN' AND ev.old_reviewer_id = x.reviewer_id)
+					OR (x.policy = 'ADD_REVIEWER' AND ev.old_reviewer_id IS NULL))
+			ORDER BY ev.id
+			LIMIT 1
+		) e ON TRUE
+		WHERE x.action IS NULL AND x.created_at <= $1
+		ORDER BY x.id
+		LIMIT $2;
+	`
+
+	releaseEscalation = `
+		DELETE FROM review_escalation WHERE id = $1 AND action IS NULL;
+	`
+)
+
+// GetStaleReviews возвращает до limit просроченных на момент now ревью, от самых старых
+func (r *EscalationRepository) GetStaleReviews(ctx context.Context, now time.Time, limit int) ([]domain.StaleReview, error) {
+	ctx, span := tracing.Start(ctx, "EscalationRepository.GetStaleReviews")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getStaleReviews, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale reviews: %w", err)
+	}
+	defer rows.Close()
+
+	stale := make([]domain.StaleReview, 0)
+	for rows.Next() {
+		var s domain.StaleReview
+		var policy string
+		if err := rows.Scan(&s.PullRequestID, &s.ReviewerID, &s.AuthorID, &policy, &s.Since); err != nil {
+			return nil, fmt.Errorf("failed to scan stale review: %w", err)
+		}
+		s.Policy = domain.MapStringToEscalationPolicy[policy]
+		stale = append(stale, s)
+	}
+
+	return stale, nil
+}
+
+// Claim захватывает эскалацию назначения esc и заполняет esc.ID;
+// false, если ее уже захватил другой процесс
+func (r *EscalationRepository) Claim(ctx context.Context, esc *domain.ReviewEscalation) (bool, error) {
+	ctx, span := tracing.Start(ctx, "EscalationRepository.Claim")
+	defer span.End()
+
+	err := r.pool.QueryRow(ctx, claimEscalation,
+		esc.PullRequestID, esc.ReviewerID, esc.Policy, esc.StaleSince, esc.CreatedAt,
+	).Scan(&esc.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim escalation: %w", err)
+	}
+	return true, nil
+}
+
+// Complete сохраняет выполненное действие эскалации и события для подписчиков
+func (r *EscalationRepository) Complete(ctx context.Context, esc *domain.ReviewEscalation, hooks []domain.WebhookEvent) error {
+	ctx, span := tracing.Start(ctx, "EscalationRepository.Complete")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, completeEscalation, esc.Action, esc.NewReviewerID, esc.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete escalation: %w", err)
+	}
+	// Эскалацию уже завершила сверка просроченных захватов
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// GetExpiredClaims возвращает до limit захватов, не завершенных с момента before.
+// Action заполняется политикой, если ее действие найдено в журнале назначений, иначе пустой
+func (r *EscalationRepository) GetExpiredClaims(ctx context.Context, before time.Time, limit int) ([]domain.ReviewEscalation, error) {
+	ctx, span := tracing.Start(ctx, "EscalationRepository.GetExpiredClaims")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getExpiredClaims, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := make([]domain.ReviewEscalation, 0)
+	for rows.Next() {
+		var esc domain.ReviewEscalation
+		var policy string
+		err := rows.Scan(&esc.ID, &esc.PullRequestID, &esc.ReviewerID, &esc.AuthorID, &policy, &esc.StaleSince, &esc.CreatedAt, &esc.NewReviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expired claim: %w", err)
+		}
+		esc.Policy = domain.MapStringToEscalationPolicy[policy]
+		if esc.NewReviewerID != nil {
+			esc.Action = esc.Policy
+		}
+		claims = append(claims, esc)
+	}
+
+	return claims, nil
+}
+
+// Release снимает захват незавершенной эскалации, чтобы повторить ее позже
+func (r *EscalationRepository) Release(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "EscalationRepository.Release")
+	defer span.End()
+
+	if _, err := r.pool.Exec(ctx, releaseEscalation, id); err != nil {
+		return fmt.Errorf("failed to release escalation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package escalation
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"pr-reviewer/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationRepository_GetExpiredClaims(t *testing.T) {
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	repo := NewEscalationRepository(pool, l)
+	ctx := context.Background()
+
+	claimedAt := time.Date(2026, 10, 17, 11, 0, 0, 0, time.UTC)
+	// Замена по захвату 1 успела попасть в журнал, по захвату 2 действия не было,
+	// захват 3 уже завершен
+	_, err = pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, is_active, team_id) VALUES (1, 'Alice', TRUE, 1), (2, 'Bob', TRUE, 1), (3, 'Carol', TRUE, 1);
+		INSERT INTO pull_request (id, title, author_id, status_id) VALUES (10, 'Add search', 1, 1), (11, 'Fix login', 1, 1);
+		INSERT INTO review_escalation (id, pr_id, reviewer_id, policy, action, stale_since, created_at) VALUES
+			(1, 10, 2, 'REASSIGN', NULL, $1::TIMESTAMP - INTERVAL '1 day', $1),
+			(2, 11, 2, 'ADD_REVIEWER', NULL, $1::TIMESTAMP - INTERVAL '1 day', $1),
+			(3, 11, 3, 'NOTIFY', 'NOTIFY', $1::TIMESTAMP - INTERVAL '1 day', $1);
+		INSERT INTO assignment_event (pr_id, old_reviewer_id, new_reviewer_id, reason, created_at)
+		VALUES (10, 2, 3, 'ESCALATED', $1::TIMESTAMP + INTERVAL '1 second');
+		INSERT INTO webhook_subscription (team_id, url, secret, events)
+		VALUES (1, 'http://hooks.local', 's', ARRAY['pull_request.review_stale']);
+	`, claimedAt)
+	require.NoError(t, err)
+
+	claims, err := repo.GetExpiredClaims(ctx, claimedAt.Add(-time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, claims)
+
+	claims, err = repo.GetExpiredClaims(ctx, claimedAt.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claims, 2)
+	assert.Equal(t, domain.EscalationReassign, claims[0].Action)
+	assert.Equal(t, 3, *claims[0].NewReviewerID)
+	assert.Empty(t, claims[1].Action)
+	assert.Nil(t, claims[1].NewReviewerID)
+
+	// Повторное завершение не ставит вебхук второй раз
+	hooks := []domain.WebhookEvent{domain.NewReviewStaleWebhookEvent(&claims[0], claimedAt)}
+	require.NoError(t, repo.Complete(ctx, &claims[0], hooks))
+	require.NoError(t, repo.Complete(ctx, &claims[0], hooks))
+	var queued int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhook_outbox`).Scan(&queued))
+	assert.Equal(t, 1, queued)
+}
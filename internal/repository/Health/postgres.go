@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/tracing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HealthRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewHealthRepository(pool *pgxpool.Pool) *HealthRepository {
+	return &HealthRepository{
+		pool: pool,
+	}
+}
+
+// getSchemaVersion таблица ведется golang-migrate
+const getSchemaVersion = `
+	SELECT version, dirty FROM schema_migrations LIMIT 1;
+`
+
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "HealthRepository.Ping")
+	defer span.End()
+
+	if err := r.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+func (r *HealthRepository) GetSchemaVersion(ctx context.Context) (*domain.SchemaVersion, error) {
+	ctx, span := tracing.Start(ctx, "HealthRepository.GetSchemaVersion")
+	defer span.End()
+
+	v := &domain.SchemaVersion{}
+	if err := r.pool.QueryRow(ctx, getSchemaVersion).Scan(&v.Version, &v.Dirty); err != nil {
+		return nil, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return v, nil
+}
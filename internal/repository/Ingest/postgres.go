@@ -0,0 +1,45 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IngestRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewIngestRepository(pool *pgxpool.Pool) *IngestRepository {
+	return &IngestRepository{
+		pool: pool,
+	}
+}
+
+const (
+	getLinkedPullRequest = `
+		SELECT pr_id FROM external_pull_request
+		WHERE provider = $1 AND repository = $2 AND number = $3;
+	`
+)
+
+// GetLinkedPullRequest возвращает id PullRequest, связанного с внешним, 0 если связи нет
+func (r *IngestRepository) GetLinkedPullRequest(ctx context.Context, ev *domain.ExternalPullRequestEvent) (int, error) {
+	ctx, span := tracing.Start(ctx, "IngestRepository.GetLinkedPullRequest")
+	defer span.End()
+
+	var prID int
+	err := r.pool.QueryRow(ctx, getLinkedPullRequest, ev.Provider, ev.Repository, ev.Number).Scan(&prID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get linked pull_request: %w", err)
+	}
+	return prID, nil
+}
@@ -2,9 +2,16 @@ package pullrequest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/tracing"
+	assignment "pr-reviewer/internal/repository/Assignment"
+	webhook "pr-reviewer/internal/repository/Webhook"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -22,22 +29,53 @@ func NewPullRequestRepository(pool *pgxpool.Pool, logger logger.Logger) *PullReq
 	}
 }
 
+// unavailableNow окно недоступности кандидата u, которое идет сейчас;
+// окна хранятся в UTC
+const unavailableNow = `
+	SELECT 1 FROM user_unavailability w
+	WHERE w.user_id = u.id
+		AND w.starts_at <= (NOW() AT TIME ZONE 'UTC') AND w.ends_at > (NOW() AT TIME ZONE 'UTC')
+`
+
 const (
 	checkRPById = `
 		SELECT EXISTS (SELECT 1 FROM pull_request WHERE id = $1);
 	`
 
+	checkTeamByName = `
+		SELECT EXISTS(SELECT 1 FROM team WHERE name = $1);
+	`
+
 	getActiveTeamMembers = `
-		SELECT id, name, is_active, (SELECT name from team WHERE id = users.team_id)
-		FROM users
-		WHERE team_id = (SELECT team_id FROM users WHERE id = $1)
-			AND id <> $1
-			AND is_active = TRUE; 
+		SELECT u.id, u.name, u.is_active, (SELECT name from team WHERE id = u.team_id), u.review_weight
+		FROM users u
+		WHERE u.team_id = (SELECT team_id FROM users WHERE id = $1)
+			AND u.id <> $1
+			AND u.is_active = TRUE
+			AND NOT EXISTS (` + unavailableNow + `);
+	`
+
+	getActiveTeamMembersWithLoad = `
+		SELECT u.id, u.name, u.is_active, t.name, u.review_weight, COUNT(pr.id)
+		FROM users u
+		JOIN team t ON t.id = u.team_id
+		LEFT JOIN assigned_pr a ON a.reviewer_id = u.id
+		LEFT JOIN pull_request pr ON pr.id = a.pr_id
+			AND pr.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+		WHERE u.team_id = (SELECT team_id FROM users WHERE id = $1)
+			AND u.id <> $1
+			AND u.is_active = TRUE
+			AND NOT EXISTS (` + unavailableNow + `)
+		GROUP BY u.id, t.name
+		ORDER BY COUNT(pr.id);
 	`
 
 	createPullRequest = `
 		INSERT INTO pull_request (id, title, author_id, status_id, created_at)
-		VALUES($1, $2, $3, $4, $5);
+		VALUES($1, $2, $3, $4, $5)
+		RETURNING COALESCE((
+			SELECT t.name FROM users u JOIN team t ON t.id = u.team_id WHERE u.id = $3
+		), '');
 	`
 
 	getStatusID = `
@@ -45,35 +83,147 @@ const (
 	`
 
 	addReviewerToPullRequest = `
-		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES ($1, $2);
+		INSERT INTO assigned_pr (pr_id, reviewer_id, from_fallback) VALUES ($1, $2, $3);
 	`
 
 	getPullRequestByID = `
-		SELECT id, title, author_id, 
-		(SELECT name FROM pr_status WHERE pr_status.id = pull_request.status_id) as status,
-		created_at, merged_at
-		FROM pull_request WHERE id = $1;
+		SELECT pr.id, pr.title, pr.author_id,
+		(SELECT name FROM pr_status WHERE pr_status.id = pr.status_id) as status,
+		pr.created_at, pr.merged_at, pr.closed_at, COALESCE(t.name, '')
+		FROM pull_request pr
+		JOIN users u ON u.id = pr.author_id
+		LEFT JOIN team t ON t.id = u.team_id
+		WHERE pr.id = $1;
 	`
 
 	getReviewers = `
-		SELECT reviewer_id FROM assigned_pr WHERE pr_id = $1;
+		SELECT a.reviewer_id, a.from_fallback, u.name, u.is_active
+		FROM assigned_pr a
+		JOIN users u ON u.id = a.reviewer_id
+		WHERE a.pr_id = $1
+		ORDER BY a.reviewer_id;
 	`
 
 	updateStatus = `
 		UPDATE pull_request SET status_id = (SELECT id FROM pr_status WHERE name = $1),
-		merged_at = $2
-		WHERE id = $3;
+		merged_at = $2, closed_at = $3
+		WHERE id = $4;
+	`
+
+	nextPullRequestID = `
+		SELECT nextval('pull_request_id_seq');
+	`
+
+	// linkExternalPullRequest пропускает вставку, если внешний PR уже связан
+	linkExternalPullRequest = `
+		INSERT INTO external_pull_request (provider, repository, number, pr_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, repository, number) DO NOTHING;
+	`
+
+	getReviewerSelection = `
+		SELECT t.id, t.name, t.reviewer_strategy, COALESCE(t.last_reviewer_id, 0), t.min_reviewers, t.max_reviewers
+		FROM users u
+		JOIN team t ON t.id = u.team_id
+		WHERE u.id = $1;
+	`
+
+	getFallbackTeamIDs = `
+		SELECT fallback_team_id FROM team_fallback WHERE team_id = $1 ORDER BY position;
+	`
+
+	getActiveMembersByTeamID = `
+		SELECT u.id, u.name, u.is_active, t.name, u.review_weight, COUNT(pr.id)
+		FROM users u
+		JOIN team t ON t.id = u.team_id
+		LEFT JOIN assigned_pr a ON a.reviewer_id = u.id
+		LEFT JOIN pull_request pr ON pr.id = a.pr_id
+			AND pr.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+		WHERE u.team_id = $1 AND u.is_active = TRUE
+			AND NOT EXISTS (` + unavailableNow + `)
+		GROUP BY u.id, t.name
+		ORDER BY COUNT(pr.id);
+	`
+
+	// getLatestReviews последний вердикт назначенных ревьюверов за текущее назначение:
+	// вердикты до последнего назначения ревьювера по журналу не учитываются,
+	// ACTIVATED назначение не начинает
+	getLatestReviews = `
+		SELECT DISTINCT ON (v.reviewer_id) v.reviewer_id, v.verdict, v.created_at
+		FROM review_verdict v
+		JOIN assigned_pr a ON a.pr_id = v.pr_id AND a.reviewer_id = v.reviewer_id
+		JOIN pull_request pr ON pr.id = v.pr_id
+		WHERE v.pr_id = $1
+			AND v.created_at >= COALESCE((
+				SELECT MAX(e.created_at) FROM assignment_event e
+				WHERE e.pr_id = v.pr_id AND e.new_reviewer_id = v.reviewer_id AND e.reason <> 'ACTIVATED'
+			), pr.created_at)
+		ORDER BY v.reviewer_id, v.created_at DESC;
+	`
+
+	addReview = `
+		INSERT INTO review_verdict (pr_id, reviewer_id, verdict, created_at) VALUES ($1, $2, $3, $4);
+	`
+
+	getMergePolicy = `
+		SELECT t.required_approvals, t.block_on_changes_requested
+		FROM users u
+		JOIN team t ON t.id = u.team_id
+		WHERE u.id = $1;
+	`
+
+	getAssignmentEvents = `
+		SELECT pr_id, actor_id, old_reviewer_id, new_reviewer_id, reason, created_at
+		FROM assignment_event
+		WHERE pr_id = $1
+		ORDER BY id;
 	`
+)
 
-	deleteOldReviewer = `
-		DELETE FROM assigned_pr WHERE pr_id = $1 AND reviewer_id = $2;
+// listPullRequests PR, попадающие под PullRequestFilter: $1 - статус, $2 - автор,
+// $3 - ревьювер, $4 - команда автора, $5 - подстрока названия, [$6, $7) - окно
+// по created_at. Статус и команда сводятся к status_id и author_id, чтобы
+// использовать idx_pull_request_status_id и idx_pull_request_author_id
+const listPullRequests = `
+	SELECT pr.id, pr.title, pr.author_id, s.name, pr.created_at, pr.merged_at,
+		ARRAY(SELECT reviewer_id FROM assigned_pr WHERE pr_id = pr.id ORDER BY reviewer_id)
+	FROM pull_request pr
+	JOIN pr_status s ON s.id = pr.status_id
+	WHERE ($1::TEXT IS NULL OR pr.status_id = (SELECT id FROM pr_status WHERE name = $1))
+		AND ($2::INT IS NULL OR pr.author_id = $2)
+		AND ($3::INT IS NULL OR EXISTS (
+			SELECT 1 FROM assigned_pr a WHERE a.pr_id = pr.id AND a.reviewer_id = $3
+		))
+		AND ($4::TEXT IS NULL OR pr.author_id IN (
+			SELECT u.id FROM users u JOIN team t ON t.id = u.team_id WHERE t.name = $4
+		))
+		AND ($5::TEXT IS NULL OR pr.title ILIKE '%' || $5 || '%' ESCAPE '\')
+		AND ($6::TIMESTAMP IS NULL OR pr.created_at >= $6)
+		AND ($7::TIMESTAMP IS NULL OR pr.created_at < $7)
+`
+
+// Страница списка после курсора ($8, $9) размером $10
+const (
+	listPullRequestsDesc = listPullRequests + `
+		AND ($8::TIMESTAMP IS NULL OR (pr.created_at, pr.id) < ($8, $9::INT))
+	ORDER BY pr.created_at DESC, pr.id DESC
+	LIMIT $10;
 	`
-	insertNewReviewer = `
-		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES($1, $2);
+
+	listPullRequestsAsc = listPullRequests + `
+		AND ($8::TIMESTAMP IS NULL OR (pr.created_at, pr.id) > ($8, $9::INT))
+	ORDER BY pr.created_at, pr.id
+	LIMIT $10;
 	`
 )
 
+// likeEscaper экранирует спецсимволы LIKE в подстроке поиска
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func (r *PullRequestRepository) ExistsById(ctx context.Context, id int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.ExistsById")
+	defer span.End()
+
 	var exists bool
 	err := r.pool.QueryRow(ctx, checkRPById, id).Scan(&exists)
 	if err != nil {
@@ -82,7 +232,22 @@ func (r *PullRequestRepository) ExistsById(ctx context.Context, id int) (bool, e
 	return exists, nil
 }
 
+func (r *PullRequestRepository) ExistsTeamByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.ExistsTeamByName")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkTeamByName, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check team existance by name: %w", err)
+	}
+	return exists, nil
+}
+
 func (r *PullRequestRepository) GetActiveTeamMembersExceptAuthor(ctx context.Context, authorId int) ([]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetActiveTeamMembersExceptAuthor")
+	defer span.End()
+
 	rows, err := r.pool.Query(ctx, getActiveTeamMembers, authorId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active team members: %w", err)
@@ -97,6 +262,39 @@ func (r *PullRequestRepository) GetActiveTeamMembersExceptAuthor(ctx context.Con
 			&u.Username,
 			&u.IsActive,
 			&u.TeamName,
+			&u.ReviewWeight,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		activeMembers = append(activeMembers, u)
+	}
+
+	return activeMembers, nil
+}
+
+// GetActiveTeamMembersWithLoad возвращает активных участников команды автора
+// с количеством назначенных им OPEN PullRequest, по возрастанию нагрузки
+func (r *PullRequestRepository) GetActiveTeamMembersWithLoad(ctx context.Context, authorId int) ([]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetActiveTeamMembersWithLoad")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getActiveTeamMembersWithLoad, authorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active team members with load: %w", err)
+	}
+	defer rows.Close()
+
+	activeMembers := make([]domain.User, 0)
+	for rows.Next() {
+		var u domain.User
+		err := rows.Scan(
+			&u.ID,
+			&u.Username,
+			&u.IsActive,
+			&u.TeamName,
+			&u.ReviewWeight,
+			&u.OpenReviews,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
@@ -107,7 +305,13 @@ func (r *PullRequestRepository) GetActiveTeamMembersExceptAuthor(ctx context.Con
 	return activeMembers, nil
 }
 
-func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+func (r *PullRequestRepository) Create(
+	ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+	moves domain.RoundRobinMoves,
+) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.Create")
+	defer span.End()
+
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
@@ -118,24 +322,47 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 		}
 	}()
 
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return nil, err
+	}
+
 	var statusID int
 	err = tx.QueryRow(ctx, getStatusID, pr.Status).Scan(&statusID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status_id: %w", err)
 	}
 
-	_, err = tx.Exec(ctx, createPullRequest, pr.ID, pr.Name, pr.AuthorID, statusID, pr.CreatedAt)
+	err = tx.QueryRow(ctx, createPullRequest, pr.ID, pr.Name, pr.AuthorID, statusID, pr.CreatedAt).Scan(&pr.AuthorTeamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert pull_request: %w", err)
 	}
 
+	if ext := pr.External; ext != nil {
+		tag, err := tx.Exec(ctx, linkExternalPullRequest, ext.Provider, ext.Repository, ext.Number, pr.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to link external pull_request: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, domain.ErrExternalPullRequestLinked
+		}
+	}
+
 	for _, reviewerID := range pr.AssignedReviewers {
-		_, err := tx.Exec(ctx, addReviewerToPullRequest, pr.ID, reviewerID)
+		fromFallback := slices.Contains(pr.FallbackReviewers, reviewerID)
+		_, err := tx.Exec(ctx, addReviewerToPullRequest, pr.ID, reviewerID, fromFallback)
 		if err != nil {
 			return nil, fmt.Errorf("faield to insert reviewer: %w", err)
 		}
 	}
 
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit: %w", err)
 	}
@@ -144,11 +371,14 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 }
 
 func (r *PullRequestRepository) GetById(ctx context.Context, id int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetById")
+	defer span.End()
+
 	pr := &domain.PullRequest{}
 	var status string
 
 	err := r.pool.QueryRow(ctx, getPullRequestByID, id).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.ID, &pr.Name, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.AuthorTeamName,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull_request: %w", err)
@@ -163,28 +393,160 @@ func (r *PullRequestRepository) GetById(ctx context.Context, id int) (*domain.Pu
 	defer rows.Close()
 
 	for rows.Next() {
-		var reviewerID int
-		if err := rows.Scan(&reviewerID); err != nil {
+		var reviewer domain.User
+		var fromFallback bool
+		if err := rows.Scan(&reviewer.ID, &fromFallback, &reviewer.Username, &reviewer.IsActive); err != nil {
 			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
 		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer.ID)
+		pr.Reviewers = append(pr.Reviewers, reviewer)
+		if fromFallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, reviewer.ID)
+		}
+	}
+
+	reviews, err := r.getLatestReviews(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	pr.Reviews = reviews
 
 	return pr, nil
 }
 
-func (r *PullRequestRepository) UpdateStatus(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
-	_, err := r.pool.Exec(ctx, updateStatus, pr.Status, pr.MergedAt, pr.ID)
+func (r *PullRequestRepository) getLatestReviews(ctx context.Context, prID int) ([]domain.Review, error) {
+	rows, err := r.pool.Query(ctx, getLatestReviews, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []domain.Review
+	for rows.Next() {
+		var review domain.Review
+		var verdict string
+		if err := rows.Scan(&review.ReviewerID, &verdict, &review.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		review.Verdict = domain.MapStringToReviewVerdict[verdict]
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// AddReview сохраняет вердикт ревьювера
+func (r *PullRequestRepository) AddReview(ctx context.Context, prID int, review *domain.Review) error {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.AddReview")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, addReview, prID, review.ReviewerID, review.Verdict, review.SubmittedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert review: %w", err)
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) UpdateStatus(
+	ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.UpdateStatus")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	_, err = tx.Exec(ctx, updateStatus, pr.Status, pr.MergedAt, pr.ClosedAt, pr.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update pull_request status: %w", err)
 	}
 
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return pr, nil
+}
+
+// NextPullRequestID выдает id для PullRequest из внешнего сервиса
+func (r *PullRequestRepository) NextPullRequestID(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.NextPullRequestID")
+	defer span.End()
+
+	var id int
+	if err := r.pool.QueryRow(ctx, nextPullRequestID).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get next pull_request id: %w", err)
+	}
+	return id, nil
+}
+
+// AssignReviewers обновляет статус PullRequest и добавляет назначенных ревьюверов
+func (r *PullRequestRepository) AssignReviewers(
+	ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.AssignReviewers")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, updateStatus, pr.Status, pr.MergedAt, pr.ClosedAt, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull_request status: %w", err)
+	}
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		fromFallback := slices.Contains(pr.FallbackReviewers, reviewerID)
+		_, err := tx.Exec(ctx, addReviewerToPullRequest, pr.ID, reviewerID, fromFallback)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert reviewer: %w", err)
+		}
+	}
+
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
 	return pr, nil
 }
 
 func (r *PullRequestRepository) UpdateAssignedReviewers(
 	ctx context.Context, prID int, oldReviewerID int, newReviewerID int,
+	events []domain.AssignmentEvent, hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
 ) error {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.UpdateAssignedReviewers")
+	defer span.End()
+
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to begin tx: %w", err)
@@ -195,14 +557,58 @@ func (r *PullRequestRepository) UpdateAssignedReviewers(
 		}
 	}()
 
-	_, err = tx.Exec(ctx, deleteOldReviewer, prID, oldReviewerID)
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return err
+	}
+
+	replacements := []domain.ReviewReplacement{{PullRequestID: prID, OldReviewerID: oldReviewerID, NewReviewerID: newReviewerID}}
+	if err := assignment.ApplyReplacements(ctx, tx, replacements); err != nil {
+		return err
+	}
+
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+// AddReviewer назначает PullRequest еще одного ревьювера, не снимая остальных
+func (r *PullRequestRepository) AddReviewer(
+	ctx context.Context, prID int, reviewerID int, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+) error {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.AddReviewer")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to delete old reviewer: %w", err)
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return err
 	}
 
-	_, err = tx.Exec(ctx, insertNewReviewer, prID, newReviewerID)
+	_, err = tx.Exec(ctx, addReviewerToPullRequest, prID, reviewerID, false)
 	if err != nil {
-		return fmt.Errorf("failed to insert new reviewer: %w", err)
+		return fmt.Errorf("failed to insert reviewer: %w", err)
+	}
+
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -211,3 +617,176 @@ func (r *PullRequestRepository) UpdateAssignedReviewers(
 
 	return nil
 }
+
+// GetReviewerSelection возвращает настройки выбора ревьюверов в команде автора
+func (r *PullRequestRepository) GetReviewerSelection(ctx context.Context, authorId int) (*domain.ReviewerSelection, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetReviewerSelection")
+	defer span.End()
+
+	sel := &domain.ReviewerSelection{}
+	var strategy string
+
+	err := r.pool.QueryRow(ctx, getReviewerSelection, authorId).Scan(
+		&sel.TeamID, &sel.TeamName, &strategy, &sel.LastReviewerID, &sel.MinReviewers, &sel.MaxReviewers,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Автор без команды - кандидатов все равно не будет
+		return &domain.ReviewerSelection{
+			Strategy:     domain.StrategyRandom,
+			MinReviewers: domain.DefaultMinReviewers,
+			MaxReviewers: domain.DefaultMaxReviewers,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer selection: %w", err)
+	}
+
+	sel.Strategy = domain.MapStringToReviewerStrategy[strategy]
+
+	rows, err := r.pool.Query(ctx, getFallbackTeamIDs, sel.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int
+		if err := rows.Scan(&teamID); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		sel.FallbackTeamIDs = append(sel.FallbackTeamIDs, teamID)
+	}
+
+	return sel, nil
+}
+
+// GetActiveMembersByTeamID возвращает активных участников команды с их нагрузкой
+func (r *PullRequestRepository) GetActiveMembersByTeamID(ctx context.Context, teamID int) ([]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetActiveMembersByTeamID")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getActiveMembersByTeamID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active members by team: %w", err)
+	}
+	defer rows.Close()
+
+	activeMembers := make([]domain.User, 0)
+	for rows.Next() {
+		var u domain.User
+		err := rows.Scan(
+			&u.ID,
+			&u.Username,
+			&u.IsActive,
+			&u.TeamName,
+			&u.ReviewWeight,
+			&u.OpenReviews,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		activeMembers = append(activeMembers, u)
+	}
+
+	return activeMembers, nil
+}
+
+// GetMergePolicy возвращает политику слияния команды автора;
+// автор без команды ограничений не имеет
+func (r *PullRequestRepository) GetMergePolicy(ctx context.Context, authorId int) (*domain.MergePolicy, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetMergePolicy")
+	defer span.End()
+
+	var policy domain.MergePolicy
+	err := r.pool.QueryRow(ctx, getMergePolicy, authorId).Scan(&policy.RequiredApprovals, &policy.BlockOnChangesRequested)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &policy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// GetAssignmentEvents возвращает журнал назначений PullRequest в порядке записи
+func (r *PullRequestRepository) GetAssignmentEvents(ctx context.Context, prID int) ([]domain.AssignmentEvent, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.GetAssignmentEvents")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getAssignmentEvents, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.AssignmentEvent, 0)
+	for rows.Next() {
+		var e domain.AssignmentEvent
+		var reason string
+		err := rows.Scan(&e.PullRequestID, &e.ActorID, &e.OldReviewerID, &e.NewReviewerID, &reason, &e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment event: %w", err)
+		}
+		e.Reason = domain.MapStringToAssignmentReason[reason]
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// List возвращает страницу PullRequest, попадающих под фильтр, в порядке filter.Sort
+func (r *PullRequestRepository) List(ctx context.Context, filter *domain.PullRequestFilter) (*domain.PullRequestPage, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestRepository.List")
+	defer span.End()
+
+	query := listPullRequestsDesc
+	if filter.Sort == domain.SortCreatedAtAsc {
+		query = listPullRequestsAsc
+	}
+
+	var status, search *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	if filter.Query != nil {
+		q := likeEscaper.Replace(*filter.Query)
+		search = &q
+	}
+	var cursorAt *time.Time
+	var cursorID *int
+	if filter.Cursor != nil {
+		cursorAt, cursorID = &filter.Cursor.CreatedAt, &filter.Cursor.PullRequestID
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	rows, err := r.pool.Query(ctx, query,
+		status, filter.AuthorID, filter.ReviewerID, filter.TeamName, search, filter.From, filter.To,
+		cursorAt, cursorID, filter.Limit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull_requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequest, 0, filter.Limit)
+	for rows.Next() {
+		var pr domain.PullRequest
+		var status string
+		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.AssignedReviewers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull_request: %w", err)
+		}
+		pr.Status = domain.MapStringToPullRequestStatus[status]
+		prs = append(prs, pr)
+	}
+
+	page := &domain.PullRequestPage{PullRequests: prs}
+	if len(prs) > filter.Limit {
+		page.PullRequests = prs[:filter.Limit]
+		last := page.PullRequests[filter.Limit-1]
+		page.NextCursor = &domain.PageCursor{Sort: filter.Sort, CreatedAt: last.CreatedAt, PullRequestID: last.ID}
+	}
+
+	return page, nil
+}
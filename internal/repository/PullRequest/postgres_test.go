@@ -0,0 +1,181 @@
+package pullrequest
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"pr-reviewer/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) (*PullRequestRepository, *pgxpool.Pool) {
+	t.Helper()
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	return NewPullRequestRepository(pool, l), pool
+}
+
+func TestQueriesMatchSchema(t *testing.T) {
+	_, pool := newTestRepository(t)
+
+	pgtest.Prepare(t, pool, map[string]string{
+		"checkRPById":                  checkRPById,
+		"checkTeamByName":              checkTeamByName,
+		"nextPullRequestID":            nextPullRequestID,
+		"linkExternalPullRequest":      linkExternalPullRequest,
+		"getActiveTeamMembers":         getActiveTeamMembers,
+		"getActiveTeamMembersWithLoad": getActiveTeamMembersWithLoad,
+		"createPullRequest":            createPullRequest,
+		"getStatusID":                  getStatusID,
+		"addReviewerToPullRequest":     addReviewerToPullRequest,
+		"getPullRequestByID":           getPullRequestByID,
+		"getReviewers":                 getReviewers,
+		"updateStatus":                 updateStatus,
+		"getReviewerSelection":         getReviewerSelection,
+		"getFallbackTeamIDs":           getFallbackTeamIDs,
+		"getActiveMembersByTeamID":     getActiveMembersByTeamID,
+		"getLatestReviews":             getLatestReviews,
+		"addReview":                    addReview,
+		"getMergePolicy":               getMergePolicy,
+		"getAssignmentEvents":          getAssignmentEvents,
+		"listPullRequestsDesc":         listPullRequestsDesc,
+		"listPullRequestsAsc":          listPullRequestsAsc,
+	})
+}
+
+func TestPullRequestRepository_CreateAndGetById(t *testing.T) {
+	repo, pool := newTestRepository(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, team_id) VALUES (1, 'Alice', 1), (2, 'Bob', 1), (3, 'Carol', 1);
+	`)
+	require.NoError(t, err)
+
+	authorID := 1
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	pr := &domain.PullRequest{
+		ID:                10,
+		Name:              "Add search",
+		AuthorID:          authorID,
+		Status:            domain.PRStatusOpen,
+		CreatedAt:         createdAt,
+		AssignedReviewers: []int{2, 3},
+		FallbackReviewers: []int{3},
+	}
+	events := domain.NewAssignedEvents(pr.ID, pr.AssignedReviewers, &authorID, createdAt)
+
+	created, err := repo.Create(ctx, pr, events, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", created.AuthorTeamName)
+
+	got, err := repo.GetById(ctx, pr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Add search", got.Name)
+	assert.Equal(t, domain.PRStatusOpen, got.Status)
+	assert.Equal(t, "backend", got.AuthorTeamName)
+	assert.Equal(t, []int{2, 3}, got.AssignedReviewers)
+	assert.Equal(t, []int{3}, got.FallbackReviewers)
+	require.Len(t, got.Reviewers, 2)
+	assert.Equal(t, "Bob", got.Reviewers[0].Username)
+	assert.Equal(t, "Carol", got.Reviewers[1].Username)
+
+	history, err := repo.GetAssignmentEvents(ctx, pr.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func TestPullRequestRepository_CreateExternal(t *testing.T) {
+	repo, pool := newTestRepository(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, team_id) VALUES (1, 'Alice', 1);
+	`)
+	require.NoError(t, err)
+
+	newPR := func(id int, external *domain.ExternalPullRequestRef) *domain.PullRequest {
+		return &domain.PullRequest{
+			ID: id, Name: "Add search", AuthorID: 1, Status: domain.PRStatusOpen,
+			CreatedAt: time.Now(), External: external,
+		}
+	}
+
+	// Последовательность не выдает id, уже занятый клиентом
+	_, err = repo.Create(ctx, newPR(500, nil), nil, nil, nil)
+	require.NoError(t, err)
+	id, err := repo.NextPullRequestID(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, id, 500)
+
+	external := &domain.ExternalPullRequestRef{Provider: domain.ProviderGitHub, Repository: "acme/api", Number: 42}
+	_, err = repo.Create(ctx, newPR(id, external), nil, nil, nil)
+	require.NoError(t, err)
+
+	// Повторная доставка не оставляет PR без связи
+	dupID, err := repo.NextPullRequestID(ctx)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newPR(dupID, external), nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrExternalPullRequestLinked)
+
+	exists, err := repo.ExistsById(ctx, dupID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestPullRequestRepository_ReviewsOfCurrentAssignment(t *testing.T) {
+	repo, pool := newTestRepository(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO team (id, name, required_approvals) VALUES (1, 'backend', 1);
+		INSERT INTO users (id, name, team_id) VALUES (1, 'Alice', 1), (2, 'Bob', 1), (3, 'Carol', 1);
+	`)
+	require.NoError(t, err)
+
+	authorID := 1
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	pr := &domain.PullRequest{
+		ID: 10, Name: "Add search", AuthorID: authorID, Status: domain.PRStatusOpen,
+		CreatedAt: start, AssignedReviewers: []int{2},
+	}
+	_, err = repo.Create(ctx, pr, domain.NewAssignedEvents(pr.ID, pr.AssignedReviewers, &authorID, start), nil, nil)
+	require.NoError(t, err)
+
+	approved := &domain.Review{ReviewerID: 2, Verdict: domain.VerdictApproved, SubmittedAt: start.Add(time.Minute)}
+	require.NoError(t, repo.AddReview(ctx, pr.ID, approved))
+
+	// Bob заменен на Carol, а затем назначен обратно
+	replacedAt, returnedAt := start.Add(2*time.Minute), start.Add(3*time.Minute)
+	require.NoError(t, repo.UpdateAssignedReviewers(ctx, pr.ID, 2, 3,
+		[]domain.AssignmentEvent{domain.NewReplacementEvent(pr.ID, 2, 3, domain.ReasonReassigned, nil, replacedAt)}, nil, nil,
+	))
+	require.NoError(t, repo.UpdateAssignedReviewers(ctx, pr.ID, 3, 2,
+		[]domain.AssignmentEvent{domain.NewReplacementEvent(pr.ID, 3, 2, domain.ReasonReassigned, nil, returnedAt)}, nil, nil,
+	))
+
+	got, err := repo.GetById(ctx, pr.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Reviews)
+
+	policy, err := repo.GetMergePolicy(ctx, authorID)
+	require.NoError(t, err)
+	assert.Error(t, policy.Check(got))
+
+	// Новый вердикт после возврата снова открывает слияние
+	approved.SubmittedAt = start.Add(4 * time.Minute)
+	require.NoError(t, repo.AddReview(ctx, pr.ID, approved))
+
+	got, err = repo.GetById(ctx, pr.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Reviews, 1)
+	assert.NoError(t, policy.Check(got))
+}
@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatsRepository(pool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{
+		pool: pool,
+	}
+}
+
+// scopedPullRequests PR, попадающие под StatsFilter:
+// $1 - команда автора, $2 - ревьювер по журналу назначений, [$3, $4) - окно по created_at
+const scopedPullRequests = `
+	WITH scoped AS (
+		SELECT pr.id, pr.created_at, pr.merged_at, s.name AS status
+		FROM pull_request pr
+		JOIN pr_status s ON s.id = pr.status_id
+		JOIN users au ON au.id = pr.author_id
+		LEFT JOIN team t ON t.id = au.team_id
+		WHERE ($1::TEXT IS NULL OR t.name = $1)
+			AND ($2::INT IS NULL OR EXISTS (
+				SELECT 1 FROM assignment_event e WHERE e.pr_id = pr.id AND e.new_reviewer_id = $2
+			))
+			AND ($3::TIMESTAMP IS NULL OR pr.created_at >= $3)
+			AND ($4::TIMESTAMP IS NULL OR pr.created_at < $4)
+	)
+`
+
+const (
+	checkTeamByName = `
+		SELECT EXISTS(SELECT 1 FROM team WHERE name = $1);
+	`
+
+	checkUserById = `
+		SELECT EXISTS(SELECT 1 FROM users WHERE id = $1);
+	`
+
+	// getSummary переназначения считаются по журналу так же, как полученные замены
+	// в getReviewerStats, поэтому совпадают с их суммой по ревьюверам
+	getSummary = scopedPullRequests + `
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'DRAFT'),
+			COUNT(*) FILTER (WHERE status = 'OPEN'),
+			COUNT(*) FILTER (WHERE status = 'MERGED'),
+			COUNT(*) FILTER (WHERE status = 'CLOSED'),
+			EXTRACT(EPOCH FROM AVG(merged_at - created_at))::FLOAT8,
+			(
+				SELECT COUNT(*)
+				FROM scoped sc
+				JOIN assignment_event e ON e.pr_id = sc.id
+				WHERE e.old_reviewer_id IS NOT NULL AND e.new_reviewer_id IS NOT NULL
+					AND e.reason <> 'ACTIVATED'
+					AND ($2::INT IS NULL OR e.new_reviewer_id = $2)
+			)
+		FROM scoped;
+	`
+
+	// getReviewerStats назначения и замены берутся из журнала, поэтому снятые ревьюверы
+	// остаются в статистике; ACTIVATED - не новое назначение. OPEN ревью - текущие назначения
+	getReviewerStats = scopedPullRequests + `,
+		journal AS (
+			SELECT e.new_reviewer_id AS reviewer_id,
+				COUNT(*) AS assignments,
+				COUNT(*) FILTER (WHERE e.old_reviewer_id IS NOT NULL) AS received
+			FROM scoped sc
+			JOIN assignment_event e ON e.pr_id = sc.id
+			WHERE e.new_reviewer_id IS NOT NULL AND e.reason <> 'ACTIVATED'
+				AND ($2::INT IS NULL OR e.new_reviewer_id = $2)
+			GROUP BY e.new_reviewer_id
+		),
+		open_reviews AS (
+			SELECT a.reviewer_id, COUNT(*) AS open
+			FROM scoped sc
+			JOIN assigned_pr a ON a.pr_id = sc.id
+			WHERE sc.status = 'OPEN'
+			GROUP BY a.reviewer_id
+		)
+		SELECT u.id, u.name, j.assignments, COALESCE(o.open, 0), j.received
+		FROM journal j
+		JOIN users u ON u.id = j.reviewer_id
+		LEFT JOIN open_reviews o ON o.reviewer_id = j.reviewer_id
+		ORDER BY j.assignments DESC, u.id;
+	`
+)
+
+func (r *StatsRepository) ExistsTeamByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "StatsRepository.ExistsTeamByName")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkTeamByName, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check team existance by name: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *StatsRepository) ExistsUserById(ctx context.Context, id int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "StatsRepository.ExistsUserById")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkUserById, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user existance by id: %w", err)
+	}
+	return exists, nil
+}
+
+// GetStats считает статистику по PullRequest, попадающим под фильтр
+func (r *StatsRepository) GetStats(ctx context.Context, filter *domain.StatsFilter) (*domain.Stats, error) {
+	ctx, span := tracing.Start(ctx, "StatsRepository.GetStats")
+	defer span.End()
+
+	args := []any{filter.TeamName, filter.UserID, filter.From, filter.To}
+
+	var st domain.Stats
+	var avgMergeSeconds *float64
+	err := r.pool.QueryRow(ctx, getSummary, args...).Scan(
+		&st.PullRequests.Total,
+		&st.PullRequests.Draft,
+		&st.PullRequests.Open,
+		&st.PullRequests.Merged,
+		&st.PullRequests.Closed,
+		&avgMergeSeconds,
+		&st.Reassignments,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats summary: %w", err)
+	}
+	if avgMergeSeconds != nil {
+		avg := time.Duration(*avgMergeSeconds * float64(time.Second))
+		st.AvgMergeTime = &avg
+	}
+
+	rows, err := r.pool.Query(ctx, getReviewerStats, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer stats: %w", err)
+	}
+	defer rows.Close()
+
+	st.Reviewers = make([]domain.ReviewerStats, 0)
+	for rows.Next() {
+		var rs domain.ReviewerStats
+		err := rows.Scan(&rs.UserID, &rs.Username, &rs.Assignments, &rs.OpenReviews, &rs.ReassignmentsReceived)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer stats: %w", err)
+		}
+		st.Reviewers = append(st.Reviewers, rs)
+	}
+
+	return &st, nil
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRepository_GetStatsFromJournal(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStatsRepository(pool)
+	ctx := context.Background()
+
+	// Bob назначен на PR 10 и заменен Carol: назначение остается в статистике Bob
+	_, err := pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, is_active, team_id) VALUES
+			(1, 'Alice', TRUE, 1), (2, 'Bob', FALSE, 1), (3, 'Carol', TRUE, 1);
+		INSERT INTO pull_request (id, title, author_id, status_id) VALUES (10, 'Add search', 1, 1);
+		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES (10, 3);
+		INSERT INTO assignment_event (pr_id, old_reviewer_id, new_reviewer_id, reason) VALUES
+			(10, NULL, 2, 'ASSIGNED'),
+			(10, 2, 3, 'DEACTIVATED'),
+			(10, NULL, 3, 'ACTIVATED');
+	`)
+	require.NoError(t, err)
+
+	st, err := repo.GetStats(ctx, &domain.StatsFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.Reassignments)
+	assert.Equal(t, []domain.ReviewerStats{
+		{UserID: 2, Username: "Bob", Assignments: 1, OpenReviews: 0, ReassignmentsReceived: 0},
+		{UserID: 3, Username: "Carol", Assignments: 1, OpenReviews: 1, ReassignmentsReceived: 1},
+	}, st.Reviewers)
+
+	bob := 2
+	st, err = repo.GetStats(ctx, &domain.StatsFilter{UserID: &bob})
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.PullRequests.Total)
+	assert.Equal(t, 0, st.Reassignments)
+	assert.Equal(t, []domain.ReviewerStats{{UserID: 2, Username: "Bob", Assignments: 1}}, st.Reviewers)
+}
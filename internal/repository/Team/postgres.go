@@ -2,11 +2,16 @@ package team
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/tracing"
+	assignment "pr-reviewer/internal/repository/Assignment"
+	webhook "pr-reviewer/internal/repository/Webhook"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,6 +27,9 @@ func NewTeamRepository(pool *pgxpool.Pool, logger logger.Logger) *TeamPepository
 	}
 }
 
+// uniqueViolation код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolation = "23505"
+
 const (
 	checkTeamByName = `
 		SELECT EXISTS(SELECT 1 FROM team WHERE name = $1);
@@ -31,28 +39,110 @@ const (
     `
 
 	createTeamWithName = `
-		INSERT INTO team (name) VALUES ($1) RETURNING id;
+		INSERT INTO team (name, reviewer_strategy) VALUES ($1, $2) RETURNING id;
 	`
 
 	createTeamMember = `
-		INSERT INTO users (id, name, is_active, team_id) VALUES ($1, $2, $3, $4);
+		INSERT INTO users (id, name, is_active, team_id, review_weight) VALUES ($1, $2, $3, $4, $5);
 	`
 
 	updateTeamMember = `
-		UPDATE users SET name = $1, is_active = $2, team_id = $3 WHERE id = $4;
+		UPDATE users SET name = $1, is_active = $2, team_id = $3, review_weight = $4 WHERE id = $5;
+	`
+
+	// syncTeamMember вес меняется только если передан ($5 не NULL)
+	syncTeamMember = `
+		INSERT INTO users (id, name, is_active, team_id, review_weight)
+		VALUES ($1, $2, $3, $4, COALESCE($5::int, $6::int))
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			is_active = EXCLUDED.is_active,
+			team_id = EXCLUDED.team_id,
+			review_weight = COALESCE($5::int, users.review_weight);
 	`
 
 	getTeamByName = `
-		SELECT id, name FROM team WHERE name = $1;
+		SELECT id, name, reviewer_strategy FROM team WHERE name = $1;
 	`
 
 	getTeamMembers = `
-		SELECT id, name, is_active FROM users WHERE team_id = $1;
+		SELECT id, name, is_active, review_weight FROM users WHERE team_id = $1;
+	`
+
+	getTeamSettings = `
+		SELECT name, reviewer_strategy, min_reviewers, max_reviewers,
+			required_approvals, block_on_changes_requested, review_sla_minutes, escalation_policy
+		FROM team WHERE name = $1;
+	`
+
+	updateTeamSettings = `
+		UPDATE team SET reviewer_strategy = $1, min_reviewers = $2, max_reviewers = $3,
+			required_approvals = $4, block_on_changes_requested = $5,
+			review_sla_minutes = $6, escalation_policy = $7
+		WHERE name = $8
+		RETURNING id;
+	`
+
+	getFallbackTeams = `
+		SELECT f.name
+		FROM team_fallback tf
+		JOIN team f ON f.id = tf.fallback_team_id
+		WHERE tf.team_id = (SELECT id FROM team WHERE name = $1)
+		ORDER BY tf.position;
+	`
+
+	deleteFallbackTeams = `
+		DELETE FROM team_fallback WHERE team_id = $1;
+	`
+
+	insertFallbackTeam = `
+		INSERT INTO team_fallback (team_id, fallback_team_id, position)
+		SELECT $1, id, $2 FROM team WHERE name = $3;
+	`
+
+	getUsersByIDs = `
+		SELECT u.id, u.name, COALESCE(t.name, ''), u.is_active, u.review_weight
+		FROM users u
+		LEFT JOIN team t ON t.id = u.team_id
+		WHERE u.id = ANY($1)
+		ORDER BY u.id;
+	`
+
+	getTeamIDByName = `
+		SELECT id FROM team WHERE name = $1;
+	`
+
+	// getOpenTeamReviews OPEN PR авторов команды $1, где назначен кто-то из $2
+	getOpenTeamReviews = `
+		SELECT pr.id, pr.title, pr.author_id, pr.created_at, array_agg(a.reviewer_id ORDER BY a.reviewer_id)
+		FROM pull_request pr
+		JOIN assigned_pr a ON a.pr_id = pr.id
+		JOIN users au ON au.id = pr.author_id
+		WHERE au.team_id = (SELECT id FROM team WHERE name = $1)
+			AND pr.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+			AND pr.id IN (SELECT pr_id FROM assigned_pr WHERE reviewer_id = ANY($2))
+		GROUP BY pr.id
+		ORDER BY pr.id;
+	`
+
+	updateUsersTeam = `
+		UPDATE users SET team_id = $1 WHERE id = ANY($2);
+	`
+
+	renameTeam = `
+		UPDATE team SET name = $1 WHERE name = $2;
+	`
+
+	deleteTeam = `
+		DELETE FROM team WHERE name = $1;
 	`
 )
 
 // Проверка существования команды с заданным именем
 func (r *TeamPepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.ExistsByName")
+	defer span.End()
+
 	var exists bool
 	err := r.pool.QueryRow(ctx, checkTeamByName, name).Scan(&exists)
 	if err != nil {
@@ -63,6 +153,9 @@ func (r *TeamPepository) ExistsByName(ctx context.Context, name string) (bool, e
 
 // Создание команды с созданием/обновлением участников
 func (r *TeamPepository) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.Create")
+	defer span.End()
+
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
@@ -75,32 +168,13 @@ func (r *TeamPepository) Create(ctx context.Context, team *domain.Team) (*domain
 
 	// Создаем команду
 	var teamID int
-	err = tx.QueryRow(ctx, createTeamWithName, team.Name).Scan(&teamID)
+	err = tx.QueryRow(ctx, createTeamWithName, team.Name, team.ReviewerStrategy).Scan(&teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert team: %w", err)
 	}
 
-	for _, m := range team.Members {
-		// Проверяем существование User с id
-		var exists bool
-		err := tx.QueryRow(ctx, checkUserByID, m.UserID).Scan(&exists)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check User by id: %w", err)
-		}
-
-		// Если существует - обновляем
-		if exists {
-			_, err := tx.Exec(ctx, updateTeamMember, m.Username, m.IsActive, teamID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to update user: %w", err)
-			}
-			// Если не существует - создаем
-		} else {
-			_, err := tx.Exec(ctx, createTeamMember, m.UserID, m.Username, m.IsActive, teamID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create user: %w", err)
-			}
-		}
+	if err := upsertMembers(ctx, tx, teamID, team.Members); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -113,12 +187,17 @@ func (r *TeamPepository) Create(ctx context.Context, team *domain.Team) (*domain
 }
 
 func (r *TeamPepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.GetByName")
+	defer span.End()
+
 	var team domain.Team
+	var strategy string
 
-	err := r.pool.QueryRow(ctx, getTeamByName, name).Scan(&team.ID, &team.Name)
+	err := r.pool.QueryRow(ctx, getTeamByName, name).Scan(&team.ID, &team.Name, &strategy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
+	team.ReviewerStrategy = domain.MapStringToReviewerStrategy[strategy]
 
 	rows, err := r.pool.Query(ctx, getTeamMembers, team.ID)
 	if err != nil {
@@ -128,7 +207,7 @@ func (r *TeamPepository) GetByName(ctx context.Context, name string) (*domain.Te
 
 	for rows.Next() {
 		var m domain.TeamMember
-		err := rows.Scan(&m.UserID, &m.Username, &m.IsActive)
+		err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.ReviewWeight)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
@@ -137,3 +216,336 @@ func (r *TeamPepository) GetByName(ctx context.Context, name string) (*domain.Te
 
 	return &team, nil
 }
+
+// GetSettings возвращает настройки назначения ревьюверов команды
+func (r *TeamPepository) GetSettings(ctx context.Context, name string) (*domain.TeamSettings, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.GetSettings")
+	defer span.End()
+
+	var ts domain.TeamSettings
+	var strategy, policy string
+
+	err := r.pool.QueryRow(ctx, getTeamSettings, name).Scan(
+		&ts.TeamName, &strategy, &ts.MinReviewers, &ts.MaxReviewers,
+		&ts.RequiredApprovals, &ts.BlockOnChangesRequested, &ts.ReviewSLAMinutes, &policy,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+	ts.ReviewerStrategy = domain.MapStringToReviewerStrategy[strategy]
+	ts.EscalationPolicy = domain.MapStringToEscalationPolicy[policy]
+
+	rows, err := r.pool.Query(ctx, getFallbackTeams, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	ts.FallbackTeams = make([]string, 0)
+	for rows.Next() {
+		var fallback string
+		if err := rows.Scan(&fallback); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		ts.FallbackTeams = append(ts.FallbackTeams, fallback)
+	}
+
+	return &ts, nil
+}
+
+// UpdateSettings сохраняет настройки назначения ревьюверов команды
+// вместе со списком резервных команд
+func (r *TeamPepository) UpdateSettings(ctx context.Context, ts *domain.TeamSettings) (*domain.TeamSettings, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.UpdateSettings")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	var teamID int
+	err = tx.QueryRow(ctx, updateTeamSettings,
+		ts.ReviewerStrategy, ts.MinReviewers, ts.MaxReviewers,
+		ts.RequiredApprovals, ts.BlockOnChangesRequested,
+		ts.ReviewSLAMinutes, ts.EscalationPolicy, ts.TeamName,
+	).Scan(&teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update team settings: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, deleteFallbackTeams, teamID); err != nil {
+		return nil, fmt.Errorf("failed to delete fallback teams: %w", err)
+	}
+
+	for i, fallback := range ts.FallbackTeams {
+		if _, err := tx.Exec(ctx, insertFallbackTeam, teamID, i, fallback); err != nil {
+			return nil, fmt.Errorf("failed to insert fallback team: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return ts, nil
+}
+
+// GetUsers возвращает существующих пользователей из ids вместе с их командами
+func (r *TeamPepository) GetUsers(ctx context.Context, ids []int) ([]domain.User, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.GetUsers")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0, len(ids))
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.ReviewWeight); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+// GetOpenTeamReviews возвращает OPEN PullRequest авторов команды,
+// на которые назначен кто-то из reviewerIDs
+func (r *TeamPepository) GetOpenTeamReviews(ctx context.Context, teamName string, reviewerIDs []int) ([]domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "TeamRepository.GetOpenTeamReviews")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getOpenTeamReviews, teamName, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open team reviews: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequest, 0)
+	for rows.Next() {
+		pr := domain.PullRequest{Status: domain.PRStatusOpen}
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.CreatedAt, &pr.AssignedReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan pull_request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// AddMembers создает/обновляет участников существующей команды
+func (r *TeamPepository) AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) error {
+	ctx, span := tracing.Start(ctx, "TeamRepository.AddMembers")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	var teamID int
+	if err := tx.QueryRow(ctx, getTeamIDByName, teamName).Scan(&teamID); err != nil {
+		return fmt.Errorf("failed to get team id: %w", err)
+	}
+
+	if err := upsertMembers(ctx, tx, teamID, members); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// ChangeMembersTeam переводит пользователей в команду toTeam (nil - без команды)
+// и в той же транзакции заменяет их ревью на OPEN PullRequest
+func (r *TeamPepository) ChangeMembersTeam(
+	ctx context.Context, userIDs []int, toTeam *string, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+	hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+) error {
+	ctx, span := tracing.Start(ctx, "TeamRepository.ChangeMembersTeam")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return err
+	}
+
+	var teamID *int
+	if toTeam != nil {
+		var id int
+		if err := tx.QueryRow(ctx, getTeamIDByName, *toTeam).Scan(&id); err != nil {
+			return fmt.Errorf("failed to get team id: %w", err)
+		}
+		teamID = &id
+	}
+
+	if _, err := tx.Exec(ctx, updateUsersTeam, teamID, userIDs); err != nil {
+		return fmt.Errorf("failed to update users team: %w", err)
+	}
+
+	if err := applyReplacements(ctx, tx, replacements, events, hooks); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// Rename переименовывает команду; если имя newName занято, возвращает domain.ErrTeamExists
+func (r *TeamPepository) Rename(ctx context.Context, name string, newName string) error {
+	ctx, span := tracing.Start(ctx, "TeamRepository.Rename")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, renameTeam, newName, name)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrTeamExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет команду: участники остаются без команды,
+// резервные связи и подписки удаляются каскадно
+func (r *TeamPepository) Delete(ctx context.Context, name string) error {
+	ctx, span := tracing.Start(ctx, "TeamRepository.Delete")
+	defer span.End()
+
+	if _, err := r.pool.Exec(ctx, deleteTeam, name); err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+	return nil
+}
+
+// Sync приводит состав команды к team.Members, создавая команду при необходимости:
+// участники создаются/обновляются (вес - только переданный), removed остаются без команды, а их ревью
+// заменяются по replacements в той же транзакции
+func (r *TeamPepository) Sync(
+	ctx context.Context, team *domain.Team, removed []int, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+	hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+) error {
+	ctx, span := tracing.Start(ctx, "TeamRepository.Sync")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return err
+	}
+
+	var teamID int
+	err = tx.QueryRow(ctx, getTeamIDByName, team.Name).Scan(&teamID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = tx.QueryRow(ctx, createTeamWithName, team.Name, team.ReviewerStrategy).Scan(&teamID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get or create team: %w", err)
+	}
+
+	for _, m := range team.Members {
+		var weight *int
+		if m.ReviewWeight > 0 {
+			weight = &m.ReviewWeight
+		}
+		if _, err := tx.Exec(ctx, syncTeamMember, m.UserID, m.Username, m.IsActive, teamID, weight, domain.DefaultReviewWeight); err != nil {
+			return fmt.Errorf("failed to sync user: %w", err)
+		}
+	}
+
+	if len(removed) > 0 {
+		if _, err := tx.Exec(ctx, updateUsersTeam, nil, removed); err != nil {
+			return fmt.Errorf("failed to detach removed members: %w", err)
+		}
+	}
+
+	if err := applyReplacements(ctx, tx, replacements, events, hooks); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// upsertMembers создает/обновляет пользователей и включает их в команду teamID
+func upsertMembers(ctx context.Context, tx pgx.Tx, teamID int, members []domain.TeamMember) error {
+	for _, m := range members {
+		// Проверяем существование User с id
+		var exists bool
+		err := tx.QueryRow(ctx, checkUserByID, m.UserID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check User by id: %w", err)
+		}
+
+		// Если существует - обновляем
+		if exists {
+			_, err := tx.Exec(ctx, updateTeamMember, m.Username, m.IsActive, teamID, m.ReviewWeight, m.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+			// Если не существует - создаем
+		} else {
+			_, err := tx.Exec(ctx, createTeamMember, m.UserID, m.Username, m.IsActive, teamID, m.ReviewWeight)
+			if err != nil {
+				return fmt.Errorf("failed to create user: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyReplacements заменяет ревьюверов, записывает события в историю назначений
+// и ставит вебхуки о заменах в outbox
+func applyReplacements(
+	ctx context.Context, tx pgx.Tx, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+) error {
+	if err := assignment.ApplyReplacements(ctx, tx, replacements); err != nil {
+		return err
+	}
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+	return webhook.Enqueue(ctx, tx, hooks)
+}
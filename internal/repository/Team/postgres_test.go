@@ -0,0 +1,61 @@
+package team
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"pr-reviewer/internal/pkg/logger"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamRepository_Rename(t *testing.T) {
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	repo := NewTeamRepository(pool, l)
+	ctx := context.Background()
+
+	_, err = pool.Exec(ctx, `INSERT INTO team (name) VALUES ('backend'), ('platform');`)
+	require.NoError(t, err)
+
+	err = repo.Rename(ctx, "backend", "platform")
+	assert.Equal(t, domain.ErrTeamExists, err)
+
+	require.NoError(t, repo.Rename(ctx, "backend", "core"))
+
+	exists, err := repo.ExistsByName(ctx, "core")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestTeamRepository_SyncKeepsOmittedWeight(t *testing.T) {
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	repo := NewTeamRepository(pool, l)
+	ctx := context.Background()
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, is_active, team_id, review_weight) VALUES (1, 'Alice', TRUE, 1, 3), (2, 'Bob', TRUE, 1, 2);
+	`)
+	require.NoError(t, err)
+
+	team := &domain.Team{Name: "backend", Members: []domain.TeamMember{
+		{UserID: 1, Username: "Alice", IsActive: true},
+		{UserID: 2, Username: "Bob", IsActive: true, ReviewWeight: 5},
+		{UserID: 7, Username: "Grace", IsActive: true},
+	}}
+	require.NoError(t, repo.Sync(ctx, team, nil, nil, nil, nil, nil))
+
+	synced, err := repo.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	weights := make(map[int]int, len(synced.Members))
+	for _, m := range synced.Members {
+		weights[m.UserID] = m.ReviewWeight
+	}
+	assert.Equal(t, map[int]int{1: 3, 2: 5, 7: domain.DefaultReviewWeight}, weights)
+}
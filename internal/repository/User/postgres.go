@@ -2,19 +2,29 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/tracing"
+	assignment "pr-reviewer/internal/repository/Assignment"
+	webhook "pr-reviewer/internal/repository/Webhook"
+	"slices"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UserRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	logger logger.Logger
 }
 
-func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
+func NewUserRepository(pool *pgxpool.Pool, logger logger.Logger) *UserRepository {
 	return &UserRepository{
-		pool: pool,
+		pool:   pool,
+		logger: logger,
 	}
 }
 
@@ -23,6 +33,10 @@ const (
 		SELECT EXISTS(SELECT 1 FROM users WHERE id = $1);
 	`
 
+	getUserIsActiveForUpdate = `
+		SELECT is_active FROM users WHERE id = $1 FOR UPDATE;
+	`
+
 	updateUserIsActive = `
 		UPDATE users u SET is_active = $1
 		FROM team t 
@@ -30,20 +44,103 @@ const (
 		RETURNING u.id, u.name, u.is_active, t.name;
 	`
 
+	// getUserPullRequests страница PR, где $1 ревьювер: фильтры $2-$6,
+	// курсор ($7, $8), остальные ревьюверы при $9, размер страницы $10.
+	// Вердикт - последний за текущее назначение ревьювера, как в PullRequest
 	getUserPullRequests = `
-		SELECT pr.id, pr.title, pr.author_id, s.name, pr.created_at, pr.merged_at
+		SELECT pr.id, pr.title, pr.author_id, s.name, pr.created_at, pr.merged_at, v.verdict,
+			CASE WHEN $9 THEN ARRAY(
+				SELECT reviewer_id FROM assigned_pr
+				WHERE pr_id = pr.id AND reviewer_id <> $1
+				ORDER BY reviewer_id
+			) END
 		FROM pull_request pr
 		JOIN pr_status s ON pr.status_id = s.id
+		JOIN users au ON au.id = pr.author_id
+		LEFT JOIN team t ON t.id = au.team_id
+		LEFT JOIN LATERAL (
+			SELECT verdict FROM review_verdict
+			WHERE pr_id = pr.id AND reviewer_id = $1
+				AND created_at >= COALESCE((
+					SELECT MAX(e.created_at) FROM assignment_event e
+					WHERE e.pr_id = pr.id AND e.new_reviewer_id = $1 AND e.reason <> 'ACTIVATED'
+				), pr.created_at)
+			ORDER BY created_at DESC
+			LIMIT 1
+		) v ON TRUE
 		WHERE pr.id IN (
 			SELECT pr_id
 			FROM assigned_pr
 			WHERE reviewer_id = $1
 		)
-		ORDER BY pr.created_at DESC;
+			AND ($2::TEXT IS NULL OR s.name = $2)
+			AND ($3::INT IS NULL OR pr.author_id = $3)
+			AND ($4::TEXT IS NULL OR t.name = $4)
+			AND ($5::TIMESTAMP IS NULL OR pr.created_at >= $5)
+			AND ($6::TIMESTAMP IS NULL OR pr.created_at < $6)
+			AND ($7::TIMESTAMP IS NULL OR (pr.created_at, pr.id) < ($7, $8::INT))
+		ORDER BY pr.created_at DESC, pr.id DESC
+		LIMIT $10;
+	`
+
+	checkTeamByName = `
+		SELECT EXISTS(SELECT 1 FROM team WHERE name = $1);
+	`
+
+	getTeamUserIDs = `
+		SELECT u.id FROM users u
+		JOIN team t ON t.id = u.team_id
+		WHERE t.name = $1
+		ORDER BY u.id;
+	`
+
+	getOpenReviewsByReviewers = `
+		SELECT pr.id, pr.title, pr.author_id, COALESCE(t.name, ''), pr.created_at,
+			array_agg(a.reviewer_id ORDER BY a.reviewer_id)
+		FROM pull_request pr
+		JOIN assigned_pr a ON a.pr_id = pr.id
+		JOIN users au ON au.id = pr.author_id
+		LEFT JOIN team t ON t.id = au.team_id
+		WHERE pr.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+			AND pr.id IN (SELECT pr_id FROM assigned_pr WHERE reviewer_id = ANY($1))
+		GROUP BY pr.id, t.name
+		ORDER BY pr.id;
+	`
+
+	// deactivateUsers возвращает id пользователей, которые были активны
+	deactivateUsers = `
+		UPDATE users SET is_active = FALSE WHERE id = ANY($1) AND is_active
+		RETURNING id;
+	`
+
+	createAvailabilityWindow = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, starts_at, ends_at, reason, created_at;
+	`
+
+	getAvailabilityWindows = `
+		SELECT id, user_id, starts_at, ends_at, reason, created_at
+		FROM user_unavailability
+		WHERE user_id = $1 AND ends_at > $2
+		ORDER BY starts_at, id;
+	`
+
+	getAvailabilityWindow = `
+		SELECT id, user_id, starts_at, ends_at, reason, created_at
+		FROM user_unavailability WHERE id = $1;
+	`
+
+	deleteAvailabilityWindow = `
+		DELETE FROM user_unavailability WHERE id = $1
+		RETURNING id, user_id, starts_at, ends_at, reason, created_at;
 	`
 )
 
 func (r *UserRepository) ExistsById(ctx context.Context, id int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ExistsById")
+	defer span.End()
+
 	var exists bool
 	err := r.pool.QueryRow(ctx, checkUserById, id).Scan(&exists)
 	if err != nil {
@@ -52,38 +149,291 @@ func (r *UserRepository) ExistsById(ctx context.Context, id int) (bool, error) {
 	return exists, nil
 }
 
-func (r *UserRepository) UpdateIsActive(ctx context.Context, set *domain.SetUserIsActive) (*domain.User, error) {
+// UpdateIsActive меняет активность пользователя; events пишутся в журнал назначений,
+// а hooks в outbox, только если значение действительно изменилось
+func (r *UserRepository) UpdateIsActive(
+	ctx context.Context, set *domain.SetUserIsActive, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateIsActive")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	var wasActive bool
+	if err := tx.QueryRow(ctx, getUserIsActiveForUpdate, set.ID).Scan(&wasActive); err != nil {
+		return nil, fmt.Errorf("failed to get user is_active: %w", err)
+	}
+
 	var user domain.User
-	err := r.pool.QueryRow(ctx, updateUserIsActive, set.IsActive, set.ID).
+	err = tx.QueryRow(ctx, updateUserIsActive, set.IsActive, set.ID).
 		Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user is_active: %w", err)
 	}
 
+	if wasActive != set.IsActive {
+		if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+			return nil, err
+		}
+		if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
 	return &user, nil
 }
 
-func (r *UserRepository) GetUserPullRequests(ctx context.Context, userID int) ([]domain.PullRequest, error) {
-	rows, err := r.pool.Query(ctx, getUserPullRequests, userID)
+// GetUserPullRequests возвращает страницу PullRequest, где пользователь назначен ревьювером,
+// от новых к старым
+func (r *UserRepository) GetUserPullRequests(ctx context.Context, filter *domain.ReviewFilter) (*domain.ReviewPage, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetUserPullRequests")
+	defer span.End()
+
+	var status *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	var cursorAt *time.Time
+	var cursorID *int
+	if filter.Cursor != nil {
+		cursorAt, cursorID = &filter.Cursor.CreatedAt, &filter.Cursor.PullRequestID
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	rows, err := r.pool.Query(ctx, getUserPullRequests,
+		filter.UserID, status, filter.AuthorID, filter.TeamName, filter.From, filter.To,
+		cursorAt, cursorID, filter.IncludeReviewers, filter.Limit+1,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user pull_requests: %w", err)
 	}
 	defer rows.Close()
 
-	var prs []domain.PullRequest
+	prs := make([]domain.PullRequest, 0, filter.Limit)
 	for rows.Next() {
 		var status string
+		var verdict *string
 		var pr domain.PullRequest
 
-		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt)
+		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &verdict, &pr.AssignedReviewers)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pull_request: %w", err)
 		}
 		pr.Status = domain.MapStringToPullRequestStatus[status]
+		if verdict != nil {
+			v := domain.MapStringToReviewVerdict[*verdict]
+			pr.Verdict = &v
+		}
+
+		prs = append(prs, pr)
+	}
+
+	page := &domain.ReviewPage{PullRequests: prs}
+	if len(prs) > filter.Limit {
+		page.PullRequests = prs[:filter.Limit]
+		last := page.PullRequests[filter.Limit-1]
+		page.NextCursor = &domain.PageCursor{Sort: domain.SortCreatedAtDesc, CreatedAt: last.CreatedAt, PullRequestID: last.ID}
+	}
+
+	return page, nil
+}
+
+func (r *UserRepository) ExistsTeamByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ExistsTeamByName")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkTeamByName, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check team existance by name: %w", err)
+	}
+	return exists, nil
+}
+
+// GetTeamUserIDs возвращает id всех участников команды
+func (r *UserRepository) GetTeamUserIDs(ctx context.Context, teamName string) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetTeamUserIDs")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getTeamUserIDs, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team users: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// GetOpenReviewsByReviewers возвращает OPEN PullRequest, где ревьювер - кто-то из userIDs
+func (r *UserRepository) GetOpenReviewsByReviewers(ctx context.Context, userIDs []int) ([]domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetOpenReviewsByReviewers")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getOpenReviewsByReviewers, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+	defer rows.Close()
 
+	prs := make([]domain.PullRequest, 0)
+	for rows.Next() {
+		pr := domain.PullRequest{Status: domain.PRStatusOpen}
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.AuthorTeamName, &pr.CreatedAt, &pr.AssignedReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan pull_request: %w", err)
+		}
 		prs = append(prs, pr)
 	}
 
 	return prs, nil
 }
+
+// DeactivateAndReassign деактивирует пользователей, применяет замены ревьюверов,
+// пишет события в журнал назначений и ставит вебхуки в outbox в одной транзакции.
+// Вебхук смены активности отправляется только для пользователей, которые были активны
+func (r *UserRepository) DeactivateAndReassign(
+	ctx context.Context, userIDs []int, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+	hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.DeactivateAndReassign")
+	defer span.End()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("tx rollback failed")
+		}
+	}()
+
+	if err := assignment.AdvanceRoundRobin(ctx, tx, moves); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, deactivateUsers, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	deactivated, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	hooks = slices.DeleteFunc(slices.Clone(hooks), func(h domain.WebhookEvent) bool {
+		return h.Type == domain.WebhookUserActivityChanged && !slices.Contains(deactivated, h.UserID)
+	})
+
+	if err := assignment.ApplyReplacements(ctx, tx, replacements); err != nil {
+		return err
+	}
+
+	if err := assignment.InsertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := webhook.Enqueue(ctx, tx, hooks); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAvailabilityWindow сохраняет окно недоступности пользователя
+func (r *UserRepository) CreateAvailabilityWindow(ctx context.Context, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.CreateAvailabilityWindow")
+	defer span.End()
+
+	row := r.pool.QueryRow(ctx, createAvailabilityWindow, w.UserID, w.From, w.To, w.Reason, w.CreatedAt)
+	created, err := scanAvailabilityWindow(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create availability window: %w", err)
+	}
+	return created, nil
+}
+
+// GetAvailabilityWindows возвращает окна недоступности пользователя, не закончившиеся к now
+func (r *UserRepository) GetAvailabilityWindows(ctx context.Context, userID int, now time.Time) ([]domain.AvailabilityWindow, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetAvailabilityWindows")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getAvailabilityWindows, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability windows: %w", err)
+	}
+	defer rows.Close()
+
+	windows := make([]domain.AvailabilityWindow, 0)
+	for rows.Next() {
+		w, err := scanAvailabilityWindow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan availability window: %w", err)
+		}
+		windows = append(windows, *w)
+	}
+	return windows, nil
+}
+
+// GetAvailabilityWindow возвращает окно недоступности, nil если его нет
+func (r *UserRepository) GetAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetAvailabilityWindow")
+	defer span.End()
+
+	w, err := scanAvailabilityWindow(r.pool.QueryRow(ctx, getAvailabilityWindow, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability window: %w", err)
+	}
+	return w, nil
+}
+
+// DeleteAvailabilityWindow удаляет окно недоступности, nil если его нет
+func (r *UserRepository) DeleteAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.DeleteAvailabilityWindow")
+	defer span.End()
+
+	w, err := scanAvailabilityWindow(r.pool.QueryRow(ctx, deleteAvailabilityWindow, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete availability window: %w", err)
+	}
+	return w, nil
+}
+
+func scanAvailabilityWindow(row pgx.Row) (*domain.AvailabilityWindow, error) {
+	var w domain.AvailabilityWindow
+	if err := row.Scan(&w.ID, &w.UserID, &w.From, &w.To, &w.Reason, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/db/pgtest"
+	"pr-reviewer/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_DeactivateAndReassign(t *testing.T) {
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	repo := NewUserRepository(pool, l)
+	ctx := context.Background()
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, is_active, team_id) VALUES
+			(1, 'Alice', TRUE, 1), (2, 'Bob', FALSE, 1), (3, 'Carol', TRUE, 1), (4, 'Dave', TRUE, 1);
+		INSERT INTO pull_request (id, title, author_id, status_id) VALUES (10, 'Add search', 4, 1);
+		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES (10, 1);
+		INSERT INTO webhook_subscription (team_id, url, secret, events)
+		VALUES (1, 'http://hooks.local', 's', ARRAY['user.activity_changed', 'pull_request.reassigned']);
+	`)
+	require.NoError(t, err)
+
+	prs, err := repo.GetOpenReviewsByReviewers(ctx, []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+
+	now := time.Now()
+	replacements := []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 1, NewReviewerID: 3}}
+	events := []domain.AssignmentEvent{domain.NewReplacementEvent(10, 1, 3, domain.ReasonDeactivated, nil, now)}
+	prs[0].AssignedReviewers = []int{3}
+	hooks := []domain.WebhookEvent{
+		domain.NewUserActivityWebhookEvent(1, false, now),
+		domain.NewUserActivityWebhookEvent(2, false, now),
+		domain.NewReassignedWebhookEvent(&prs[0], 1, 3, now),
+	}
+
+	require.NoError(t, repo.DeactivateAndReassign(ctx, []int{1, 2}, replacements, events, hooks, nil))
+
+	// Bob уже был неактивен, смена активности о нем не отправляется
+	rows, err := pool.Query(ctx, `SELECT event_type FROM webhook_outbox ORDER BY id`)
+	require.NoError(t, err)
+	var types []string
+	for rows.Next() {
+		var typ string
+		require.NoError(t, rows.Scan(&typ))
+		types = append(types, typ)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{string(domain.WebhookUserActivityChanged), string(domain.WebhookPRReassigned)}, types)
+
+	var reviewers []int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT array_agg(reviewer_id) FROM assigned_pr WHERE pr_id = 10`).Scan(&reviewers))
+	assert.Equal(t, []int{3}, reviewers)
+
+	// Прежний ревьювер уже снят: замена по устаревшему выбору отклоняется
+	err = repo.DeactivateAndReassign(ctx, []int{1}, replacements, events, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrNotAssigned)
+}
+
+func TestUserRepository_GetUserPullRequestsVerdictOfCurrentAssignment(t *testing.T) {
+	pool := pgtest.New(t)
+	l, err := logger.NewZapLogger("error")
+	require.NoError(t, err)
+	repo := NewUserRepository(pool, l)
+	ctx := context.Background()
+
+	// Bob одобрил PR 10, был заменен и назначен обратно; PR 11 одобрен в текущем назначении
+	_, err = pool.Exec(ctx, `
+		INSERT INTO team (id, name) VALUES (1, 'backend');
+		INSERT INTO users (id, name, is_active, team_id) VALUES (1, 'Alice', TRUE, 1), (2, 'Bob', TRUE, 1), (3, 'Carol', TRUE, 1);
+		INSERT INTO pull_request (id, title, author_id, status_id, created_at) VALUES
+			(10, 'Add search', 1, 1, '2026-10-01 12:00'), (11, 'Fix search', 1, 1, '2026-10-01 13:00');
+		INSERT INTO assigned_pr (pr_id, reviewer_id) VALUES (10, 2), (11, 2);
+		INSERT INTO assignment_event (pr_id, old_reviewer_id, new_reviewer_id, reason, created_at) VALUES
+			(10, NULL, 2, 'ASSIGNED', '2026-10-01 12:00'),
+			(10, 2, 3, 'REASSIGNED', '2026-10-01 12:20'),
+			(10, 3, 2, 'REASSIGNED', '2026-10-01 12:30'),
+			(11, NULL, 2, 'ASSIGNED', '2026-10-01 13:00');
+		INSERT INTO review_verdict (pr_id, reviewer_id, verdict, created_at) VALUES
+			(10, 2, 'APPROVED', '2026-10-01 12:10'), (11, 2, 'APPROVED', '2026-10-01 13:10');
+	`)
+	require.NoError(t, err)
+
+	page, err := repo.GetUserPullRequests(ctx, &domain.ReviewFilter{UserID: 2, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+
+	assert.Equal(t, 11, page.PullRequests[0].ID)
+	require.NotNil(t, page.PullRequests[0].Verdict)
+	assert.Equal(t, domain.VerdictApproved, *page.PullRequests[0].Verdict)
+	assert.Equal(t, 10, page.PullRequests[1].ID)
+	assert.Nil(t, page.PullRequests[1].Verdict)
+}
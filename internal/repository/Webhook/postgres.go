@@ -0,0 +1,265 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool, logger logger.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+const (
+	checkTeamByName = `
+		SELECT EXISTS(SELECT 1 FROM team WHERE name = $1);
+	`
+
+	createSubscription = `
+		INSERT INTO webhook_subscription (team_id, url, secret, events, created_at)
+		SELECT id, $2, $3, $4, $5 FROM team WHERE name = $1
+		RETURNING id;
+	`
+
+	getTeamSubscriptions = `
+		SELECT s.id, t.name, s.url, s.secret, s.events, s.created_at
+		FROM webhook_subscription s
+		JOIN team t ON t.id = s.team_id
+		WHERE t.name = $1
+		ORDER BY s.id;
+	`
+
+	deleteSubscription = `
+		WITH deleted AS (
+			DELETE FROM webhook_subscription WHERE id = $1
+			RETURNING id, team_id, url, secret, events, created_at
+		)
+		SELECT d.id, t.name, d.url, d.secret, d.events, d.created_at
+		FROM deleted d
+		JOIN team t ON t.id = d.team_id;
+	`
+
+	// enqueueEvent событие для всех подписок команды пользователя $1 на тип $2
+	enqueueEvent = `
+		INSERT INTO webhook_outbox (subscription_id, event_type, payload, next_attempt_at, created_at)
+		SELECT s.id, $2, $3, $4, $4
+		FROM webhook_subscription s
+		JOIN users u ON u.team_id = s.team_id
+		WHERE u.id = $1 AND $2 = ANY(s.events);
+	`
+
+	// claimDue забирает готовые к отправке события и откладывает их на время lease,
+	// чтобы их не взял другой экземпляр сервиса
+	claimDue = `
+		WITH claimed AS (
+			UPDATE webhook_outbox SET next_attempt_at = $3
+			WHERE id IN (
+				SELECT id FROM webhook_outbox
+				WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $2
+				ORDER BY next_attempt_at, id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, subscription_id, event_type, payload, attempts, created_at
+		)
+		SELECT c.id, s.url, s.secret, c.event_type, c.payload, c.attempts, c.created_at
+		FROM claimed c
+		JOIN webhook_subscription s ON s.id = c.subscription_id
+		ORDER BY c.id;
+	`
+
+	markDelivered = `
+		UPDATE webhook_outbox SET attempts = attempts + 1, delivered_at = $2, last_error = NULL WHERE id = $1;
+	`
+
+	scheduleRetry = `
+		UPDATE webhook_outbox SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1;
+	`
+
+	markFailed = `
+		UPDATE webhook_outbox SET attempts = $2, failed_at = $3, last_error = $4 WHERE id = $1;
+	`
+)
+
+func (r *WebhookRepository) ExistsTeamByName(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ExistsTeamByName")
+	defer span.End()
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, checkTeamByName, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check team existance by name: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.Create")
+	defer span.End()
+
+	err := r.pool.QueryRow(ctx, createSubscription,
+		sub.TeamName, sub.URL, sub.Secret, eventsToStrings(sub.Events), sub.CreatedAt,
+	).Scan(&sub.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook subscription: %w", err)
+	}
+	return sub, nil
+}
+
+func (r *WebhookRepository) GetByTeam(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.GetByTeam")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, getTeamSubscriptions, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]domain.WebhookSubscription, 0)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, *sub)
+	}
+
+	return subs, nil
+}
+
+// Delete удаляет подписку, nil если подписки нет
+func (r *WebhookRepository) Delete(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.Delete")
+	defer span.End()
+
+	sub, err := scanSubscription(r.pool.QueryRow(ctx, deleteSubscription, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// ClaimDue возвращает до limit событий, готовых к отправке на момент now,
+// и откладывает их повторную выдачу до leaseUntil
+func (r *WebhookRepository) ClaimDue(
+	ctx context.Context, limit int, now time.Time, leaseUntil time.Time,
+) ([]domain.OutboxMessage, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ClaimDue")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, claimDue, limit, now, leaseUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	msgs := make([]domain.OutboxMessage, 0)
+	for rows.Next() {
+		var msg domain.OutboxMessage
+		var eventType string
+		err := rows.Scan(&msg.ID, &msg.URL, &msg.Secret, &eventType, &msg.Payload, &msg.Attempts, &msg.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		msg.EventType = domain.MapStringToWebhookEventType[eventType]
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.MarkDelivered")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, markDelivered, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message delivered: %w", err)
+	}
+	return nil
+}
+
+// ScheduleRetry сохраняет неудачную попытку и время следующей
+func (r *WebhookRepository) ScheduleRetry(
+	ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastErr string,
+) error {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ScheduleRetry")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, scheduleRetry, id, attempts, nextAttemptAt, lastErr)
+	if err != nil {
+		return fmt.Errorf("failed to schedule outbox retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed прекращает доставку события после исчерпания попыток
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, attempts int, at time.Time, lastErr string) error {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.MarkFailed")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, markFailed, id, attempts, at, lastErr)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
+
+// Enqueue сохраняет события в outbox в транзакции tx изменения,
+// по одной записи на каждую подходящую подписку
+func Enqueue(ctx context.Context, tx pgx.Tx, events []domain.WebhookEvent) error {
+	for _, e := range events {
+		payload, err := json.Marshal(e.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, enqueueEvent, e.UserID, string(e.Type), payload, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue webhook event: %w", err)
+		}
+	}
+	return nil
+}
+
+func scanSubscription(row pgx.Row) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var events []string
+	err := row.Scan(&sub.ID, &sub.TeamName, &sub.URL, &sub.Secret, &events, &sub.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+	}
+
+	for _, e := range events {
+		sub.Events = append(sub.Events, domain.MapStringToWebhookEventType[e])
+	}
+	return &sub, nil
+}
+
+func eventsToStrings(events []domain.WebhookEventType) []string {
+	res := make([]string, 0, len(events))
+	for _, e := range events {
+		res = append(res, string(e))
+	}
+	return res
+}
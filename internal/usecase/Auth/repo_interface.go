@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"time"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_auth_repo.go -package=mocks
+
+type AuthRepo interface {
+	ExistsUserById(ctx context.Context, id int) (bool, error)
+	Create(ctx context.Context, t *domain.ApiToken) (*domain.ApiToken, error)
+	GetActiveByHash(ctx context.Context, hash string) (*domain.ApiToken, error)
+	Revoke(ctx context.Context, id int, at time.Time) (*domain.ApiToken, error)
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"time"
+)
+
+type AuthUsecase struct {
+	repo AuthRepo
+	// adminTokenHash хэш токена администратора из окружения для выпуска первых токенов
+	adminTokenHash string
+	logger         logger.Logger
+}
+
+// NewAuthUsecase adminToken - токен администратора, не хранящийся в базе, пустой - не используется
+func NewAuthUsecase(repo AuthRepo, adminToken string, logger logger.Logger) *AuthUsecase {
+	uc := &AuthUsecase{
+		repo:   repo,
+		logger: logger,
+	}
+	if adminToken != "" {
+		uc.adminTokenHash = domain.HashToken(adminToken)
+	}
+	return uc
+}
+
+// Authenticate возвращает владельца неотозванного токена или ErrUnauthorized
+func (uc *AuthUsecase) Authenticate(ctx context.Context, token string) (*domain.Actor, error) {
+	if token == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	hash := domain.HashToken(token)
+	if uc.adminTokenHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(uc.adminTokenHash)) == 1 {
+		return &domain.Actor{Role: domain.RoleAdmin}, nil
+	}
+
+	t, err := uc.repo.GetActiveByHash(ctx, hash)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("Auth usecase: get token failed")
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	if t == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &domain.Actor{TokenID: t.ID, Role: t.Role, UserID: t.UserID}, nil
+}
+
+// IssueToken выпускает токен, его значение возвращается только здесь
+func (uc *AuthUsecase) IssueToken(ctx context.Context, issue *domain.IssueToken) (*domain.IssuedToken, error) {
+	if issue.UserID != nil {
+		exists, err := uc.repo.ExistsUserById(ctx, *issue.UserID)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": *issue.UserID}).Error("Auth usecase: check user_id failed")
+			return nil, fmt.Errorf("failed to check user: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrUserNotFound
+		}
+	}
+
+	token, err := domain.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.repo.Create(ctx, &domain.ApiToken{
+		Role:      issue.Role,
+		UserID:    issue.UserID,
+		Hash:      domain.HashToken(token),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "role": issue.Role}).Error("Auth usecase: create token failed")
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	return &domain.IssuedToken{Token: token, ApiToken: created}, nil
+}
+
+// RevokeToken отзывает токен, повторный отзыв ничего не меняет
+func (uc *AuthUsecase) RevokeToken(ctx context.Context, id int) (*domain.ApiToken, error) {
+	revoked, err := uc.repo.Revoke(ctx, id, time.Now())
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "tokenID": id}).Error("Auth usecase: revoke token failed")
+		return nil, fmt.Errorf("failed to revoke token: %w", err)
+	}
+	if revoked == nil {
+		return nil, domain.ErrTokenNotFound
+	}
+	return revoked, nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *AuthUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Auth/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthUsecase_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockAuthRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewAuthUsecase(repo, "bootstrap-admin-token", logger)
+
+	ctx := context.Background()
+
+	t.Run("empty token", func(t *testing.T) {
+		actor, err := uc.Authenticate(ctx, "")
+		assert.Nil(t, actor)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	t.Run("bootstrap admin token", func(t *testing.T) {
+		actor, err := uc.Authenticate(ctx, "bootstrap-admin-token")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Actor{Role: domain.RoleAdmin}, actor)
+	})
+
+	t.Run("stored token", func(t *testing.T) {
+		userID := 2
+		repo.EXPECT().GetActiveByHash(ctx, domain.HashToken("user-token")).
+			Return(&domain.ApiToken{ID: 5, Role: domain.RoleUser, UserID: &userID}, nil)
+
+		actor, err := uc.Authenticate(ctx, "user-token")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Actor{TokenID: 5, Role: domain.RoleUser, UserID: &userID}, actor)
+	})
+
+	t.Run("unknown or revoked token", func(t *testing.T) {
+		repo.EXPECT().GetActiveByHash(ctx, domain.HashToken("revoked")).Return(nil, nil)
+
+		actor, err := uc.Authenticate(ctx, "revoked")
+		assert.Nil(t, actor)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo.EXPECT().GetActiveByHash(ctx, gomock.Any()).Return(nil, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Auth usecase: get token failed")
+
+		actor, err := uc.Authenticate(ctx, "any")
+		assert.Nil(t, actor)
+		assert.ErrorContains(t, err, "db error")
+	})
+}
+
+func TestAuthUsecase_IssueToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockAuthRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewAuthUsecase(repo, "", logger)
+
+	ctx := context.Background()
+	userID := 2
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsUserById(ctx, 2).Return(false, nil)
+
+		issued, err := uc.IssueToken(ctx, &domain.IssueToken{Role: domain.RoleUser, UserID: &userID})
+		assert.Nil(t, issued)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("ok stores hash only", func(t *testing.T) {
+		repo.EXPECT().ExistsUserById(ctx, 2).Return(true, nil)
+		repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tok *domain.ApiToken) (*domain.ApiToken, error) {
+				assert.Equal(t, domain.RoleUser, tok.Role)
+				assert.Len(t, tok.Hash, 64)
+				tok.ID = 3
+				return tok, nil
+			},
+		)
+
+		issued, err := uc.IssueToken(ctx, &domain.IssueToken{Role: domain.RoleUser, UserID: &userID})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, issued.Token)
+		assert.Equal(t, domain.HashToken(issued.Token), issued.ApiToken.Hash)
+		assert.Equal(t, 3, issued.ApiToken.ID)
+	})
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		first, err := domain.GenerateToken()
+		assert.NoError(t, err)
+		second, err := domain.GenerateToken()
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestAuthUsecase_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockAuthRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewAuthUsecase(repo, "", logger)
+
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		repo.EXPECT().Revoke(ctx, 9, gomock.Any()).Return(nil, nil)
+
+		revoked, err := uc.RevokeToken(ctx, 9)
+		assert.Nil(t, revoked)
+		assert.Equal(t, domain.ErrTokenNotFound, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		at := time.Now()
+		repo.EXPECT().Revoke(ctx, 3, gomock.Any()).Return(&domain.ApiToken{ID: 3, RevokedAt: &at}, nil)
+
+		revoked, err := uc.RevokeToken(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, &at, revoked.RevokedAt)
+	})
+}
@@ -0,0 +1,14 @@
+package escalation
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source pullrequest_interface.go -destination=mocks/mock_reviewer_escalator.go -package=mocks
+
+// ReviewerEscalator изменения ревьюверов PullRequest, которыми эскалируется просроченное ревью
+type ReviewerEscalator interface {
+	AddReviewer(ctx context.Context, prID int, reason domain.AssignmentReason) (*domain.PullRequest, int, error)
+	ReplaceReviewer(ctx context.Context, prID int, reviewerID int, reason domain.AssignmentReason) (*domain.PullRequest, int, error)
+}
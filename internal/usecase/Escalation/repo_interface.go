@@ -0,0 +1,17 @@
+package escalation
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"time"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_escalation_repo.go -package=mocks
+
+type EscalationRepo interface {
+	GetStaleReviews(ctx context.Context, now time.Time, limit int) ([]domain.StaleReview, error)
+	Claim(ctx context.Context, esc *domain.ReviewEscalation) (bool, error)
+	Complete(ctx context.Context, esc *domain.ReviewEscalation, hooks []domain.WebhookEvent) error
+	Release(ctx context.Context, id int) error
+	GetExpiredClaims(ctx context.Context, before time.Time, limit int) ([]domain.ReviewEscalation, error)
+}
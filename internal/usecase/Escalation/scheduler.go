@@ -0,0 +1,177 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"time"
+)
+
+// Параметры Scheduler по умолчанию
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Minute
+	// DefaultClaimLease за это время захватившая эскалацию попытка должна ее завершить,
+	// иначе захват считается брошенным и сверяется с журналом назначений
+	DefaultClaimLease = 10 * time.Minute
+)
+
+// Scheduler периодически находит просроченные ревью и эскалирует их
+// по политике команды автора
+type Scheduler struct {
+	repo   EscalationRepo
+	prs    ReviewerEscalator
+	logger logger.Logger
+
+	batchSize int
+	interval  time.Duration
+	lease     time.Duration
+	now       func() time.Time
+}
+
+func NewScheduler(repo EscalationRepo, prs ReviewerEscalator, logger logger.Logger) *Scheduler {
+	return &Scheduler{
+		repo:      repo,
+		prs:       prs,
+		logger:    logger,
+		batchSize: DefaultBatchSize,
+		interval:  DefaultPollInterval,
+		lease:     DefaultClaimLease,
+		now:       time.Now,
+	}
+}
+
+// Run эскалирует просроченные ревью раз в interval до отмены ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = s.EscalateBatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// EscalateBatch эскалирует одну пачку просроченных ревью, возвращает число выполненных.
+// Перед этим сверяются брошенные захваты прошлых проходов
+func (s *Scheduler) EscalateBatch(ctx context.Context) (int, error) {
+	s.reconcile(ctx)
+
+	stale, err := s.repo.GetStaleReviews(ctx, s.now(), s.batchSize)
+	if err != nil {
+		s.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("Escalation scheduler: get stale reviews failed")
+		return 0, fmt.Errorf("failed to get stale reviews: %w", err)
+	}
+
+	escalated := 0
+	for i := range stale {
+		ok, err := s.escalate(ctx, &stale[i])
+		if err != nil {
+			s.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": stale[i].PullRequestID, "reviewerID": stale[i].ReviewerID}).
+				Error("Escalation scheduler: escalate review failed")
+			continue
+		}
+		if ok {
+			escalated++
+		}
+	}
+	return escalated, nil
+}
+
+// reconcile завершает захваты старше lease: попытка упала между действием и Complete.
+// Выполненное действие завершается по журналу назначений, невыполненное снимается,
+// и ревью снова эскалируется на этом или следующем проходе
+func (s *Scheduler) reconcile(ctx context.Context) {
+	now := s.now()
+	expired, err := s.repo.GetExpiredClaims(ctx, now.Add(-s.lease), s.batchSize)
+	if err != nil {
+		s.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("Escalation scheduler: get expired claims failed")
+		return
+	}
+
+	for i := range expired {
+		esc := &expired[i]
+		if esc.Action == "" {
+			err = s.repo.Release(ctx, esc.ID)
+		} else {
+			err = s.repo.Complete(ctx, esc, []domain.WebhookEvent{domain.NewReviewStaleWebhookEvent(esc, now)})
+		}
+		if err != nil {
+			s.logger.WithFields(logger.LoggerFields{"err": err.Error(), "escalationID": esc.ID}).Error("Escalation scheduler: reconcile claim failed")
+		}
+	}
+}
+
+// escalate захватывает назначение и выполняет политику; false, если его
+// уже эскалировал другой процесс
+func (s *Scheduler) escalate(ctx context.Context, stale *domain.StaleReview) (bool, error) {
+	esc := &domain.ReviewEscalation{
+		PullRequestID: stale.PullRequestID,
+		ReviewerID:    stale.ReviewerID,
+		AuthorID:      stale.AuthorID,
+		Policy:        stale.Policy,
+		Action:        stale.Policy,
+		StaleSince:    stale.Since,
+		CreatedAt:     s.now(),
+	}
+
+	claimed, err := s.repo.Claim(ctx, esc)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	if err := s.act(ctx, esc); err != nil {
+		// Захват снимается, чтобы повторить эскалацию на следующем проходе
+		if releaseErr := s.repo.Release(ctx, esc.ID); releaseErr != nil {
+			return false, errors.Join(err, releaseErr)
+		}
+		// PR слили, закрыли или сняли ревьювера после выборки - эскалировать нечего
+		if errors.Is(err, domain.ErrPullRequestIsMerged) || errors.Is(err, domain.ErrPullRequestIsClosed) ||
+			errors.Is(err, domain.ErrNotAssigned) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	hooks := []domain.WebhookEvent{domain.NewReviewStaleWebhookEvent(esc, esc.CreatedAt)}
+	if err := s.repo.Complete(ctx, esc, hooks); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// act меняет ревьюверов по политике и записывает в esc выполненное действие;
+// без кандидатов эскалация сводится к NOTIFY
+func (s *Scheduler) act(ctx context.Context, esc *domain.ReviewEscalation) error {
+	var newReviewerID int
+	var err error
+	switch esc.Policy {
+	case domain.EscalationAddReviewer:
+		_, newReviewerID, err = s.prs.AddReviewer(ctx, esc.PullRequestID, domain.ReasonEscalated)
+	case domain.EscalationReassign:
+		_, newReviewerID, err = s.prs.ReplaceReviewer(ctx, esc.PullRequestID, esc.ReviewerID, domain.ReasonEscalated)
+	default:
+		return nil
+	}
+
+	if errors.Is(err, domain.ErrNoAvailableCandidats) {
+		esc.Action = domain.EscalationNotify
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	esc.NewReviewerID = &newReviewerID
+	return nil
+}
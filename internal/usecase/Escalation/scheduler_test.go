@@ -0,0 +1,194 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	"pr-reviewer/internal/usecase/Escalation/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_EscalateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEscalationRepo(ctrl)
+	prs := mocks.NewMockReviewerEscalator(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	s := NewScheduler(repo, prs, logger)
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	since := now.Add(-25 * time.Hour)
+	stale := func(policy domain.EscalationPolicy) domain.StaleReview {
+		return domain.StaleReview{PullRequestID: 1001, ReviewerID: 2, AuthorID: 1, Policy: policy, Since: since}
+	}
+	expectClaim := func(claimed bool) {
+		repo.EXPECT().Claim(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, esc *domain.ReviewEscalation) (bool, error) {
+			esc.ID = 7
+			return claimed, nil
+		})
+	}
+	expectComplete := func(action domain.EscalationPolicy, newReviewerID *int) {
+		repo.EXPECT().Complete(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, esc *domain.ReviewEscalation, hooks []domain.WebhookEvent) error {
+				assert.Equal(t, 7, esc.ID)
+				assert.Equal(t, action, esc.Action)
+				assert.Equal(t, newReviewerID, esc.NewReviewerID)
+				assert.Equal(t, since, esc.StaleSince)
+				assert.Equal(t, []domain.WebhookEvent{domain.NewReviewStaleWebhookEvent(esc, now)}, hooks)
+				return nil
+			},
+		)
+	}
+	intPtr := func(v int) *int { return &v }
+	// Брошенных захватов нет, их сверка проверяется в TestScheduler_Reconcile
+	repo.EXPECT().GetExpiredClaims(ctx, now.Add(-DefaultClaimLease), DefaultBatchSize).Return(nil, nil).AnyTimes()
+
+	t.Run("notify", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationNotify)}, nil)
+		expectClaim(true)
+		expectComplete(domain.EscalationNotify, nil)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("add reviewer", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationAddReviewer)}, nil)
+		expectClaim(true)
+		prs.EXPECT().AddReviewer(ctx, 1001, domain.ReasonEscalated).Return(&domain.PullRequest{ID: 1001}, 3, nil)
+		expectComplete(domain.EscalationAddReviewer, intPtr(3))
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationReassign)}, nil)
+		expectClaim(true)
+		prs.EXPECT().ReplaceReviewer(ctx, 1001, 2, domain.ReasonEscalated).Return(&domain.PullRequest{ID: 1001}, 4, nil)
+		expectComplete(domain.EscalationReassign, intPtr(4))
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("no candidates falls back to notify", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationReassign)}, nil)
+		expectClaim(true)
+		prs.EXPECT().ReplaceReviewer(ctx, 1001, 2, domain.ReasonEscalated).Return(nil, 0, domain.ErrNoAvailableCandidats)
+		expectComplete(domain.EscalationNotify, nil)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("claimed by another process", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationAddReviewer)}, nil)
+		expectClaim(false)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("pr merged after select", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{stale(domain.EscalationAddReviewer)}, nil)
+		expectClaim(true)
+		prs.EXPECT().AddReviewer(ctx, 1001, domain.ReasonEscalated).Return(nil, 0, domain.ErrPullRequestIsMerged)
+		repo.EXPECT().Release(ctx, 7).Return(nil)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("action failed is released and logged", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{
+			stale(domain.EscalationAddReviewer), stale(domain.EscalationNotify),
+		}, nil)
+		expectClaim(true)
+		prs.EXPECT().AddReviewer(ctx, 1001, domain.ReasonEscalated).Return(nil, 0, errors.New("db error"))
+		repo.EXPECT().Release(ctx, 7).Return(nil)
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Escalation scheduler: escalate review failed")
+		expectClaim(true)
+		expectComplete(domain.EscalationNotify, nil)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("get stale reviews failed", func(t *testing.T) {
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return(nil, errors.New("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Escalation scheduler: get stale reviews failed")
+
+		n, err := s.EscalateBatch(ctx)
+		assert.ErrorContains(t, err, "db error")
+		assert.Equal(t, 0, n)
+	})
+}
+
+func TestScheduler_Reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEscalationRepo(ctrl)
+	prs := mocks.NewMockReviewerEscalator(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	s := NewScheduler(repo, prs, logger)
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	newReviewerID := 4
+	claimedAt := now.Add(-time.Hour)
+	done := domain.ReviewEscalation{
+		ID: 7, PullRequestID: 1001, ReviewerID: 2, Policy: domain.EscalationReassign,
+		Action: domain.EscalationReassign, NewReviewerID: &newReviewerID, CreatedAt: claimedAt,
+	}
+	notDone := domain.ReviewEscalation{ID: 8, PullRequestID: 1002, ReviewerID: 2, Policy: domain.EscalationAddReviewer, CreatedAt: claimedAt}
+
+	t.Run("performed action is completed, lost one released", func(t *testing.T) {
+		repo.EXPECT().GetExpiredClaims(ctx, now.Add(-DefaultClaimLease), DefaultBatchSize).Return([]domain.ReviewEscalation{done, notDone}, nil)
+		repo.EXPECT().Complete(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, esc *domain.ReviewEscalation, hooks []domain.WebhookEvent) error {
+				assert.Equal(t, done, *esc)
+				assert.Equal(t, []domain.WebhookEvent{domain.NewReviewStaleWebhookEvent(esc, now)}, hooks)
+				return nil
+			},
+		)
+		repo.EXPECT().Release(ctx, 8).Return(nil)
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{}, nil)
+
+		n, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("reconcile errors do not stop escalation", func(t *testing.T) {
+		repo.EXPECT().GetExpiredClaims(ctx, now.Add(-DefaultClaimLease), DefaultBatchSize).Return(nil, errors.New("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Escalation scheduler: get expired claims failed")
+		repo.EXPECT().GetStaleReviews(ctx, now, DefaultBatchSize).Return([]domain.StaleReview{}, nil)
+
+		_, err := s.EscalateBatch(ctx)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_health_repo.go -package=mocks
+
+type HealthRepo interface {
+	Ping(ctx context.Context) error
+	GetSchemaVersion(ctx context.Context) (*domain.SchemaVersion, error)
+}
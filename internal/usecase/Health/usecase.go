@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/buildinfo"
+	"pr-reviewer/internal/pkg/logger"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout ограничивает проверку БД, чтобы probe не зависал вместе с ней
+const checkTimeout = 2 * time.Second
+
+type HealthUsecase struct {
+	repo HealthRepo
+	// schemaVersion версия схемы, которую ожидает сборка
+	schemaVersion int
+	draining      atomic.Bool
+	logger        logger.Logger
+}
+
+func NewHealthUsecase(repo HealthRepo, schemaVersion int, logger logger.Logger) *HealthUsecase {
+	return &HealthUsecase{
+		repo:          repo,
+		schemaVersion: schemaVersion,
+		logger:        logger,
+	}
+}
+
+// Drain переводит сервис в неготовность перед остановкой
+func (uc *HealthUsecase) Drain() {
+	uc.draining.Store(true)
+}
+
+// Readiness Проверить готовность: сервис не останавливается, БД отвечает, схема не отстает от сборки.
+// /readyz доступен без токена, поэтому проверки возвращают только ошибки domain, а причины пишутся в лог
+func (uc *HealthUsecase) Readiness(ctx context.Context) *domain.Readiness {
+	if uc.draining.Load() {
+		return &domain.Readiness{Checks: []domain.ReadinessCheck{
+			{Name: domain.ReadinessShutdown, Err: domain.ErrShuttingDown},
+		}}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	readiness := &domain.Readiness{}
+
+	if err := uc.repo.Ping(ctx); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("Health usecase: database ping failed")
+		readiness.Checks = append(readiness.Checks,
+			domain.ReadinessCheck{Name: domain.ReadinessDatabase, Err: domain.ErrDatabaseUnavailable},
+			domain.ReadinessCheck{Name: domain.ReadinessMigrations, Err: domain.ErrSchemaUnknown},
+		)
+		return readiness
+	}
+	readiness.Checks = append(readiness.Checks, domain.ReadinessCheck{Name: domain.ReadinessDatabase})
+
+	readiness.Checks = append(readiness.Checks, domain.ReadinessCheck{
+		Name: domain.ReadinessMigrations,
+		Err:  uc.checkSchema(ctx),
+	})
+	return readiness
+}
+
+// BuildInfo Получить данные сборки
+func (uc *HealthUsecase) BuildInfo() domain.BuildInfo {
+	return domain.BuildInfo{
+		Commit:    buildinfo.Commit,
+		BuildTime: buildinfo.BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
+func (uc *HealthUsecase) checkSchema(ctx context.Context) error {
+	v, err := uc.repo.GetSchemaVersion(ctx)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("Health usecase: get schema version failed")
+		return domain.ErrSchemaUnknown
+	}
+	if v.Dirty {
+		uc.log(ctx).WithFields(logger.LoggerFields{"version": v.Version}).Error("Health usecase: schema migration is dirty")
+		return domain.ErrSchemaDirty
+	}
+	// Схема новее сборки допустима на время выкатки
+	if v.Version < uc.schemaVersion {
+		uc.log(ctx).WithFields(logger.LoggerFields{"version": v.Version, "expected": uc.schemaVersion}).Error("Health usecase: schema version is behind the build")
+		return domain.ErrSchemaOutdated
+	}
+	return nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *HealthUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
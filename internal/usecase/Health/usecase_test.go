@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Health/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthUsecase_Readiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockHealthRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("ready", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 18, logger)
+		repo.EXPECT().Ping(gomock.Any()).Return(nil)
+		repo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: 18}, nil)
+
+		readiness := uc.Readiness(ctx)
+		assert.True(t, readiness.Ready())
+		assert.Equal(t, []domain.ReadinessCheck{
+			{Name: domain.ReadinessDatabase},
+			{Name: domain.ReadinessMigrations},
+		}, readiness.Checks)
+	})
+
+	t.Run("schema ahead of build", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 17, logger)
+		repo.EXPECT().Ping(gomock.Any()).Return(nil)
+		repo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: 18}, nil)
+
+		assert.True(t, uc.Readiness(ctx).Ready())
+	})
+
+	t.Run("database unavailable", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 18, logger)
+		repo.EXPECT().Ping(gomock.Any()).Return(fmt.Errorf("connection refused"))
+
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Health usecase: database ping failed")
+
+		readiness := uc.Readiness(ctx)
+		assert.False(t, readiness.Ready())
+		assert.Equal(t, domain.ErrDatabaseUnavailable, readiness.Checks[0].Err)
+		assert.Equal(t, domain.ErrSchemaUnknown, readiness.Checks[1].Err)
+	})
+
+	t.Run("schema outdated", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 18, logger)
+		repo.EXPECT().Ping(gomock.Any()).Return(nil)
+		repo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: 17}, nil)
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Health usecase: schema version is behind the build")
+
+		readiness := uc.Readiness(ctx)
+		assert.False(t, readiness.Ready())
+		assert.NoError(t, readiness.Checks[0].Err)
+		assert.ErrorIs(t, readiness.Checks[1].Err, domain.ErrSchemaOutdated)
+	})
+
+	t.Run("schema dirty", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 18, logger)
+		repo.EXPECT().Ping(gomock.Any()).Return(nil)
+		repo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: 18, Dirty: true}, nil)
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Health usecase: schema migration is dirty")
+
+		readiness := uc.Readiness(ctx)
+		assert.ErrorIs(t, readiness.Checks[1].Err, domain.ErrSchemaDirty)
+	})
+
+	t.Run("draining skips database", func(t *testing.T) {
+		uc := NewHealthUsecase(repo, 18, logger)
+		uc.Drain()
+
+		readiness := uc.Readiness(ctx)
+		assert.False(t, readiness.Ready())
+		assert.Equal(t, []domain.ReadinessCheck{
+			{Name: domain.ReadinessShutdown, Err: domain.ErrShuttingDown},
+		}, readiness.Checks)
+	})
+}
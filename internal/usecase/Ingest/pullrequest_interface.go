@@ -0,0 +1,16 @@
+package ingest
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source pullrequest_interface.go -destination=mocks/mock_pullrequest_lifecycle.go -package=mocks
+
+// PullRequestLifecycle операции над PullRequest, на которые отображаются внешние события
+type PullRequestLifecycle interface {
+	CreatePullRequest(ctx context.Context, cr *domain.CreatePullRequest) (*domain.PullRequest, error)
+	MergePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error)
+}
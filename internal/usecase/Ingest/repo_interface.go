@@ -0,0 +1,12 @@
+package ingest
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_ingest_repo.go -package=mocks
+
+type IngestRepo interface {
+	GetLinkedPullRequest(ctx context.Context, ev *domain.ExternalPullRequestEvent) (int, error)
+}
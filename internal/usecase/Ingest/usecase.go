@@ -0,0 +1,124 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+)
+
+type IngestUsecase struct {
+	repo   IngestRepo
+	prs    PullRequestLifecycle
+	cfg    domain.IngestConfig
+	logger logger.Logger
+}
+
+func NewIngestUsecase(repo IngestRepo, prs PullRequestLifecycle, cfg domain.IngestConfig, logger logger.Logger) *IngestUsecase {
+	return &IngestUsecase{
+		repo:   repo,
+		prs:    prs,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Ingest проверяет подпись события внешнего сервиса и применяет его к PullRequest
+func (uc *IngestUsecase) Ingest(ctx context.Context, d *domain.IngestDelivery) (*domain.IngestResult, error) {
+	ev, err := uc.parse(d)
+	if err != nil {
+		return nil, err
+	}
+	if ev == nil || ev.Action == "" {
+		return &domain.IngestResult{Outcome: domain.IngestIgnored}, nil
+	}
+
+	prID, err := uc.repo.GetLinkedPullRequest(ctx, ev)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "repository": ev.Repository, "number": ev.Number}).Error("Ingest usecase: get linked pull_request failed")
+		return nil, err
+	}
+
+	if prID == 0 {
+		// Слияние и закрытие PR, созданного до подключения интеграции, пропускаются
+		if ev.Action != domain.ExternalOpened && ev.Action != domain.ExternalReopened {
+			return &domain.IngestResult{Outcome: domain.IngestIgnored}, nil
+		}
+		return uc.create(ctx, ev)
+	}
+
+	var pr *domain.PullRequest
+	var outcome domain.IngestOutcome
+	switch ev.Action {
+	case domain.ExternalOpened:
+		// Повторная доставка события открытия
+		return &domain.IngestResult{Outcome: domain.IngestIgnored}, nil
+	case domain.ExternalReopened:
+		pr, err = uc.prs.ReopenPullRequest(ctx, prID)
+		outcome = domain.IngestReopened
+	case domain.ExternalMerged:
+		pr, err = uc.prs.MergePullRequest(ctx, prID)
+		outcome = domain.IngestMerged
+	case domain.ExternalClosed:
+		pr, err = uc.prs.ClosePullRequest(ctx, prID)
+		outcome = domain.IngestClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.IngestResult{Outcome: outcome, PullRequest: pr}, nil
+}
+
+// parse проверяет подпись и разбирает тело, nil для событий другого типа
+func (uc *IngestUsecase) parse(d *domain.IngestDelivery) (*domain.ExternalPullRequestEvent, error) {
+	switch d.Provider {
+	case domain.ProviderGitHub:
+		if !domain.VerifyGitHubSignature(uc.cfg.GitHubSecret, d.Body, d.Signature) {
+			return nil, domain.ErrInvalidSignature
+		}
+		if d.Event != domain.GitHubPullRequestEvent {
+			return nil, nil
+		}
+		return domain.ParseGitHubPullRequestEvent(d.Body)
+	case domain.ProviderGitLab:
+		if !domain.VerifyGitLabToken(uc.cfg.GitLabToken, d.Signature) {
+			return nil, domain.ErrInvalidSignature
+		}
+		if d.Event != domain.GitLabMergeRequestEvent {
+			return nil, nil
+		}
+		return domain.ParseGitLabMergeRequestEvent(d.Body)
+	default:
+		return nil, fmt.Errorf("%w: unknown provider %s", domain.ErrInvalidPayload, d.Provider)
+	}
+}
+
+// create создает PullRequest для внешнего; связь сохраняется в той же транзакции
+func (uc *IngestUsecase) create(ctx context.Context, ev *domain.ExternalPullRequestEvent) (*domain.IngestResult, error) {
+	authorID, ok := uc.cfg.Users.Lookup(ev.Provider, ev.Author)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s:%s", domain.ErrUnknownExternalUser, ev.Provider, ev.Author)
+	}
+
+	pr, err := uc.prs.CreatePullRequest(ctx, &domain.CreatePullRequest{
+		Name:     ev.Title,
+		AuthorId: authorID,
+		External: ev.Ref(),
+	})
+	// Параллельная доставка того же события уже создала PR
+	if errors.Is(err, domain.ErrExternalPullRequestLinked) {
+		return &domain.IngestResult{Outcome: domain.IngestIgnored}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.IngestResult{Outcome: domain.IngestCreated, PullRequest: pr}, nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *IngestUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
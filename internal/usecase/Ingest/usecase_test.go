@@ -0,0 +1,169 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Ingest/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "0123456789abcdef"
+
+func gitHubDelivery(action string, merged bool) *domain.IngestDelivery {
+	body := []byte(fmt.Sprintf(`{"action":%q,"pull_request":{"number":42,"title":"Add search","merged":%t,`+
+		`"user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`, action, merged))
+	return &domain.IngestDelivery{
+		Provider:  domain.ProviderGitHub,
+		Event:     domain.GitHubPullRequestEvent,
+		Signature: domain.SignWebhook(testSecret, body),
+		Body:      body,
+	}
+}
+
+func TestIngestUsecase_Ingest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockIngestRepo(ctrl)
+	prs := mockRepo.NewMockPullRequestLifecycle(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	cfg := domain.IngestConfig{
+		GitHubSecret: testSecret,
+		GitLabToken:  testSecret,
+		Users:        domain.ExternalUserMap{domain.ProviderGitHub: {"octocat": 1}},
+	}
+	uc := NewIngestUsecase(repo, prs, cfg, logger)
+
+	ctx := context.Background()
+
+	t.Run("invalid signature", func(t *testing.T) {
+		d := gitHubDelivery("opened", false)
+		d.Signature = domain.SignWebhook("wrong-secret", d.Body)
+
+		res, err := uc.Ingest(ctx, d)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, domain.ErrInvalidSignature)
+	})
+
+	t.Run("other github event ignored", func(t *testing.T) {
+		d := gitHubDelivery("opened", false)
+		d.Event = "push"
+
+		res, err := uc.Ingest(ctx, d)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestIgnored, res.Outcome)
+	})
+
+	external := &domain.ExternalPullRequestRef{Provider: domain.ProviderGitHub, Repository: "acme/api", Number: 42}
+
+	t.Run("opened creates linked pull_request", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(0, nil)
+		created := &domain.PullRequest{ID: 11, Name: "Add search", AuthorID: 1, Status: domain.PRStatusOpen, External: external}
+		prs.EXPECT().CreatePullRequest(ctx, &domain.CreatePullRequest{Name: "Add search", AuthorId: 1, External: external}).
+			Return(created, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("opened", false))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestCreated, res.Outcome)
+		assert.Equal(t, created, res.PullRequest)
+	})
+
+	t.Run("opened linked by concurrent delivery ignored", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(0, nil)
+		prs.EXPECT().CreatePullRequest(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to create PR: %w", domain.ErrExternalPullRequestLinked))
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("opened", false))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestIgnored, res.Outcome)
+	})
+
+	t.Run("opened with unmapped author", func(t *testing.T) {
+		uc := NewIngestUsecase(repo, prs, domain.IngestConfig{GitHubSecret: testSecret}, logger)
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(0, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("opened", false))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, domain.ErrUnknownExternalUser)
+	})
+
+	t.Run("repeated opened ignored", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(11, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("opened", false))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestIgnored, res.Outcome)
+	})
+
+	t.Run("closed merged merges", func(t *testing.T) {
+		merged := &domain.PullRequest{ID: 11, Status: domain.PRStatusMerged}
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(11, nil)
+		prs.EXPECT().MergePullRequest(ctx, 11).Return(merged, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("closed", true))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestMerged, res.Outcome)
+		assert.Equal(t, merged, res.PullRequest)
+	})
+
+	t.Run("merge rejected by policy", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(11, nil)
+		prs.EXPECT().MergePullRequest(ctx, 11).Return(nil, domain.ErrNotApproved)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("closed", true))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, domain.ErrNotApproved)
+	})
+
+	t.Run("reopened reopens", func(t *testing.T) {
+		reopened := &domain.PullRequest{ID: 11, Status: domain.PRStatusOpen}
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(11, nil)
+		prs.EXPECT().ReopenPullRequest(ctx, 11).Return(reopened, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("reopened", false))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestReopened, res.Outcome)
+	})
+
+	t.Run("merged unknown pull_request ignored", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(0, nil)
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("closed", true))
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestIgnored, res.Outcome)
+	})
+
+	t.Run("gitlab close", func(t *testing.T) {
+		body := []byte(`{"object_kind":"merge_request","user":{"username":"jdoe"},` +
+			`"project":{"path_with_namespace":"acme/web"},"object_attributes":{"iid":7,"title":"Fix","action":"close"}}`)
+		closed := &domain.PullRequest{ID: 12, Status: domain.PRStatusClosed}
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(12, nil)
+		prs.EXPECT().ClosePullRequest(ctx, 12).Return(closed, nil)
+
+		res, err := uc.Ingest(ctx, &domain.IngestDelivery{
+			Provider:  domain.ProviderGitLab,
+			Event:     domain.GitLabMergeRequestEvent,
+			Signature: testSecret,
+			Body:      body,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IngestClosed, res.Outcome)
+	})
+
+	t.Run("link lookup error", func(t *testing.T) {
+		repo.EXPECT().GetLinkedPullRequest(ctx, gomock.Any()).Return(0, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Ingest usecase: get linked pull_request failed")
+
+		res, err := uc.Ingest(ctx, gitHubDelivery("closed", true))
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "db error")
+	})
+}
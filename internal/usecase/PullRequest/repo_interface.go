@@ -9,9 +9,32 @@ import (
 
 type PullRequestRepo interface {
 	ExistsById(ctx context.Context, id int) (bool, error)
+	ExistsTeamByName(ctx context.Context, name string) (bool, error)
 	GetActiveTeamMembersExceptAuthor(ctx context.Context, authorId int) ([]domain.User, error)
-	Create(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
+	GetActiveTeamMembersWithLoad(ctx context.Context, authorId int) ([]domain.User, error)
+	NextPullRequestID(ctx context.Context) (int, error)
+	Create(
+		ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+		moves domain.RoundRobinMoves,
+	) (*domain.PullRequest, error)
 	GetById(ctx context.Context, id int) (*domain.PullRequest, error)
-	UpdateStatus(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
-	UpdateAssignedReviewers(ctx context.Context, prID int, oldReviewerID int, newReviewerID int) error
+	UpdateStatus(
+		ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+	) (*domain.PullRequest, error)
+	AssignReviewers(
+		ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+	) (*domain.PullRequest, error)
+	AddReview(ctx context.Context, prID int, review *domain.Review) error
+	UpdateAssignedReviewers(
+		ctx context.Context, prID int, oldReviewerID int, newReviewerID int,
+		events []domain.AssignmentEvent, hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+	) error
+	AddReviewer(
+		ctx context.Context, prID int, reviewerID int, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+	) error
+	GetAssignmentEvents(ctx context.Context, prID int) ([]domain.AssignmentEvent, error)
+	List(ctx context.Context, filter *domain.PullRequestFilter) (*domain.PullRequestPage, error)
+	GetMergePolicy(ctx context.Context, authorId int) (*domain.MergePolicy, error)
+	GetReviewerSelection(ctx context.Context, authorId int) (*domain.ReviewerSelection, error)
+	GetActiveMembersByTeamID(ctx context.Context, teamID int) ([]domain.User, error)
 }
@@ -0,0 +1,125 @@
+package pullrequest
+
+import (
+	"pr-reviewer/internal/domain"
+	"slices"
+)
+
+// ReviewerSelector стратегия выбора ревьюверов из кандидатов
+type ReviewerSelector interface {
+	// Select возвращает не более n ревьюверов из candidates
+	Select(candidates []domain.User, n int) []domain.User
+}
+
+// RandomSelector равновероятный случайный выбор
+type RandomSelector struct {
+	intn func(n int) int
+}
+
+func NewRandomSelector(intn func(n int) int) *RandomSelector {
+	return &RandomSelector{intn: intn}
+}
+
+func (s *RandomSelector) Select(candidates []domain.User, n int) []domain.User {
+	shuffled := shuffle(candidates, s.intn)
+	return shuffled[:min(n, len(shuffled))]
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим количеством OPEN ревью,
+// при равной нагрузке - случайно
+type LeastLoadedSelector struct {
+	intn func(n int) int
+}
+
+func NewLeastLoadedSelector(intn func(n int) int) *LeastLoadedSelector {
+	return &LeastLoadedSelector{intn: intn}
+}
+
+func (s *LeastLoadedSelector) Select(candidates []domain.User, n int) []domain.User {
+	sorted := shuffle(candidates, s.intn)
+	slices.SortStableFunc(sorted, func(a, b domain.User) int {
+		return a.OpenReviews - b.OpenReviews
+	})
+
+	return sorted[:min(n, len(sorted))]
+}
+
+// RoundRobinSelector выбирает кандидатов по кругу, начиная после последнего назначенного
+type RoundRobinSelector struct {
+	lastReviewerID int
+}
+
+func NewRoundRobinSelector(lastReviewerID int) *RoundRobinSelector {
+	return &RoundRobinSelector{lastReviewerID: lastReviewerID}
+}
+
+func (s *RoundRobinSelector) Select(candidates []domain.User, n int) []domain.User {
+	sorted := slices.Clone(candidates)
+	slices.SortFunc(sorted, func(a, b domain.User) int { return a.ID - b.ID })
+
+	start := slices.IndexFunc(sorted, func(u domain.User) bool { return u.ID > s.lastReviewerID })
+	if start == -1 {
+		start = 0
+	}
+
+	n = min(n, len(sorted))
+	selected := make([]domain.User, 0, n)
+	for i := 0; i < n; i++ {
+		selected = append(selected, sorted[(start+i)%len(sorted)])
+	}
+
+	return selected
+}
+
+// WeightedSelector случайный выбор с вероятностью, пропорциональной весу пользователя
+type WeightedSelector struct {
+	intn func(n int) int
+}
+
+func NewWeightedSelector(intn func(n int) int) *WeightedSelector {
+	return &WeightedSelector{intn: intn}
+}
+
+func (s *WeightedSelector) Select(candidates []domain.User, n int) []domain.User {
+	pool := slices.Clone(candidates)
+	n = min(n, len(pool))
+
+	selected := make([]domain.User, 0, n)
+	for len(selected) < n {
+		total := 0
+		for _, u := range pool {
+			total += weightOf(u)
+		}
+
+		r := s.intn(total)
+		idx := 0
+		for ; idx < len(pool)-1; idx++ {
+			r -= weightOf(pool[idx])
+			if r < 0 {
+				break
+			}
+		}
+
+		selected = append(selected, pool[idx])
+		pool = slices.Delete(pool, idx, idx+1)
+	}
+
+	return selected
+}
+
+func weightOf(u domain.User) int {
+	if u.ReviewWeight < 1 {
+		return domain.DefaultReviewWeight
+	}
+	return u.ReviewWeight
+}
+
+// shuffle возвращает перемешанную копию users
+func shuffle(users []domain.User, intn func(n int) int) []domain.User {
+	shuffled := slices.Clone(users)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled
+}
@@ -0,0 +1,112 @@
+package pullrequest
+
+import (
+	"math/rand"
+	"pr-reviewer/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func userIDs(users []domain.User) []int {
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func TestRandomSelector(t *testing.T) {
+	candidates := []domain.User{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	t.Run("same seed - same reviewers", func(t *testing.T) {
+		first := NewRandomSelector(rand.New(rand.NewSource(42)).Intn).Select(candidates, 2)
+		second := NewRandomSelector(rand.New(rand.NewSource(42)).Intn).Select(candidates, 2)
+
+		assert.Len(t, first, 2)
+		assert.Equal(t, userIDs(first), userIDs(second))
+		assert.NotEqual(t, first[0].ID, first[1].ID)
+	})
+
+	t.Run("less candidates than needed", func(t *testing.T) {
+		selected := NewRandomSelector(rand.New(rand.NewSource(1)).Intn).Select(candidates[:1], 2)
+		assert.Equal(t, []int{1}, userIDs(selected))
+	})
+
+	t.Run("candidates are not modified", func(t *testing.T) {
+		NewRandomSelector(rand.New(rand.NewSource(1)).Intn).Select(candidates, 4)
+		assert.Equal(t, []int{1, 2, 3, 4}, userIDs(candidates))
+	})
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	t.Run("fewest open reviews first", func(t *testing.T) {
+		candidates := []domain.User{{ID: 1, OpenReviews: 5}, {ID: 2}, {ID: 3, OpenReviews: 2}, {ID: 4, OpenReviews: 1}}
+
+		selected := NewLeastLoadedSelector(rand.New(rand.NewSource(1)).Intn).Select(candidates, 2)
+
+		assert.Equal(t, []int{2, 4}, userIDs(selected))
+	})
+
+	t.Run("ties are broken randomly", func(t *testing.T) {
+		candidates := []domain.User{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4, OpenReviews: 1}}
+		selector := NewLeastLoadedSelector(rand.New(rand.NewSource(5)).Intn)
+
+		hits := map[int]int{}
+		for i := 0; i < 300; i++ {
+			hits[selector.Select(candidates, 1)[0].ID]++
+		}
+
+		assert.Zero(t, hits[4])
+		for _, id := range []int{1, 2, 3} {
+			assert.Greater(t, hits[id], 50)
+		}
+	})
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	candidates := []domain.User{{ID: 30}, {ID: 10}, {ID: 20}}
+
+	tests := []struct {
+		name   string
+		lastID int
+		n      int
+		want   []int
+	}{
+		{"first assignment", 0, 2, []int{10, 20}},
+		{"continue after last", 10, 2, []int{20, 30}},
+		{"wrap around", 20, 2, []int{30, 10}},
+		{"last is not a candidate anymore", 25, 1, []int{30}},
+		{"last is the biggest id", 30, 1, []int{10}},
+		{"n bigger than candidates", 10, 5, []int{20, 30, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := NewRoundRobinSelector(tt.lastID).Select(candidates, tt.n)
+			assert.Equal(t, tt.want, userIDs(selected))
+		})
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	t.Run("heavier reviewer is chosen more often", func(t *testing.T) {
+		candidates := []domain.User{{ID: 1, ReviewWeight: 1}, {ID: 2, ReviewWeight: 9}}
+		selector := NewWeightedSelector(rand.New(rand.NewSource(7)).Intn)
+
+		hits := map[int]int{}
+		for i := 0; i < 1000; i++ {
+			hits[selector.Select(candidates, 1)[0].ID]++
+		}
+
+		assert.Greater(t, hits[2], hits[1]*3)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		candidates := []domain.User{{ID: 1, ReviewWeight: 100}, {ID: 2}, {ID: 3, ReviewWeight: 2}}
+		selector := NewWeightedSelector(rand.New(rand.NewSource(3)).Intn)
+
+		selected := selector.Select(candidates, 3)
+		assert.ElementsMatch(t, []int{1, 2, 3}, userIDs(selected))
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"math/rand"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/metrics"
+	"pr-reviewer/internal/pkg/tracing"
 	user "pr-reviewer/internal/usecase/User"
 	"slices"
 	"time"
@@ -15,56 +17,265 @@ type PullRequestUsecase struct {
 	repo     PullRequestRepo
 	userRepo user.UserRepo
 	logger   logger.Logger
+	// randIntn источник случайности для стратегий выбора, по умолчанию rand.Intn
+	randIntn func(n int) int
 }
 
 func NewPullRequestUsecase(repo PullRequestRepo, userRepo user.UserRepo, logger logger.Logger) *PullRequestUsecase {
 	return &PullRequestUsecase{
 		repo:     repo,
 		userRepo: userRepo,
+		logger:   logger,
+		randIntn: rand.Intn,
 	}
 }
 
 func (uc *PullRequestUsecase) CreatePullRequest(ctx context.Context, cr *domain.CreatePullRequest) (*domain.PullRequest, error) {
-	if err := uc.checkCreatePRConditions(ctx, cr.AuthorId, cr.PullRequestId); err != nil {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.CreatePullRequest")
+	defer span.End()
+
+	prID := cr.PullRequestId
+	if cr.External != nil {
+		id, err := uc.repo.NextPullRequestID(ctx)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("PR usecase: failed to get next pull_request id")
+			return nil, fmt.Errorf("failed to get next PR id: %w", err)
+		}
+		prID = id
+	}
+
+	if err := uc.checkCreatePRConditions(ctx, cr.AuthorId, prID); err != nil {
 		return nil, err
 	}
 
 	pr := &domain.PullRequest{
-		ID:                cr.PullRequestId,
+		ID:                prID,
 		Name:              cr.Name,
 		AuthorID:          cr.AuthorId,
 		CreatedAt:         time.Now(),
 		Status:            domain.PRStatusOpen,
 		AssignedReviewers: []int{},
+		External:          cr.External,
 	}
 
-	teamMembers, err := uc.repo.GetActiveTeamMembersExceptAuthor(ctx, cr.AuthorId)
+	// Черновику ревьюверы назначаются при переводе в OPEN
+	if cr.Draft {
+		pr.Status = domain.PRStatusDraft
+		hooks := []domain.WebhookEvent{domain.NewPRWebhookEvent(domain.WebhookPRCreated, pr, pr.CreatedAt)}
+		createdPR, err := uc.repo.Create(ctx, pr, nil, hooks, nil)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID}).Error("PR usecase: failed to create pull_request")
+			return nil, fmt.Errorf("failed to create PR: %w", err)
+		}
+		metrics.PullRequestCreated(createdPR.AuthorTeamName)
+		return createdPR, nil
+	}
+
+	var createdPR *domain.PullRequest
+	err := domain.RetryOnRoundRobinMoved(func() error {
+		var moves domain.RoundRobinMoves
+		sel, err := uc.pickReviewers(ctx, pr, &moves)
+		if err != nil {
+			return err
+		}
+
+		authorID := pr.AuthorID
+		events := domain.NewAssignedEvents(pr.ID, pr.AssignedReviewers, &authorID, pr.CreatedAt)
+		hooks := []domain.WebhookEvent{domain.NewPRWebhookEvent(domain.WebhookPRCreated, pr, pr.CreatedAt)}
+
+		createdPR, err = uc.repo.Create(ctx, pr, events, hooks, moves)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID}).Error("PR usecase: failed to create pull_request")
+			return fmt.Errorf("failed to create PR: %w", err)
+		}
+		metrics.PullRequestCreated(sel.TeamName)
+		return nil
+	})
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "authorID": cr.AuthorId}).Error("PR usecase: failed to get active members")
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		return nil, err
 	}
 
-	n := domain.MaxReviewersNumber
-	if len(teamMembers) < 2 {
-		n = len(teamMembers)
+	return createdPR, nil
+}
+
+func (uc *PullRequestUsecase) MergePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.MergePullRequest")
+	defer span.End()
+
+	pr, err := uc.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
-	rand.Shuffle(len(teamMembers), func(i, j int) { teamMembers[i], teamMembers[j] = teamMembers[j], teamMembers[i] })
+	if pr.Status == domain.PRStatusMerged {
+		return pr, nil
+	}
 
-	for i := 0; i < n; i++ {
-		pr.AssignedReviewers = append(pr.AssignedReviewers, teamMembers[i].ID)
+	pr.Status, err = domain.NextStatus(pr.Status, domain.PRActionMerge)
+	if err != nil {
+		return nil, err
 	}
 
-	createdPR, err := uc.repo.Create(ctx, pr)
+	policy, err := uc.repo.GetMergePolicy(ctx, pr.AuthorID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID}).Error("PR usecase: failed to create pull_request")
-		return nil, fmt.Errorf("failed to create PR: %w", err)
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID}).Error("PR usecase: failed to get merge policy")
+		return nil, fmt.Errorf("failed to get merge policy: %w", err)
+	}
+	if err := policy.Check(pr); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pr.MergedAt = &now
+
+	events := []domain.AssignmentEvent{
+		domain.NewStatusEvent(pr.ID, domain.ReasonMerged, domain.ActorUserID(ctx), now),
 	}
+	hooks := []domain.WebhookEvent{domain.NewPRWebhookEvent(domain.WebhookPRMerged, pr, now)}
 
-	return createdPR, err
+	mergedPR, err := uc.updateStatus(ctx, pr, events, hooks)
+	if err != nil {
+		return nil, err
+	}
+	metrics.PullRequestMerged(pr.AuthorTeamName)
+	return mergedPR, nil
 }
 
-func (uc *PullRequestUsecase) MergePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+// ClosePullRequest закрывает PullRequest без слияния, повторное закрытие ничего не меняет
+func (uc *PullRequestUsecase) ClosePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.ClosePullRequest")
+	defer span.End()
+
+	pr, err := uc.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusClosed {
+		return pr, nil
+	}
+
+	pr.Status, err = domain.NextStatus(pr.Status, domain.PRActionClose)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pr.ClosedAt = &now
+
+	return uc.updateStatus(ctx, pr, []domain.AssignmentEvent{
+		domain.NewStatusEvent(pr.ID, domain.ReasonClosed, domain.ActorUserID(ctx), now),
+	}, nil)
+}
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов
+func (uc *PullRequestUsecase) MarkReady(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.MarkReady")
+	defer span.End()
+
+	pr, err := uc.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status, err = domain.NextStatus(pr.Status, domain.PRActionReady)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.openWithReviewers(ctx, pr, nil)
+}
+
+// ReopenPullRequest переоткрывает закрытый PullRequest;
+// если ревьюверов не было (закрыт черновик), они назначаются
+func (uc *PullRequestUsecase) ReopenPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.ReopenPullRequest")
+	defer span.End()
+
+	pr, err := uc.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status, err = domain.NextStatus(pr.Status, domain.PRActionReopen)
+	if err != nil {
+		return nil, err
+	}
+	pr.ClosedAt = nil
+
+	events := []domain.AssignmentEvent{
+		domain.NewStatusEvent(pr.ID, domain.ReasonReopened, domain.ActorUserID(ctx), time.Now()),
+	}
+
+	if len(pr.AssignedReviewers) > 0 {
+		return uc.updateStatus(ctx, pr, events, nil)
+	}
+
+	return uc.openWithReviewers(ctx, pr, events)
+}
+
+// SubmitReview сохраняет вердикт назначенного ревьювера по OPEN PullRequest
+func (uc *PullRequestUsecase) SubmitReview(ctx context.Context, sr *domain.SubmitReview) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.SubmitReview")
+	defer span.End()
+
+	userExists, err := uc.userRepo.ExistsById(ctx, sr.UserID)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": sr.UserID}).Error("PR usecase: failed to check user existence")
+		return nil, fmt.Errorf("failed to check reviewer existence: %w", err)
+	}
+	if !userExists {
+		return nil, domain.ErrUserNotFound
+	}
+
+	pr, err := uc.getPullRequest(ctx, sr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		return nil, domain.ErrPullRequestIsMerged
+	case domain.PRStatusClosed:
+		return nil, domain.ErrPullRequestIsClosed
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, sr.UserID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	review := domain.Review{
+		ReviewerID:  sr.UserID,
+		Verdict:     sr.Verdict,
+		SubmittedAt: time.Now(),
+	}
+
+	if err := uc.repo.AddReview(ctx, pr.ID, &review); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID, "userID": sr.UserID}).Error("PR usecase: failed to add review")
+		return nil, fmt.Errorf("failed to add review: %w", err)
+	}
+
+	pr.Reviews = slices.DeleteFunc(pr.Reviews, func(r domain.Review) bool {
+		return r.ReviewerID == sr.UserID
+	})
+	pr.Reviews = append(pr.Reviews, review)
+
+	return pr, nil
+}
+
+// GetPullRequest возвращает PullRequest с ревьюверами и командой автора
+func (uc *PullRequestUsecase) GetPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.GetPullRequest")
+	defer span.End()
+
+	return uc.getPullRequest(ctx, prID)
+}
+
+// GetHistory возвращает журнал назначений PullRequest
+func (uc *PullRequestUsecase) GetHistory(ctx context.Context, prID int) ([]domain.AssignmentEvent, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.GetHistory")
+	defer span.End()
+
 	prExists, err := uc.checkPRIDExists(ctx, prID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check PR existance: %w", err)
@@ -73,100 +284,441 @@ func (uc *PullRequestUsecase) MergePullRequest(ctx context.Context, prID int) (*
 		return nil, domain.ErrPullRequestNotFound
 	}
 
-	pr, err := uc.repo.GetById(ctx, prID)
+	events, err := uc.repo.GetAssignmentEvents(ctx, prID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": prID}).Error("PR usecase: failed to get pull_request by id")
-		return nil, fmt.Errorf("failed to get PR by id: %w", err)
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": prID}).Error("PR usecase: failed to get assignment events")
+		return nil, fmt.Errorf("failed to get assignment events: %w", err)
 	}
+	return events, nil
+}
 
-	if pr.Status == domain.PRStatusMerged {
-		return pr, nil
+// ListPullRequests возвращает страницу PullRequest по фильтру. Несуществующая команда
+// в фильтре - domain.ErrTeamNotFound, несуществующие автор или ревьювер дают пустую страницу
+func (uc *PullRequestUsecase) ListPullRequests(ctx context.Context, filter *domain.PullRequestFilter) (*domain.PullRequestPage, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.ListPullRequests")
+	defer span.End()
+
+	// Как и в /users/getReview, неизвестная команда - ошибка, а не пустой список
+	if filter.TeamName != nil {
+		exists, err := uc.repo.ExistsTeamByName(ctx, *filter.TeamName)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": *filter.TeamName}).Error("PR usecase: failed to check team_name")
+			return nil, fmt.Errorf("failed to check team existance: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
 	}
 
-	now := time.Now()
-	pr.Status = domain.PRStatusMerged
-	pr.MergedAt = &now
-
-	updatedPR, err := uc.repo.UpdateStatus(ctx, pr)
+	page, err := uc.repo.List(ctx, filter)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID, "status": pr.Status}).Error("PR usecase: failed to update status")
-		return nil, fmt.Errorf("failed to update PR status: %w", err)
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("PR usecase: failed to list pull_requests")
+		return nil, fmt.Errorf("failed to list pull_requests: %w", err)
 	}
-
-	return updatedPR, nil
+	return page, nil
 }
 
 func (uc *PullRequestUsecase) ReassignReviewer(ctx context.Context, reas *domain.ReassingReviewer) (*domain.PullRequest, int, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.ReassignReviewer")
+	defer span.End()
+
 	userExists, err := uc.userRepo.ExistsById(ctx, reas.UserID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": reas.UserID}).Error("PR usecase: failed to check user existence")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": reas.UserID}).Error("PR usecase: failed to check user existence")
 		return nil, 0, fmt.Errorf("failed to check author existence: %w", err)
 	}
 	if !userExists {
 		return nil, 0, domain.ErrUserNotFound
 	}
 
-	prExists, err := uc.checkPRIDExists(ctx, reas.PullRequestID)
+	return uc.ReplaceReviewer(ctx, reas.PullRequestID, reas.UserID, domain.ReasonReassigned)
+}
+
+// ReplaceReviewer заменяет назначенного ревьювера reviewerID в PullRequest prID
+// другим участником команды автора; reason записывается в журнал назначений
+func (uc *PullRequestUsecase) ReplaceReviewer(
+	ctx context.Context, prID int, reviewerID int, reason domain.AssignmentReason,
+) (*domain.PullRequest, int, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.ReplaceReviewer")
+	defer span.End()
+
+	var (
+		pr            *domain.PullRequest
+		newReviewerID int
+	)
+	err := domain.RetryOnRoundRobinMoved(func() error {
+		var err error
+		pr, newReviewerID, err = uc.replaceReviewer(ctx, prID, reviewerID, reason)
+		return err
+	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to check PR existance: %w", err)
+		return nil, 0, err
 	}
-	if !prExists {
-		return nil, 0, domain.ErrPullRequestNotFound
+	return pr, newReviewerID, nil
+}
+
+// replaceReviewer выполняет одну попытку ReplaceReviewer
+func (uc *PullRequestUsecase) replaceReviewer(
+	ctx context.Context, prID int, reviewerID int, reason domain.AssignmentReason,
+) (*domain.PullRequest, int, error) {
+	pr, err := uc.getChangeablePullRequest(ctx, prID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	idx := slices.IndexFunc(pr.AssignedReviewers, func(id int) bool {
+		return id == reviewerID
+	})
+	if idx == -1 {
+		return nil, 0, domain.ErrNotAssigned
 	}
 
-	pr, err := uc.repo.GetById(ctx, reas.PullRequestID)
+	var moves domain.RoundRobinMoves
+	sel, newReviewer, err := uc.pickReplacement(ctx, pr, nil, &moves, nil)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": reas.PullRequestID}).Error("PR usecase: failed to get pull_request by id")
-		return nil, 0, fmt.Errorf("failed to get pull_request: %w", err)
+		return nil, 0, err
+	}
+
+	pr.AssignedReviewers[idx] = newReviewer.ID
+	pr.FallbackReviewers = slices.DeleteFunc(pr.FallbackReviewers, func(id int) bool {
+		return id == reviewerID
+	})
+	pr.Reviews = slices.DeleteFunc(pr.Reviews, func(r domain.Review) bool {
+		return r.ReviewerID == reviewerID
+	})
+
+	now := time.Now()
+	events := []domain.AssignmentEvent{
+		domain.NewReplacementEvent(pr.ID, reviewerID, newReviewer.ID, reason, domain.ActorUserID(ctx), now),
+	}
+	hooks := []domain.WebhookEvent{domain.NewReassignedWebhookEvent(pr, reviewerID, newReviewer.ID, now)}
+
+	err = uc.repo.UpdateAssignedReviewers(ctx, pr.ID, reviewerID, newReviewer.ID, events, hooks, moves)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{
+			"err": err.Error(), "prID": pr.ID, "old_reviewer": reviewerID, "new_reviewer": newReviewer.ID}).
+			Error("PR usecase: failed to update assigned reviewers")
+		return nil, 0, fmt.Errorf("failed to update assigned reviewers: %w", err)
+	}
+	metrics.ReviewerReassigned(sel.TeamName, string(reason))
+
+	return pr, newReviewer.ID, nil
+}
+
+// AddReviewer назначает PullRequest prID дополнительного ревьювера из команды автора
+// сверх max_reviewers; reason записывается в журнал назначений
+func (uc *PullRequestUsecase) AddReviewer(ctx context.Context, prID int, reason domain.AssignmentReason) (*domain.PullRequest, int, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.AddReviewer")
+	defer span.End()
+
+	var (
+		pr            *domain.PullRequest
+		newReviewerID int
+	)
+	err := domain.RetryOnRoundRobinMoved(func() error {
+		var err error
+		pr, newReviewerID, err = uc.addReviewer(ctx, prID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return pr, newReviewerID, nil
+}
+
+// addReviewer выполняет одну попытку AddReviewer
+func (uc *PullRequestUsecase) addReviewer(ctx context.Context, prID int, reason domain.AssignmentReason) (*domain.PullRequest, int, error) {
+	pr, err := uc.getChangeablePullRequest(ctx, prID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var moves domain.RoundRobinMoves
+	_, newReviewer, err := uc.pickReplacement(ctx, pr, nil, &moves, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events := []domain.AssignmentEvent{
+		domain.NewAddedEvent(pr.ID, newReviewer.ID, reason, domain.ActorUserID(ctx), time.Now()),
+	}
+	if err := uc.repo.AddReviewer(ctx, pr.ID, newReviewer.ID, events, moves); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID, "new_reviewer": newReviewer.ID}).
+			Error("PR usecase: failed to add reviewer")
+		return nil, 0, fmt.Errorf("failed to add reviewer: %w", err)
+	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, newReviewer.ID)
+
+	return pr, newReviewer.ID, nil
+}
+
+// getChangeablePullRequest возвращает PullRequest, ревьюверов которого можно менять:
+// существующий, не MERGED и не CLOSED
+func (uc *PullRequestUsecase) getChangeablePullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	pr, err := uc.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
 	if pr.Status == domain.PRStatusMerged {
-		return nil, 0, domain.ErrPullRequestIsMerged
+		return nil, domain.ErrPullRequestIsMerged
+	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, domain.ErrPullRequestIsClosed
 	}
+	return pr, nil
+}
 
-	idx := slices.IndexFunc(pr.AssignedReviewers, func(id int) bool {
-		return id == reas.UserID
+// pickReviewers выбирает ревьюверов для pr по настройкам команды автора
+// и записывает их в pr; сдвиг очереди ROUND_ROBIN добавляется в moves
+func (uc *PullRequestUsecase) pickReviewers(
+	ctx context.Context, pr *domain.PullRequest, moves *domain.RoundRobinMoves,
+) (*domain.ReviewerSelection, error) {
+	sel, err := uc.getReviewerSelection(ctx, pr.AuthorID, moves)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMembers, err := uc.getCandidates(ctx, sel, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := uc.newSelector(sel).Select(teamMembers, sel.MaxReviewers)
+
+	fallbackReviewers, err := uc.selectFallbackReviewers(ctx, sel, pr.AuthorID, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reviewers)+len(fallbackReviewers) < sel.MinReviewers {
+		return nil, domain.ErrNotEnoughReviewers
+	}
+
+	pr.AssignedReviewers = make([]int, 0, len(reviewers)+len(fallbackReviewers))
+	pr.FallbackReviewers = nil
+	for _, r := range reviewers {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, r.ID)
+	}
+	for _, r := range fallbackReviewers {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, r.ID)
+		pr.FallbackReviewers = append(pr.FallbackReviewers, r.ID)
+	}
+
+	moves.Advance(sel, reviewers)
+	return sel, nil
+}
+
+// openWithReviewers назначает ревьюверов и сохраняет pr с новым статусом;
+// в журнал пишутся events и события назначения ревьюверов
+func (uc *PullRequestUsecase) openWithReviewers(
+	ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent,
+) (*domain.PullRequest, error) {
+	var updatedPR *domain.PullRequest
+	err := domain.RetryOnRoundRobinMoved(func() error {
+		var moves domain.RoundRobinMoves
+		if _, err := uc.pickReviewers(ctx, pr, &moves); err != nil {
+			return err
+		}
+
+		assigned := domain.NewAssignedEvents(pr.ID, pr.AssignedReviewers, domain.ActorUserID(ctx), time.Now())
+
+		var err error
+		updatedPR, err = uc.repo.AssignReviewers(ctx, pr, append(slices.Clip(events), assigned...), moves)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID}).Error("PR usecase: failed to assign reviewers")
+			return fmt.Errorf("failed to assign reviewers: %w", err)
+		}
+		return nil
 	})
-	if idx == -1 {
-		return nil, 0, domain.ErrNotAssigned
+	if err != nil {
+		return nil, err
 	}
 
-	candidates, err := uc.repo.GetActiveTeamMembersExceptAuthor(ctx, pr.AuthorID)
+	return updatedPR, nil
+}
+
+// getPullRequest возвращает существующий PullRequest или ErrPullRequestNotFound
+func (uc *PullRequestUsecase) getPullRequest(ctx context.Context, prID int) (*domain.PullRequest, error) {
+	prExists, err := uc.checkPRIDExists(ctx, prID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "authorID": pr.AuthorID}).Error("PR usecase: failed to get active members")
-		return nil, 0, fmt.Errorf("failed to get team members: %w", err)
+		return nil, fmt.Errorf("failed to check PR existance: %w", err)
 	}
+	if !prExists {
+		return nil, domain.ErrPullRequestNotFound
+	}
+
+	pr, err := uc.repo.GetById(ctx, prID)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": prID}).Error("PR usecase: failed to get pull_request by id")
+		return nil, fmt.Errorf("failed to get PR by id: %w", err)
+	}
+	return pr, nil
+}
+
+func (uc *PullRequestUsecase) updateStatus(
+	ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+) (*domain.PullRequest, error) {
+	updatedPR, err := uc.repo.UpdateStatus(ctx, pr, events, hooks)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": pr.ID, "status": pr.Status}).Error("PR usecase: failed to update status")
+		return nil, fmt.Errorf("failed to update PR status: %w", err)
+	}
+	return updatedPR, nil
+}
+
+// PickReplacement выбирает замену ревьюверу pr из активных участников команды автора,
+// не назначенных на pr и не входящих в exclude. Очередь ROUND_ROBIN не сохраняется:
+// сдвиг добавляется в moves, и вызывающий сохраняет его в транзакции назначения.
+// load - ревью, выбранные раньше в той же операции; новый выбор добавляется в него
+func (uc *PullRequestUsecase) PickReplacement(
+	ctx context.Context, pr *domain.PullRequest, exclude []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+) (int, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestUsecase.PickReplacement")
+	defer span.End()
+
+	_, newReviewer, err := uc.pickReplacement(ctx, pr, exclude, moves, load)
+	if err != nil {
+		return 0, err
+	}
+	return newReviewer.ID, nil
+}
+
+func (uc *PullRequestUsecase) pickReplacement(
+	ctx context.Context, pr *domain.PullRequest, exclude []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+) (*domain.ReviewerSelection, *domain.User, error) {
+	sel, err := uc.getReviewerSelection(ctx, pr.AuthorID, moves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates, err := uc.getCandidates(ctx, sel, pr.AuthorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	load.Apply(candidates)
 
 	filteredCandidates := make([]domain.User, 0)
 	for _, u := range candidates {
-		if !slices.Contains(pr.AssignedReviewers, u.ID) {
+		if !slices.Contains(pr.AssignedReviewers, u.ID) && !slices.Contains(exclude, u.ID) {
 			filteredCandidates = append(filteredCandidates, u)
 		}
 	}
 
 	if len(filteredCandidates) == 0 {
-		return nil, 0, domain.ErrNoAvailableCandidats
+		metrics.NoCandidate(sel.TeamName)
+		return nil, nil, domain.ErrNoAvailableCandidats
 	}
 
-	newReviewer := filteredCandidates[rand.Intn(len(filteredCandidates))]
+	newReviewer := uc.newSelector(sel).Select(filteredCandidates, 1)[0]
+	moves.Advance(sel, []domain.User{newReviewer})
+	load.Add(newReviewer.ID)
+	return sel, &newReviewer, nil
+}
 
-	pr.AssignedReviewers[idx] = newReviewer.ID
+// getReviewerSelection возвращает настройки команды автора с позицией ROUND_ROBIN,
+// учитывающей уже выбранные в moves ревьюверов
+func (uc *PullRequestUsecase) getReviewerSelection(
+	ctx context.Context, authorID int, moves *domain.RoundRobinMoves,
+) (*domain.ReviewerSelection, error) {
+	sel, err := uc.repo.GetReviewerSelection(ctx, authorID)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "authorID": authorID}).Error("PR usecase: failed to get reviewer selection")
+		return nil, fmt.Errorf("failed to get reviewer selection: %w", err)
+	}
+	moves.Apply(sel)
+	return sel, nil
+}
 
-	err = uc.repo.UpdateAssignedReviewers(ctx, pr.ID, reas.UserID, newReviewer.ID)
+// getCandidates возвращает активных участников команды автора;
+// для LEAST_LOADED - вместе с их текущей нагрузкой
+func (uc *PullRequestUsecase) getCandidates(ctx context.Context, sel *domain.ReviewerSelection, authorID int) ([]domain.User, error) {
+	var (
+		candidates []domain.User
+		err        error
+	)
+	if sel.Strategy == domain.StrategyLeastLoaded {
+		candidates, err = uc.repo.GetActiveTeamMembersWithLoad(ctx, authorID)
+	} else {
+		candidates, err = uc.repo.GetActiveTeamMembersExceptAuthor(ctx, authorID)
+	}
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{
-			"err": err.Error(), "prID": pr.ID, "old_reviewer": reas.UserID, "new_reviewer": newReviewer.ID}).
-			Error("PR usecase: failed to update assigned reviewers")
-		return nil, 0, fmt.Errorf("failed to update assigned reviewers: %w", err)
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "authorID": authorID}).Error("PR usecase: failed to get active members")
+		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
+	return candidates, nil
+}
 
-	return pr, newReviewer.ID, nil
+// selectFallbackReviewers добирает недостающих до MaxReviewers ревьюверов
+// из резервных команд по порядку их приоритета
+func (uc *PullRequestUsecase) selectFallbackReviewers(
+	ctx context.Context, sel *domain.ReviewerSelection, authorID int, selected []domain.User,
+) ([]domain.User, error) {
+	missing := sel.MaxReviewers - len(selected)
+	if missing <= 0 || len(sel.FallbackTeamIDs) == 0 {
+		return nil, nil
+	}
+
+	taken := []int{authorID}
+	for _, u := range selected {
+		taken = append(taken, u.ID)
+	}
+
+	// ROUND_ROBIN ведется только по своей команде
+	selector := uc.newSelector(sel)
+	if sel.Strategy == domain.StrategyRoundRobin {
+		selector = NewRandomSelector(uc.intn)
+	}
+
+	fallback := make([]domain.User, 0, missing)
+	for _, teamID := range sel.FallbackTeamIDs {
+		members, err := uc.repo.GetActiveMembersByTeamID(ctx, teamID)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "teamID": teamID}).Error("PR usecase: failed to get fallback team members")
+			return nil, fmt.Errorf("failed to get fallback team members: %w", err)
+		}
+
+		members = slices.DeleteFunc(members, func(u domain.User) bool {
+			return slices.Contains(taken, u.ID)
+		})
+
+		for _, u := range selector.Select(members, missing-len(fallback)) {
+			fallback = append(fallback, u)
+			taken = append(taken, u.ID)
+		}
+
+		if len(fallback) == missing {
+			break
+		}
+	}
+
+	return fallback, nil
+}
+
+func (uc *PullRequestUsecase) newSelector(sel *domain.ReviewerSelection) ReviewerSelector {
+	switch sel.Strategy {
+	case domain.StrategyLeastLoaded:
+		return NewLeastLoadedSelector(uc.intn)
+	case domain.StrategyRoundRobin:
+		return NewRoundRobinSelector(sel.LastReviewerID)
+	case domain.StrategyWeighted:
+		return NewWeightedSelector(uc.intn)
+	default:
+		return NewRandomSelector(uc.intn)
+	}
+}
+
+func (uc *PullRequestUsecase) intn(n int) int {
+	if uc.randIntn == nil {
+		return rand.Intn(n)
+	}
+	return uc.randIntn(n)
 }
 
 func (uc *PullRequestUsecase) checkCreatePRConditions(ctx context.Context, uid int, prid int) error {
 	authorExists, err := uc.userRepo.ExistsById(ctx, uid)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": uid}).Error("PR usecase: failed to check user existence")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": uid}).Error("PR usecase: failed to check user existence")
 		return fmt.Errorf("failed to check author existence: %w", err)
 	}
 	if !authorExists {
@@ -186,8 +738,13 @@ func (uc *PullRequestUsecase) checkCreatePRConditions(ctx context.Context, uid i
 func (uc *PullRequestUsecase) checkPRIDExists(ctx context.Context, id int) (bool, error) {
 	exists, err := uc.repo.ExistsById(ctx, id)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "prID": id}).Error("PR usecase: failed to check pr existance")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "prID": id}).Error("PR usecase: failed to check pr existance")
 		return false, fmt.Errorf("failed to check pull_request existance: %w", err)
 	}
 	return exists, nil
 }
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *PullRequestUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
@@ -14,6 +14,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func defaultSelection() *domain.ReviewerSelection {
+	return &domain.ReviewerSelection{
+		Strategy:     domain.StrategyRandom,
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
+	}
+}
+
 func TestCreatePullRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -39,10 +47,25 @@ func TestCreatePullRequest(t *testing.T) {
 
 		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
 		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(defaultSelection(), nil)
 		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
 
-		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				// На каждого назначенного ревьювера событие ASSIGNED от автора
+				assert.Len(t, events, len(pr.AssignedReviewers))
+				for i, e := range events {
+					assert.Equal(t, domain.ReasonAssigned, e.Reason)
+					assert.Equal(t, cr.AuthorId, *e.ActorID)
+					assert.Equal(t, pr.AssignedReviewers[i], *e.NewReviewerID)
+					assert.Nil(t, e.OldReviewerID)
+				}
+				assert.Len(t, hooks, 1)
+				assert.Equal(t, domain.WebhookPRCreated, hooks[0].Type)
+				assert.Equal(t, cr.AuthorId, hooks[0].UserID)
 				return pr, nil
 			},
 		)
@@ -63,7 +86,33 @@ func TestCreatePullRequest(t *testing.T) {
 		}
 
 		// Проверяем, что ревьюверов было назначенр =< 2
-		assert.LessOrEqual(t, len(pr.AssignedReviewers), domain.MaxReviewersNumber)
+		assert.LessOrEqual(t, len(pr.AssignedReviewers), domain.DefaultMaxReviewers)
+	})
+
+	t.Run("external PR gets id from sequence", func(t *testing.T) {
+		external := &domain.ExternalPullRequestRef{Provider: domain.ProviderGitHub, Repository: "acme/api", Number: 42}
+		ext := &domain.CreatePullRequest{Name: "Add search", AuthorId: 10, External: external}
+
+		repo.EXPECT().NextPullRequestID(ctx).Return(2001, nil)
+		userRepo.EXPECT().ExistsById(ctx, ext.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, 2001).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return([]domain.User{{ID: 11}}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				assert.Equal(t, 2001, pr.ID)
+				assert.Equal(t, external, pr.External)
+				assert.Equal(t, 2001, events[0].PullRequestID)
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.CreatePullRequest(ctx, ext)
+		assert.NoError(t, err)
+		assert.Equal(t, 2001, pr.ID)
 	})
 
 	t.Run("PR created, but without reviewers", func(t *testing.T) {
@@ -72,10 +121,14 @@ func TestCreatePullRequest(t *testing.T) {
 
 		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
 		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(defaultSelection(), nil)
 		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
 
-		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
 				return pr, nil
 			},
 		)
@@ -87,6 +140,65 @@ func TestCreatePullRequest(t *testing.T) {
 		assert.Equal(t, len(pr.AssignedReviewers), 0)
 	})
 
+	t.Run("draft PR created without reviewers", func(t *testing.T) {
+		draft := &domain.CreatePullRequest{PullRequestId: 1001, Name: "Test PR", AuthorId: 10, Draft: true}
+
+		userRepo.EXPECT().ExistsById(ctx, draft.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, draft.PullRequestId).Return(false, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.CreatePullRequest(ctx, draft)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusDraft, pr.Status)
+		assert.Empty(t, pr.AssignedReviewers)
+	})
+
+	t.Run("team settings: three reviewers", func(t *testing.T) {
+		members := []domain.User{{ID: 11}, {ID: 12}, {ID: 13}, {ID: 14}}
+		sel := &domain.ReviewerSelection{Strategy: domain.StrategyRandom, MinReviewers: 3, MaxReviewers: 3}
+
+		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 3)
+	})
+
+	t.Run("team settings: not enough reviewers", func(t *testing.T) {
+		members := []domain.User{{ID: 11}}
+		sel := &domain.ReviewerSelection{Strategy: domain.StrategyRandom, MinReviewers: 2, MaxReviewers: 3}
+
+		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrNotEnoughReviewers, err)
+	})
+
 }
 
 func TestCheckCreatePRConditions(t *testing.T) {
@@ -171,7 +283,8 @@ func TestMergePullRequest(t *testing.T) {
 
 		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
 		repo.EXPECT().GetById(ctx, prID).Return(prToMerge, nil)
-		repo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(nil, fmt.Errorf("update failed"))
+		repo.EXPECT().GetMergePolicy(ctx, 0).Return(&domain.MergePolicy{}, nil)
+		repo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("update failed"))
 
 		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
 		logger.EXPECT().Error("PR usecase: failed to update status")
@@ -181,6 +294,71 @@ func TestMergePullRequest(t *testing.T) {
 		assert.ErrorContains(t, err, "update failed")
 	})
 
+	t.Run("draft and closed PR cannot be merged", func(t *testing.T) {
+		for _, status := range []domain.PullRequestStatus{domain.PRStatusDraft, domain.PRStatusClosed} {
+			repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+			repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: status}, nil)
+
+			pr, err := uc.MergePullRequest(ctx, prID)
+			assert.Nil(t, pr)
+			assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		}
+	})
+
+	t.Run("not approved by team policy", func(t *testing.T) {
+		prToMerge := &domain.PullRequest{
+			ID:                prID,
+			AuthorID:          10,
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []int{11, 12},
+			Reviews: []domain.Review{
+				{ReviewerID: 11, Verdict: domain.VerdictApproved},
+				{ReviewerID: 12, Verdict: domain.VerdictChangesRequested},
+			},
+		}
+		policy := &domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: true}
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(prToMerge, nil)
+		repo.EXPECT().GetMergePolicy(ctx, 10).Return(policy, nil)
+
+		pr, err := uc.MergePullRequest(ctx, prID)
+		assert.Nil(t, pr)
+		assert.ErrorIs(t, err, domain.ErrNotApproved)
+
+		var notApproved *domain.NotApprovedError
+		assert.ErrorAs(t, err, &notApproved)
+		assert.Equal(t, &domain.NotApprovedError{
+			RequiredApprovals:  2,
+			Approvals:          1,
+			MissingApprovals:   []int{12},
+			ChangesRequestedBy: []int{12},
+		}, notApproved)
+	})
+
+	t.Run("approved by team policy", func(t *testing.T) {
+		prToMerge := &domain.PullRequest{
+			ID:                prID,
+			AuthorID:          10,
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []int{11, 12},
+			Reviews:           []domain.Review{{ReviewerID: 11, Verdict: domain.VerdictApproved}},
+		}
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(prToMerge, nil)
+		repo.EXPECT().GetMergePolicy(ctx, 10).Return(&domain.MergePolicy{RequiredApprovals: 1}, nil)
+		repo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.MergePullRequest(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	})
+
 	t.Run("successfully merged", func(t *testing.T) {
 		prToMerge := &domain.PullRequest{
 			ID:     prID,
@@ -189,8 +367,13 @@ func TestMergePullRequest(t *testing.T) {
 
 		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
 		repo.EXPECT().GetById(ctx, prID).Return(prToMerge, nil)
-		repo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(
-			func(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+		repo.EXPECT().GetMergePolicy(ctx, 0).Return(&domain.MergePolicy{}, nil)
+		repo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent) (*domain.PullRequest, error) {
+				assert.Len(t, events, 1)
+				assert.Equal(t, domain.ReasonMerged, events[0].Reason)
+				assert.Len(t, hooks, 1)
+				assert.Equal(t, domain.WebhookPRMerged, hooks[0].Type)
 				pr.MergedAt = &now
 				return pr, nil
 			},
@@ -201,6 +384,29 @@ func TestMergePullRequest(t *testing.T) {
 		assert.Equal(t, domain.PRStatusMerged, pr.Status)
 		assert.NotNil(t, pr.MergedAt)
 	})
+
+	t.Run("actor recorded from token", func(t *testing.T) {
+		actorID := 7
+		actorCtx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleAdmin, UserID: &actorID})
+		prToMerge := &domain.PullRequest{
+			ID:     prID,
+			Status: domain.PRStatusOpen,
+		}
+
+		repo.EXPECT().ExistsById(actorCtx, prID).Return(true, nil)
+		repo.EXPECT().GetById(actorCtx, prID).Return(prToMerge, nil)
+		repo.EXPECT().GetMergePolicy(actorCtx, 0).Return(&domain.MergePolicy{}, nil)
+		repo.EXPECT().UpdateStatus(actorCtx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent) (*domain.PullRequest, error) {
+				assert.Len(t, events, 1)
+				assert.Equal(t, &actorID, events[0].ActorID)
+				return pr, nil
+			},
+		)
+
+		_, err := uc.MergePullRequest(actorCtx, prID)
+		assert.NoError(t, err)
+	})
 }
 
 func TestReassignReviewer(t *testing.T) {
@@ -255,6 +461,18 @@ func TestReassignReviewer(t *testing.T) {
 		assert.Equal(t, domain.ErrPullRequestIsMerged, err)
 	})
 
+	t.Run("PR closed", func(t *testing.T) {
+		closedPR := &domain.PullRequest{ID: prID, Status: domain.PRStatusClosed, AssignedReviewers: []int{oldReviewer}}
+		userRepo.EXPECT().ExistsById(ctx, oldReviewer).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(closedPR, nil)
+
+		prResult, newID, err := uc.ReassignReviewer(ctx, &domain.ReassingReviewer{UserID: oldReviewer, PullRequestID: prID})
+		assert.Nil(t, prResult)
+		assert.Equal(t, 0, newID)
+		assert.Equal(t, domain.ErrPullRequestIsClosed, err)
+	})
+
 	t.Run("user not assigned", func(t *testing.T) {
 		userRepo.EXPECT().ExistsById(ctx, 99).Return(true, nil)
 		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
@@ -270,6 +488,7 @@ func TestReassignReviewer(t *testing.T) {
 		userRepo.EXPECT().ExistsById(ctx, oldReviewer).Return(true, nil)
 		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
 		repo.EXPECT().GetById(ctx, prID).Return(pr, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, pr.AuthorID).Return(defaultSelection(), nil)
 		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, pr.AuthorID).Return([]domain.User{
 			{ID: oldReviewer},
 			{ID: 11},
@@ -285,10 +504,29 @@ func TestReassignReviewer(t *testing.T) {
 		userRepo.EXPECT().ExistsById(ctx, oldReviewer).Return(true, nil)
 		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
 		repo.EXPECT().GetById(ctx, prID).Return(pr, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, pr.AuthorID).Return(defaultSelection(), nil)
 		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, pr.AuthorID).Return([]domain.User{
 			{ID: 12}, {ID: 13}, {ID: 14},
 		}, nil)
-		repo.EXPECT().UpdateAssignedReviewers(ctx, prID, oldReviewer, gomock.Any()).Return(nil)
+		repo.EXPECT().UpdateAssignedReviewers(ctx, prID, oldReviewer, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, prID, oldID, newID int, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) error {
+				assert.Len(t, events, 1)
+				assert.Equal(t, domain.ReasonReassigned, events[0].Reason)
+				assert.Equal(t, oldID, *events[0].OldReviewerID)
+				assert.Equal(t, newID, *events[0].NewReviewerID)
+				assert.Len(t, hooks, 1)
+				assert.Equal(t, domain.WebhookPRReassigned, hooks[0].Type)
+				assert.Equal(t, domain.ReassignedPayload{
+					PullRequest: domain.DomainPRToAPI(pr),
+					OldUserID:   fmt.Sprintf("u%d", oldID),
+					ReplacedBy:  fmt.Sprintf("u%d", newID),
+				}, hooks[0].Data)
+				return nil
+			},
+		)
 
 		prResult, newID, err := uc.ReassignReviewer(ctx, &domain.ReassingReviewer{UserID: oldReviewer, PullRequestID: prID})
 		assert.NoError(t, err)
@@ -297,3 +535,704 @@ func TestReassignReviewer(t *testing.T) {
 		assert.Contains(t, prResult.AssignedReviewers, newID)
 	})
 }
+
+func TestEscalationReviewerChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	prID := 1
+	newPR := func() *domain.PullRequest {
+		return &domain.PullRequest{ID: prID, AuthorID: 1, Status: domain.PRStatusOpen, AssignedReviewers: []int{10, 11}}
+	}
+	expectPick := func(pr *domain.PullRequest, members []domain.User) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(pr, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, pr.AuthorID).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, pr.AuthorID).Return(members, nil)
+	}
+
+	t.Run("add reviewer", func(t *testing.T) {
+		expectPick(newPR(), []domain.User{{ID: 10}, {ID: 11}, {ID: 12}})
+		repo.EXPECT().AddReviewer(ctx, prID, 12, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, prID, reviewerID int, events []domain.AssignmentEvent, moves domain.RoundRobinMoves) error {
+				assert.Equal(t, []domain.AssignmentEvent{
+					domain.NewAddedEvent(prID, 12, domain.ReasonEscalated, nil, events[0].CreatedAt),
+				}, events)
+				return nil
+			},
+		)
+
+		pr, newID, err := uc.AddReviewer(ctx, prID, domain.ReasonEscalated)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, newID)
+		assert.Equal(t, []int{10, 11, 12}, pr.AssignedReviewers)
+	})
+
+	t.Run("add reviewer retried when round robin moved", func(t *testing.T) {
+		expectPick(newPR(), []domain.User{{ID: 10}, {ID: 11}, {ID: 12}})
+		repo.EXPECT().AddReviewer(ctx, prID, 12, gomock.Any(), gomock.Any()).Return(domain.ErrRoundRobinMoved)
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("PR usecase: failed to add reviewer")
+
+		expectPick(newPR(), []domain.User{{ID: 10}, {ID: 11}, {ID: 12}})
+		repo.EXPECT().AddReviewer(ctx, prID, 12, gomock.Any(), gomock.Any()).Return(nil)
+
+		pr, newID, err := uc.AddReviewer(ctx, prID, domain.ReasonEscalated)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, newID)
+		assert.Equal(t, []int{10, 11, 12}, pr.AssignedReviewers)
+	})
+
+	t.Run("add reviewer without candidates", func(t *testing.T) {
+		expectPick(newPR(), []domain.User{{ID: 10}, {ID: 11}})
+
+		pr, newID, err := uc.AddReviewer(ctx, prID, domain.ReasonEscalated)
+		assert.Nil(t, pr)
+		assert.Equal(t, 0, newID)
+		assert.Equal(t, domain.ErrNoAvailableCandidats, err)
+	})
+
+	t.Run("add reviewer to merged pr", func(t *testing.T) {
+		merged := newPR()
+		merged.Status = domain.PRStatusMerged
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(merged, nil)
+
+		_, _, err := uc.AddReviewer(ctx, prID, domain.ReasonEscalated)
+		assert.Equal(t, domain.ErrPullRequestIsMerged, err)
+	})
+
+	t.Run("replace reviewer with reason", func(t *testing.T) {
+		expectPick(newPR(), []domain.User{{ID: 10}, {ID: 11}, {ID: 12}})
+		repo.EXPECT().UpdateAssignedReviewers(ctx, prID, 10, 12, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, prID, oldID, newID int, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) error {
+				assert.Len(t, events, 1)
+				assert.Equal(t, domain.ReasonEscalated, events[0].Reason)
+				assert.Len(t, hooks, 1)
+				return nil
+			},
+		)
+
+		pr, newID, err := uc.ReplaceReviewer(ctx, prID, 10, domain.ReasonEscalated)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, newID)
+		assert.Equal(t, []int{12, 11}, pr.AssignedReviewers)
+	})
+}
+
+func TestCreatePullRequestStrategies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	ctx := context.Background()
+	cr := &domain.CreatePullRequest{PullRequestId: 1001, Name: "Test PR", AuthorId: 10}
+	members := []domain.User{{ID: 11}, {ID: 12}, {ID: 13}}
+
+	expectCreate := func(sel *domain.ReviewerSelection, moves gomock.Matcher) {
+		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), moves).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+	}
+
+	t.Run("least loaded", func(t *testing.T) {
+		expectCreate(&domain.ReviewerSelection{TeamID: 1, Strategy: domain.StrategyLeastLoaded, MaxReviewers: 2}, gomock.Any())
+		repo.EXPECT().GetActiveTeamMembersWithLoad(ctx, 10).Return([]domain.User{
+			{ID: 11, OpenReviews: 3}, {ID: 12, OpenReviews: 1}, {ID: 13},
+		}, nil)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{13, 12}, pr.AssignedReviewers)
+	})
+
+	t.Run("round robin remembers last reviewer", func(t *testing.T) {
+		expectCreate(&domain.ReviewerSelection{TeamID: 1, Strategy: domain.StrategyRoundRobin, LastReviewerID: 12, MaxReviewers: 2},
+			gomock.Eq(domain.RoundRobinMoves{{TeamID: 1, From: 12, To: 11}}))
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{13, 11}, pr.AssignedReviewers)
+	})
+
+	t.Run("get members with load error", func(t *testing.T) {
+		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(&domain.ReviewerSelection{Strategy: domain.StrategyLeastLoaded}, nil)
+		repo.EXPECT().GetActiveTeamMembersWithLoad(ctx, 10).Return(nil, fmt.Errorf("db down"))
+
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("PR usecase: failed to get active members")
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.Nil(t, pr)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestCreatePullRequestFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	ctx := context.Background()
+	cr := &domain.CreatePullRequest{PullRequestId: 1001, Name: "Test PR", AuthorId: 10}
+
+	expectStart := func(sel *domain.ReviewerSelection, members []domain.User) {
+		userRepo.EXPECT().ExistsById(ctx, cr.AuthorId).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, cr.PullRequestId).Return(false, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return(members, nil)
+	}
+
+	t.Run("missing slots are filled from fallback teams in order", func(t *testing.T) {
+		sel := &domain.ReviewerSelection{
+			Strategy: domain.StrategyRandom, MinReviewers: 3, MaxReviewers: 3, FallbackTeamIDs: []int{2, 3},
+		}
+		expectStart(sel, []domain.User{{ID: 11}})
+		// u10 - автор, состоит в резервной команде по ошибке конфигурации
+		repo.EXPECT().GetActiveMembersByTeamID(ctx, 2).Return([]domain.User{{ID: 21}, {ID: 10}}, nil)
+		repo.EXPECT().GetActiveMembersByTeamID(ctx, 3).Return([]domain.User{{ID: 31}}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{11, 21, 31}, pr.AssignedReviewers)
+		assert.Equal(t, []int{21, 31}, pr.FallbackReviewers)
+	})
+
+	t.Run("first fallback team is enough", func(t *testing.T) {
+		sel := &domain.ReviewerSelection{
+			Strategy: domain.StrategyRandom, MaxReviewers: 2, FallbackTeamIDs: []int{2, 3},
+		}
+		expectStart(sel, []domain.User{})
+		repo.EXPECT().GetActiveMembersByTeamID(ctx, 2).Return([]domain.User{{ID: 21}, {ID: 22}}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+				moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []int{21, 22}, pr.FallbackReviewers)
+	})
+
+	t.Run("fallback is not enough for min reviewers", func(t *testing.T) {
+		sel := &domain.ReviewerSelection{
+			Strategy: domain.StrategyRandom, MinReviewers: 2, MaxReviewers: 2, FallbackTeamIDs: []int{2},
+		}
+		expectStart(sel, []domain.User{})
+		repo.EXPECT().GetActiveMembersByTeamID(ctx, 2).Return([]domain.User{{ID: 21}}, nil)
+
+		pr, err := uc.CreatePullRequest(ctx, cr)
+
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrNotEnoughReviewers, err)
+	})
+}
+
+func TestClosePullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	prID := 1
+	ctx := context.Background()
+
+	t.Run("PR does not exist", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(false, nil)
+
+		pr, err := uc.ClosePullRequest(ctx, prID)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrPullRequestNotFound, err)
+	})
+
+	t.Run("open and draft PR are closed", func(t *testing.T) {
+		for _, status := range []domain.PullRequestStatus{domain.PRStatusOpen, domain.PRStatusDraft} {
+			repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+			repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: status}, nil)
+			repo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent) (*domain.PullRequest, error) {
+					return pr, nil
+				},
+			)
+
+			pr, err := uc.ClosePullRequest(ctx, prID)
+			assert.NoError(t, err)
+			assert.Equal(t, domain.PRStatusClosed, pr.Status)
+			assert.NotNil(t, pr.ClosedAt)
+		}
+	})
+
+	t.Run("PR already closed", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusClosed}, nil)
+
+		pr, err := uc.ClosePullRequest(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, pr.Status)
+	})
+
+	t.Run("merged PR cannot be closed", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusMerged}, nil)
+
+		pr, err := uc.ClosePullRequest(ctx, prID)
+		assert.Nil(t, pr)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+}
+
+func TestMarkReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	prID := 1
+	authorID := 10
+	ctx := context.Background()
+
+	t.Run("draft becomes open with reviewers", func(t *testing.T) {
+		draft := &domain.PullRequest{ID: prID, AuthorID: authorID, Status: domain.PRStatusDraft}
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(draft, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, authorID).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, authorID).Return([]domain.User{{ID: 11}, {ID: 12}}, nil)
+		repo.EXPECT().AssignReviewers(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.MarkReady(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.ElementsMatch(t, []int{11, 12}, pr.AssignedReviewers)
+	})
+
+	t.Run("open PR cannot be marked ready", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusOpen}, nil)
+
+		pr, err := uc.MarkReady(ctx, prID)
+		assert.Nil(t, pr)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("not enough reviewers keeps draft", func(t *testing.T) {
+		sel := defaultSelection()
+		sel.MinReviewers = 1
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, AuthorID: authorID, Status: domain.PRStatusDraft}, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, authorID).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, authorID).Return([]domain.User{}, nil)
+
+		pr, err := uc.MarkReady(ctx, prID)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrNotEnoughReviewers, err)
+	})
+}
+
+func TestReopenPullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	prID := 1
+	authorID := 10
+	ctx := context.Background()
+	closedAt := time.Now()
+
+	t.Run("reviewers are kept", func(t *testing.T) {
+		closed := &domain.PullRequest{ID: prID, AuthorID: authorID, Status: domain.PRStatusClosed, ClosedAt: &closedAt, AssignedReviewers: []int{11}}
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(closed, nil)
+		repo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, hooks []domain.WebhookEvent) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.ReopenPullRequest(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.Nil(t, pr.ClosedAt)
+		assert.Equal(t, []int{11}, pr.AssignedReviewers)
+	})
+
+	t.Run("closed draft gets reviewers", func(t *testing.T) {
+		closed := &domain.PullRequest{ID: prID, AuthorID: authorID, Status: domain.PRStatusClosed, ClosedAt: &closedAt}
+
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(closed, nil)
+		repo.EXPECT().GetReviewerSelection(ctx, authorID).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, authorID).Return([]domain.User{{ID: 12}}, nil)
+		repo.EXPECT().AssignReviewers(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, pr *domain.PullRequest, events []domain.AssignmentEvent, moves domain.RoundRobinMoves,
+			) (*domain.PullRequest, error) {
+				return pr, nil
+			},
+		)
+
+		pr, err := uc.ReopenPullRequest(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.Equal(t, []int{12}, pr.AssignedReviewers)
+	})
+
+	t.Run("merged PR cannot be reopened", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusMerged}, nil)
+
+		pr, err := uc.ReopenPullRequest(ctx, prID)
+		assert.Nil(t, pr)
+
+		var trErr *domain.TransitionError
+		assert.ErrorAs(t, err, &trErr)
+		assert.Equal(t, domain.PRActionReopen, trErr.Action)
+	})
+}
+
+func TestSubmitReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	prID := 1
+	reviewerID := 2
+	ctx := context.Background()
+	sr := &domain.SubmitReview{PullRequestID: prID, UserID: reviewerID, Verdict: domain.VerdictApproved}
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo.EXPECT().ExistsById(ctx, reviewerID).Return(false, nil)
+
+		pr, err := uc.SubmitReview(ctx, sr)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("PR merged", func(t *testing.T) {
+		userRepo.EXPECT().ExistsById(ctx, reviewerID).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusMerged, AssignedReviewers: []int{reviewerID}}, nil)
+
+		pr, err := uc.SubmitReview(ctx, sr)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrPullRequestIsMerged, err)
+	})
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		userRepo.EXPECT().ExistsById(ctx, reviewerID).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AssignedReviewers: []int{3}}, nil)
+
+		pr, err := uc.SubmitReview(ctx, sr)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrNotAssigned, err)
+	})
+
+	t.Run("latest verdict replaces previous", func(t *testing.T) {
+		existing := &domain.PullRequest{
+			ID: prID, Status: domain.PRStatusOpen, AssignedReviewers: []int{reviewerID, 3},
+			Reviews: []domain.Review{
+				{ReviewerID: reviewerID, Verdict: domain.VerdictChangesRequested},
+				{ReviewerID: 3, Verdict: domain.VerdictCommented},
+			},
+		}
+
+		userRepo.EXPECT().ExistsById(ctx, reviewerID).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(existing, nil)
+		repo.EXPECT().AddReview(ctx, prID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, prID int, review *domain.Review) error {
+				assert.Equal(t, reviewerID, review.ReviewerID)
+				assert.Equal(t, domain.VerdictApproved, review.Verdict)
+				assert.False(t, review.SubmittedAt.IsZero())
+				return nil
+			},
+		)
+
+		pr, err := uc.SubmitReview(ctx, sr)
+		assert.NoError(t, err)
+		assert.Len(t, pr.Reviews, 2)
+		assert.Equal(t, domain.Review{ReviewerID: 3, Verdict: domain.VerdictCommented}, pr.Reviews[0])
+		assert.Equal(t, domain.VerdictApproved, pr.Reviews[1].Verdict)
+	})
+
+	t.Run("add review error", func(t *testing.T) {
+		userRepo.EXPECT().ExistsById(ctx, reviewerID).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(&domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AssignedReviewers: []int{reviewerID}}, nil)
+		repo.EXPECT().AddReview(ctx, prID, gomock.Any()).Return(fmt.Errorf("insert failed"))
+
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("PR usecase: failed to add review")
+
+		pr, err := uc.SubmitReview(ctx, sr)
+		assert.Nil(t, pr)
+		assert.ErrorContains(t, err, "insert failed")
+	})
+}
+
+func TestPickReplacement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+	userRepo := mocksUserRepo.NewMockUserRepo(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger, userRepo: userRepo}
+
+	ctx := context.Background()
+	pr := &domain.PullRequest{ID: 1, AuthorID: 10, AssignedReviewers: []int{11, 12}}
+
+	t.Run("excluded and assigned users are skipped", func(t *testing.T) {
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return([]domain.User{
+			{ID: 11}, {ID: 12}, {ID: 13}, {ID: 14},
+		}, nil)
+
+		newID, err := uc.PickReplacement(ctx, pr, []int{13}, &domain.RoundRobinMoves{}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 14, newID)
+	})
+
+	t.Run("only excluded candidates left", func(t *testing.T) {
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(defaultSelection(), nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return([]domain.User{{ID: 11}, {ID: 13}}, nil)
+
+		newID, err := uc.PickReplacement(ctx, pr, []int{13}, &domain.RoundRobinMoves{}, nil)
+		assert.Equal(t, 0, newID)
+		assert.Equal(t, domain.ErrNoAvailableCandidats, err)
+	})
+
+	t.Run("round robin position is remembered", func(t *testing.T) {
+		sel := defaultSelection()
+		sel.TeamID = 3
+		sel.Strategy = domain.StrategyRoundRobin
+
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return([]domain.User{{ID: 15}, {ID: 16}}, nil)
+
+		moves := domain.RoundRobinMoves{}
+		newID, err := uc.PickReplacement(ctx, pr, nil, &moves, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 15, newID)
+		assert.Equal(t, domain.RoundRobinMoves{{TeamID: 3, To: 15}}, moves)
+
+		// Следующий выбор той же операции продолжает очередь от 15 без записи в базу
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersExceptAuthor(ctx, 10).Return([]domain.User{{ID: 15}, {ID: 16}}, nil)
+
+		newID, err = uc.PickReplacement(ctx, pr, nil, &moves, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, newID)
+		assert.Equal(t, domain.RoundRobinMoves{{TeamID: 3, To: 16}}, moves)
+	})
+
+	t.Run("least loaded counts earlier picks of the operation", func(t *testing.T) {
+		sel := defaultSelection()
+		sel.Strategy = domain.StrategyLeastLoaded
+
+		repo.EXPECT().GetReviewerSelection(ctx, 10).Return(sel, nil)
+		repo.EXPECT().GetActiveTeamMembersWithLoad(ctx, 10).Return([]domain.User{
+			{ID: 15, OpenReviews: 0}, {ID: 16, OpenReviews: 1},
+		}, nil)
+
+		// 15 уже получил две ревью в этой операции и загружен больше 16
+		load := domain.BatchLoad{15: 2}
+		newID, err := uc.PickReplacement(ctx, pr, nil, &domain.RoundRobinMoves{}, load)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, newID)
+		assert.Equal(t, domain.BatchLoad{15: 2, 16: 1}, load)
+	})
+}
+
+func TestGetPullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	prID := 1001
+
+	t.Run("pr not found", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(false, nil)
+
+		pr, err := uc.GetPullRequest(ctx, prID)
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrPullRequestNotFound, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		want := &domain.PullRequest{
+			ID: prID, AuthorID: 1, AuthorTeamName: "backend", AssignedReviewers: []int{2},
+			Reviewers: []domain.User{{ID: 2, Username: "Bob", IsActive: true}},
+		}
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetById(ctx, prID).Return(want, nil)
+
+		pr, err := uc.GetPullRequest(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, want, pr)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	prID := 1001
+
+	t.Run("pr not found", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(false, nil)
+
+		events, err := uc.GetHistory(ctx, prID)
+		assert.Nil(t, events)
+		assert.Equal(t, domain.ErrPullRequestNotFound, err)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetAssignmentEvents(ctx, prID).Return(nil, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("PR usecase: failed to get assignment events")
+
+		events, err := uc.GetHistory(ctx, prID)
+		assert.Nil(t, events)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		history := domain.NewAssignedEvents(prID, []int{2, 3}, nil, time.Now())
+		repo.EXPECT().ExistsById(ctx, prID).Return(true, nil)
+		repo.EXPECT().GetAssignmentEvents(ctx, prID).Return(history, nil)
+
+		events, err := uc.GetHistory(ctx, prID)
+		assert.NoError(t, err)
+		assert.Equal(t, history, events)
+	})
+}
+
+func TestListPullRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocksRepo.NewMockPullRequestRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := PullRequestUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	filter := &domain.PullRequestFilter{Sort: domain.SortCreatedAtDesc, Limit: domain.DefaultPageLimit}
+
+	t.Run("repo error", func(t *testing.T) {
+		repo.EXPECT().List(ctx, filter).Return(nil, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("PR usecase: failed to list pull_requests")
+
+		page, err := uc.ListPullRequests(ctx, filter)
+		assert.Nil(t, page)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		want := &domain.PullRequestPage{PullRequests: []domain.PullRequest{{ID: 1}}}
+		repo.EXPECT().List(ctx, filter).Return(want, nil)
+
+		page, err := uc.ListPullRequests(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, want, page)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		team := "ghosts"
+		teamFilter := &domain.PullRequestFilter{TeamName: &team, Sort: domain.SortCreatedAtDesc, Limit: domain.DefaultPageLimit}
+		repo.EXPECT().ExistsTeamByName(ctx, team).Return(false, nil)
+
+		page, err := uc.ListPullRequests(ctx, teamFilter)
+		assert.Nil(t, page)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+}
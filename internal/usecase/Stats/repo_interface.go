@@ -0,0 +1,14 @@
+package stats
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_stats_repo.go -package=mocks
+
+type StatsRepo interface {
+	ExistsTeamByName(ctx context.Context, name string) (bool, error)
+	ExistsUserById(ctx context.Context, id int) (bool, error)
+	GetStats(ctx context.Context, filter *domain.StatsFilter) (*domain.Stats, error)
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+)
+
+type StatsUsecase struct {
+	repo   StatsRepo
+	logger logger.Logger
+}
+
+func NewStatsUsecase(repo StatsRepo, logger logger.Logger) *StatsUsecase {
+	return &StatsUsecase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetStats возвращает статистику назначений по фильтру;
+// команда и пользователь из фильтра должны существовать
+func (uc *StatsUsecase) GetStats(ctx context.Context, filter *domain.StatsFilter) (*domain.Stats, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidTimeWindow
+	}
+
+	if filter.TeamName != nil {
+		exists, err := uc.repo.ExistsTeamByName(ctx, *filter.TeamName)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": *filter.TeamName}).Error("Stats usecase: check team_name failed")
+			return nil, fmt.Errorf("failed to check team existance: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	if filter.UserID != nil {
+		exists, err := uc.repo.ExistsUserById(ctx, *filter.UserID)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": *filter.UserID}).Error("Stats usecase: check user_id failed")
+			return nil, fmt.Errorf("failed to check user existance: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrUserNotFound
+		}
+	}
+
+	st, err := uc.repo.GetStats(ctx, filter)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error()}).Error("Stats usecase: get stats failed")
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return st, nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *StatsUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
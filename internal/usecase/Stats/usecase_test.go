@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Stats/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsUsecase_GetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockStatsRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewStatsUsecase(repo, logger)
+
+	ctx := context.Background()
+	teamName := "backend"
+	userID := 1
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	stats := &domain.Stats{
+		PullRequests: domain.PullRequestCounts{Total: 3, Open: 2, Merged: 1},
+		Reviewers:    []domain.ReviewerStats{{UserID: 1, Username: "garry", Assignments: 3, OpenReviews: 2}},
+	}
+
+	t.Run("invalid time window", func(t *testing.T) {
+		filter := &domain.StatsFilter{From: &to, To: &from}
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.Nil(t, st)
+		assert.ErrorIs(t, err, domain.ErrInvalidTimeWindow)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		filter := &domain.StatsFilter{TeamName: &teamName}
+		repo.EXPECT().ExistsTeamByName(ctx, teamName).Return(false, nil)
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.Nil(t, st)
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+	})
+
+	t.Run("check team error", func(t *testing.T) {
+		filter := &domain.StatsFilter{TeamName: &teamName}
+		repo.EXPECT().ExistsTeamByName(ctx, teamName).Return(false, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Stats usecase: check team_name failed")
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.Nil(t, st)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		filter := &domain.StatsFilter{UserID: &userID}
+		repo.EXPECT().ExistsUserById(ctx, userID).Return(false, nil)
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.Nil(t, st)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("repo GetStats error", func(t *testing.T) {
+		filter := &domain.StatsFilter{From: &from, To: &to}
+		repo.EXPECT().GetStats(ctx, filter).Return(nil, fmt.Errorf("query failed"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Stats usecase: get stats failed")
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.Nil(t, st)
+		assert.ErrorContains(t, err, "query failed")
+	})
+
+	t.Run("ok team", func(t *testing.T) {
+		filter := &domain.StatsFilter{TeamName: &teamName, From: &from}
+		repo.EXPECT().ExistsTeamByName(ctx, teamName).Return(true, nil)
+		repo.EXPECT().GetStats(ctx, filter).Return(stats, nil)
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, stats, st)
+	})
+
+	t.Run("ok user", func(t *testing.T) {
+		filter := &domain.StatsFilter{UserID: &userID}
+		repo.EXPECT().ExistsUserById(ctx, userID).Return(true, nil)
+		repo.EXPECT().GetStats(ctx, filter).Return(stats, nil)
+
+		st, err := uc.GetStats(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, stats, st)
+	})
+}
@@ -0,0 +1,19 @@
+package team
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+)
+
+//go:generate mockgen -source picker_interface.go -destination=mocks/mock_reviewer_picker.go -package=mocks
+
+// ReviewerPicker выбирает замену ревьюверу по настройкам команды автора PullRequest
+type ReviewerPicker interface {
+	// PickReplacement возвращает id нового ревьювера для pr, не назначенного на pr
+	// и не входящего в exclude, или domain.ErrNoAvailableCandidats. Сдвиг очереди ROUND_ROBIN
+	// добавляется в moves и сохраняется репозиторием в транзакции назначения; выбор
+	// добавляется в load, чтобы LEAST_LOADED учитывал прежние выборы той же операции
+	PickReplacement(
+		ctx context.Context, pr *domain.PullRequest, exclude []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+	) (int, error)
+}
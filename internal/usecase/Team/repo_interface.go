@@ -11,4 +11,19 @@ type teamRepo interface {
 	ExistsByName(ctx context.Context, name string) (bool, error)
 	Create(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
+	GetSettings(ctx context.Context, name string) (*domain.TeamSettings, error)
+	UpdateSettings(ctx context.Context, ts *domain.TeamSettings) (*domain.TeamSettings, error)
+	GetUsers(ctx context.Context, ids []int) ([]domain.User, error)
+	GetOpenTeamReviews(ctx context.Context, teamName string, reviewerIDs []int) ([]domain.PullRequest, error)
+	AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) error
+	ChangeMembersTeam(
+		ctx context.Context, userIDs []int, toTeam *string, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+		hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+	) error
+	Rename(ctx context.Context, name string, newName string) error
+	Sync(
+		ctx context.Context, team *domain.Team, removed []int, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+		hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+	) error
+	Delete(ctx context.Context, name string) error
 }
@@ -2,19 +2,26 @@ package team
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/metrics"
+	"slices"
+	"time"
 )
 
 type TeamUsecase struct {
 	repo   teamRepo
+	picker ReviewerPicker
 	logger logger.Logger
 }
 
-func NewTeamUsecase(repo teamRepo, logger logger.Logger) *TeamUsecase {
+func NewTeamUsecase(repo teamRepo, picker ReviewerPicker, logger logger.Logger) *TeamUsecase {
 	return &TeamUsecase{
-		repo: repo,
+		repo:   repo,
+		picker: picker,
+		logger: logger,
 	}
 }
 
@@ -30,7 +37,7 @@ func (uc *TeamUsecase) CreateTeam(ctx context.Context, team *domain.Team) (*doma
 
 	createdTeam, err := uc.repo.Create(ctx, team)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "team_name": team.Name, "team_id": team.ID, "members": team.Members}).Error("Team usecase: create team failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": team.Name, "team_id": team.ID, "members": team.Members}).Error("Team usecase: create team failed")
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
@@ -40,7 +47,7 @@ func (uc *TeamUsecase) CreateTeam(ctx context.Context, team *domain.Team) (*doma
 func (uc *TeamUsecase) checkTeamNameExists(ctx context.Context, name string) (bool, error) {
 	exists, err := uc.repo.ExistsByName(ctx, name)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: check team_name existance failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: check team_name existance failed")
 		return false, fmt.Errorf("failed to check team_name existance: %w", err)
 	}
 	return exists, err
@@ -58,8 +65,518 @@ func (uc *TeamUsecase) GetTeamByName(ctx context.Context, name string) (*domain.
 
 	team, err := uc.repo.GetByName(ctx, name)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: create team failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: create team failed")
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 	return team, nil
 }
+
+// GetTeamSettings Получить настройки назначения ревьюверов команды
+func (uc *TeamUsecase) GetTeamSettings(ctx context.Context, name string) (*domain.TeamSettings, error) {
+	exists, err := uc.checkTeamNameExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	settings, err := uc.repo.GetSettings(ctx, name)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: get team settings failed")
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings Изменить настройки назначения ревьюверов команды.
+// Не переданные поля остаются прежними
+func (uc *TeamUsecase) UpdateTeamSettings(ctx context.Context, upd *domain.UpdateTeamSettings) (*domain.TeamSettings, error) {
+	settings, err := uc.GetTeamSettings(ctx, upd.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.ReviewerStrategy != nil {
+		settings.ReviewerStrategy = *upd.ReviewerStrategy
+	}
+	if upd.MinReviewers != nil {
+		settings.MinReviewers = *upd.MinReviewers
+	}
+	if upd.MaxReviewers != nil {
+		settings.MaxReviewers = *upd.MaxReviewers
+	}
+	if upd.FallbackTeams != nil {
+		settings.FallbackTeams = *upd.FallbackTeams
+	}
+	if upd.RequiredApprovals != nil {
+		settings.RequiredApprovals = *upd.RequiredApprovals
+	}
+	if upd.BlockOnChangesRequested != nil {
+		settings.BlockOnChangesRequested = *upd.BlockOnChangesRequested
+	}
+	if upd.ReviewSLAMinutes != nil {
+		settings.ReviewSLAMinutes = *upd.ReviewSLAMinutes
+	}
+	if upd.EscalationPolicy != nil {
+		settings.EscalationPolicy = *upd.EscalationPolicy
+	}
+
+	if settings.MinReviewers < 0 || settings.MaxReviewers < 1 || settings.MinReviewers > settings.MaxReviewers {
+		return nil, domain.ErrInvalidSettings
+	}
+
+	// Одобрений не может требоваться больше, чем назначается ревьюверов
+	if settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.MaxReviewers {
+		return nil, domain.ErrInvalidSettings
+	}
+
+	if err := uc.checkFallbackTeams(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.repo.UpdateSettings(ctx, settings)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": upd.TeamName}).Error("Team usecase: update team settings failed")
+		return nil, fmt.Errorf("failed to update team settings: %w", err)
+	}
+	return updated, nil
+}
+
+// checkFallbackTeams проверяет, что резервные команды существуют,
+// не повторяются и не совпадают с самой командой
+func (uc *TeamUsecase) checkFallbackTeams(ctx context.Context, settings *domain.TeamSettings) error {
+	seen := make(map[string]struct{}, len(settings.FallbackTeams))
+	for _, name := range settings.FallbackTeams {
+		if _, ok := seen[name]; ok || name == settings.TeamName {
+			return domain.ErrInvalidSettings
+		}
+		seen[name] = struct{}{}
+
+		exists, err := uc.checkTeamNameExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrTeamNotFound
+		}
+	}
+	return nil
+}
+
+// AddTeamMembers Добавить участников в существующую команду.
+// Пользователи из других команд не добавляются, назначения не меняются
+func (uc *TeamUsecase) AddTeamMembers(ctx context.Context, add *domain.AddTeamMembers) (*domain.Team, error) {
+	if err := uc.checkTeamFound(ctx, add.TeamName); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int, 0, len(add.Members))
+	for _, m := range add.Members {
+		userIDs = append(userIDs, m.UserID)
+	}
+
+	users, err := uc.getUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.TeamName != "" && u.TeamName != add.TeamName {
+			return nil, domain.ErrUserInOtherTeam
+		}
+	}
+
+	if err := uc.repo.AddMembers(ctx, add.TeamName, add.Members); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": add.TeamName, "members": add.Members}).Error("Team usecase: add team members failed")
+		return nil, fmt.Errorf("failed to add team members: %w", err)
+	}
+
+	return uc.getTeam(ctx, add.TeamName)
+}
+
+// RemoveTeamMembers Исключить участников из команды. Их ревью на OPEN PR
+// авторов команды переназначаются на оставшихся участников
+func (uc *TeamUsecase) RemoveTeamMembers(ctx context.Context, rm *domain.RemoveTeamMembers) (*domain.TeamMembershipResult, error) {
+	if err := uc.checkTeamFound(ctx, rm.TeamName); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int, 0, len(rm.UserIDs))
+	for _, id := range rm.UserIDs {
+		if !slices.Contains(userIDs, id) {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	users, err := uc.getUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(userIDs) {
+		return nil, domain.ErrUserNotFound
+	}
+	for _, u := range users {
+		if u.TeamName != rm.TeamName {
+			return nil, domain.ErrNotTeamMember
+		}
+	}
+
+	res, err := uc.changeTeam(ctx, rm.TeamName, userIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Team, err = uc.getTeam(ctx, rm.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MoveTeamMember Перевести пользователя в другую команду. Его ревью на OPEN PR
+// авторов прежней команды переназначаются, как при исключении
+func (uc *TeamUsecase) MoveTeamMember(ctx context.Context, mv *domain.MoveTeamMember) (*domain.TeamMembershipResult, error) {
+	if err := uc.checkTeamFound(ctx, mv.ToTeamName); err != nil {
+		return nil, err
+	}
+
+	users, err := uc.getUsers(ctx, []int{mv.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, domain.ErrUserNotFound
+	}
+
+	res := &domain.TeamMembershipResult{
+		Reassigned:       make([]domain.ReviewReplacement, 0),
+		WithoutCandidate: make([]domain.StuckReview, 0),
+	}
+	if users[0].TeamName != mv.ToTeamName {
+		res, err = uc.changeTeam(ctx, users[0].TeamName, []int{mv.UserID}, &mv.ToTeamName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Team, err = uc.getTeam(ctx, mv.ToTeamName)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RenameTeam Переименовать команду
+func (uc *TeamUsecase) RenameTeam(ctx context.Context, rn *domain.RenameTeam) (*domain.Team, error) {
+	if err := uc.checkTeamFound(ctx, rn.TeamName); err != nil {
+		return nil, err
+	}
+
+	if rn.NewTeamName != rn.TeamName {
+		// Занятость имени проверяет уникальный индекс, чтобы не гоняться с параллельным созданием
+		err := uc.repo.Rename(ctx, rn.TeamName, rn.NewTeamName)
+		if errors.Is(err, domain.ErrTeamExists) {
+			return nil, err
+		}
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": rn.TeamName, "new_team_name": rn.NewTeamName}).Error("Team usecase: rename team failed")
+			return nil, fmt.Errorf("failed to rename team: %w", err)
+		}
+	}
+
+	return uc.getTeam(ctx, rn.NewTeamName)
+}
+
+// DeleteTeam Удалить команду. Участники остаются без команды,
+// их назначения на открытые PR сохраняются
+func (uc *TeamUsecase) DeleteTeam(ctx context.Context, name string) (*domain.Team, error) {
+	team, err := uc.GetTeamByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Delete(ctx, name); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: delete team failed")
+		return nil, fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	return team, nil
+}
+
+// SyncTeam Привести состав команды к переданному списку, создав команду при необходимости.
+// Участники вне списка остаются без команды, пользователи из других команд переводятся.
+// С DryRun возвращается разница и замены ревьюверов без применения
+func (uc *TeamUsecase) SyncTeam(ctx context.Context, sync *domain.SyncTeam) (*domain.TeamSyncResult, error) {
+	name := sync.Team.Name
+
+	exists, err := uc.checkTeamNameExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &domain.Team{Name: name, ReviewerStrategy: sync.Team.ReviewerStrategy}
+	if exists {
+		current, err = uc.getTeam(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	incoming, err := uc.incomingUsers(ctx, current.Members, sync.Team.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	// Не переданный вес остается текущим, сравнивается уже разрешенный состав
+	weights := make(map[int]int, len(current.Members)+len(incoming))
+	for _, m := range current.Members {
+		weights[m.UserID] = m.ReviewWeight
+	}
+	for _, u := range incoming {
+		weights[u.ID] = u.ReviewWeight
+	}
+	desired := domain.ResolveReviewWeights(sync.Team.Members, weights)
+
+	res := &domain.TeamSyncResult{
+		Team:             current,
+		TeamCreated:      !exists,
+		DryRun:           sync.DryRun,
+		Reassigned:       make([]domain.ReviewReplacement, 0),
+		WithoutCandidate: make([]domain.StuckReview, 0),
+	}
+	res.Added, res.Updated, res.Removed = domain.DiffTeamMembers(current.Members, desired)
+
+	if exists && !res.Changed() {
+		return res, nil
+	}
+
+	leaving := leavingByTeam(name, res.Removed, incoming)
+
+	// reassignedTeams команда автора PR для каждой замены из res.Reassigned
+	var reassignedTeams []string
+	err = domain.RetryOnRoundRobinMoved(func() error {
+		var moves domain.RoundRobinMoves
+		load := make(domain.BatchLoad)
+		res.Reassigned = make([]domain.ReviewReplacement, 0)
+		res.WithoutCandidate = make([]domain.StuckReview, 0)
+		reassignedTeams = reassignedTeams[:0]
+		var hooks []domain.WebhookEvent
+		for _, l := range leaving {
+			reassigned, withoutCandidate, teamHooks, err := uc.reassignReviews(ctx, l.team, l.userIDs, &moves, load)
+			if err != nil {
+				return err
+			}
+			hooks = append(hooks, teamHooks...)
+			res.Reassigned = append(res.Reassigned, reassigned...)
+			res.WithoutCandidate = append(res.WithoutCandidate, withoutCandidate...)
+			for range reassigned {
+				reassignedTeams = append(reassignedTeams, l.team)
+			}
+		}
+
+		// Подбор замены только читает очередь ROUND_ROBIN, сдвиги применяет repo.Sync
+		if sync.DryRun {
+			return nil
+		}
+
+		events := teamChangedEvents(ctx, res.Reassigned)
+		if err := uc.repo.Sync(ctx, sync.Team, res.Removed, res.Reassigned, events, hooks, moves); err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: sync team failed")
+			return fmt.Errorf("failed to sync team: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sync.DryRun {
+		res.Team = &domain.Team{ID: current.ID, Name: name, Members: desired, ReviewerStrategy: current.ReviewerStrategy}
+		return res, nil
+	}
+
+	for _, team := range reassignedTeams {
+		metrics.ReviewerReassigned(team, string(domain.ReasonTeamChanged))
+	}
+
+	res.Team, err = uc.getTeam(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// incomingUsers существующие пользователи из desired, которых нет в current
+func (uc *TeamUsecase) incomingUsers(ctx context.Context, current, desired []domain.TeamMember) ([]domain.User, error) {
+	ids := make([]int, 0)
+	for _, m := range desired {
+		if !slices.ContainsFunc(current, func(c domain.TeamMember) bool { return c.UserID == m.UserID }) {
+			ids = append(ids, m.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return uc.getUsers(ctx, ids)
+}
+
+// teamLeaving участники, уходящие из команды team при синхронизации
+type teamLeaving struct {
+	team    string
+	userIDs []int
+}
+
+// leavingByTeam группирует уходящих участников по командам: исключенные из name
+// и переходящие в name из других команд
+func leavingByTeam(name string, removed []int, incoming []domain.User) []teamLeaving {
+	leaving := make([]teamLeaving, 0)
+	if len(removed) > 0 {
+		leaving = append(leaving, teamLeaving{team: name, userIDs: removed})
+	}
+
+	for _, u := range incoming {
+		if u.TeamName == "" || u.TeamName == name {
+			continue
+		}
+		idx := slices.IndexFunc(leaving, func(l teamLeaving) bool { return l.team == u.TeamName })
+		if idx < 0 {
+			leaving = append(leaving, teamLeaving{team: u.TeamName})
+			idx = len(leaving) - 1
+		}
+		leaving[idx].userIDs = append(leaving[idx].userIDs, u.ID)
+	}
+
+	return leaving
+}
+
+// changeTeam переводит userIDs из fromTeam в toTeam (nil - без команды),
+// переназначая их ревью на OPEN PR авторов fromTeam
+func (uc *TeamUsecase) changeTeam(ctx context.Context, fromTeam string, userIDs []int, toTeam *string) (*domain.TeamMembershipResult, error) {
+	res := &domain.TeamMembershipResult{}
+	err := domain.RetryOnRoundRobinMoved(func() error {
+		var (
+			moves domain.RoundRobinMoves
+			hooks []domain.WebhookEvent
+			err   error
+		)
+		res.Reassigned, res.WithoutCandidate, hooks, err = uc.reassignReviews(ctx, fromTeam, userIDs, &moves, make(domain.BatchLoad))
+		if err != nil {
+			return err
+		}
+
+		events := teamChangedEvents(ctx, res.Reassigned)
+		if err := uc.repo.ChangeMembersTeam(ctx, userIDs, toTeam, res.Reassigned, events, hooks, moves); err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userIDs": userIDs}).Error("Team usecase: change members team failed")
+			return fmt.Errorf("failed to change members team: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for range res.Reassigned {
+		metrics.ReviewerReassigned(fromTeam, string(domain.ReasonTeamChanged))
+	}
+
+	return res, nil
+}
+
+// reassignReviews подбирает замену userIDs на OPEN PR авторов fromTeam и вебхуки о заменах;
+// PR без кандидата попадают в withoutCandidate, сдвиги ROUND_ROBIN - в moves, выбранные замены - в load
+func (uc *TeamUsecase) reassignReviews(
+	ctx context.Context, fromTeam string, userIDs []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+) ([]domain.ReviewReplacement, []domain.StuckReview, []domain.WebhookEvent, error) {
+	reassigned := make([]domain.ReviewReplacement, 0)
+	withoutCandidate := make([]domain.StuckReview, 0)
+	hooks := make([]domain.WebhookEvent, 0)
+
+	// У пользователя без команды нет ревью, которые нужно передать в команде автора
+	if fromTeam == "" || len(userIDs) == 0 {
+		return reassigned, withoutCandidate, hooks, nil
+	}
+
+	prs, err := uc.repo.GetOpenTeamReviews(ctx, fromTeam, userIDs)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": fromTeam, "userIDs": userIDs}).Error("Team usecase: get open team reviews failed")
+		return nil, nil, nil, fmt.Errorf("failed to get open team reviews: %w", err)
+	}
+
+	now := time.Now()
+	for i := range prs {
+		pr := &prs[i]
+		for idx, reviewerID := range pr.AssignedReviewers {
+			if !slices.Contains(userIDs, reviewerID) {
+				continue
+			}
+
+			newReviewerID, err := uc.picker.PickReplacement(ctx, pr, userIDs, moves, load)
+			if errors.Is(err, domain.ErrNoAvailableCandidats) {
+				withoutCandidate = append(withoutCandidate, domain.StuckReview{
+					PullRequestID: pr.ID,
+					ReviewerID:    reviewerID,
+				})
+				continue
+			}
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to pick replacement: %w", err)
+			}
+
+			pr.AssignedReviewers[idx] = newReviewerID
+			reassigned = append(reassigned, domain.ReviewReplacement{
+				PullRequestID: pr.ID,
+				OldReviewerID: reviewerID,
+				NewReviewerID: newReviewerID,
+			})
+			hooks = append(hooks, domain.NewReassignedWebhookEvent(pr, reviewerID, newReviewerID, now))
+		}
+	}
+
+	return reassigned, withoutCandidate, hooks, nil
+}
+
+// teamChangedEvents события истории для замен из-за смены команды
+func teamChangedEvents(ctx context.Context, reassigned []domain.ReviewReplacement) []domain.AssignmentEvent {
+	now := time.Now()
+	events := make([]domain.AssignmentEvent, 0, len(reassigned))
+	for _, rep := range reassigned {
+		events = append(events, domain.NewReplacementEvent(
+			rep.PullRequestID, rep.OldReviewerID, rep.NewReviewerID, domain.ReasonTeamChanged, domain.ActorUserID(ctx), now,
+		))
+	}
+	return events
+}
+
+func (uc *TeamUsecase) checkTeamFound(ctx context.Context, name string) error {
+	exists, err := uc.checkTeamNameExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (uc *TeamUsecase) getUsers(ctx context.Context, ids []int) ([]domain.User, error) {
+	users, err := uc.repo.GetUsers(ctx, ids)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userIDs": ids}).Error("Team usecase: get users failed")
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	return users, nil
+}
+
+func (uc *TeamUsecase) getTeam(ctx context.Context, name string) (*domain.Team, error) {
+	team, err := uc.repo.GetByName(ctx, name)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": name}).Error("Team usecase: get team failed")
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return team, nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *TeamUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
@@ -129,3 +129,522 @@ func TestTeamUsecase_GetTeamByName(t *testing.T) {
 		assert.Equal(t, team, result)
 	})
 }
+
+func TestTeamUsecase_UpdateTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	current := func() *domain.TeamSettings {
+		return &domain.TeamSettings{
+			TeamName: "docs", ReviewerStrategy: domain.StrategyRandom, MinReviewers: 0, MaxReviewers: 2,
+		}
+	}
+
+	t.Run("team not found", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(false, nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs"})
+		assert.Nil(t, settings)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("only passed fields are changed", func(t *testing.T) {
+		maxReviewers := 1
+		want := current()
+		want.MaxReviewers = 1
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+		repo.EXPECT().UpdateSettings(ctx, want).Return(want, nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", MaxReviewers: &maxReviewers})
+		assert.NoError(t, err)
+		assert.Equal(t, want, settings)
+	})
+
+	t.Run("min greater than current max", func(t *testing.T) {
+		minReviewers := 3
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", MinReviewers: &minReviewers})
+		assert.Nil(t, settings)
+		assert.Equal(t, domain.ErrInvalidSettings, err)
+	})
+
+	t.Run("merge policy is saved", func(t *testing.T) {
+		requiredApprovals, block := 2, true
+		want := current()
+		want.RequiredApprovals = 2
+		want.BlockOnChangesRequested = true
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+		repo.EXPECT().UpdateSettings(ctx, want).Return(want, nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{
+			TeamName: "docs", RequiredApprovals: &requiredApprovals, BlockOnChangesRequested: &block,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, want, settings)
+	})
+
+	t.Run("required approvals greater than max reviewers", func(t *testing.T) {
+		requiredApprovals := 3
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", RequiredApprovals: &requiredApprovals})
+		assert.Nil(t, settings)
+		assert.Equal(t, domain.ErrInvalidSettings, err)
+	})
+}
+
+func TestTeamUsecase_UpdateTeamSettingsFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	current := func() *domain.TeamSettings {
+		return &domain.TeamSettings{TeamName: "docs", ReviewerStrategy: domain.StrategyRandom, MaxReviewers: 2}
+	}
+
+	t.Run("fallback teams saved", func(t *testing.T) {
+		fallback := []string{"backend", "frontend"}
+		want := current()
+		want.FallbackTeams = fallback
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().ExistsByName(ctx, "frontend").Return(true, nil)
+		repo.EXPECT().UpdateSettings(ctx, want).Return(want, nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", FallbackTeams: &fallback})
+		assert.NoError(t, err)
+		assert.Equal(t, fallback, settings.FallbackTeams)
+	})
+
+	t.Run("fallback team not found", func(t *testing.T) {
+		fallback := []string{"ghost"}
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+		repo.EXPECT().ExistsByName(ctx, "ghost").Return(false, nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", FallbackTeams: &fallback})
+		assert.Nil(t, settings)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("team is its own fallback", func(t *testing.T) {
+		fallback := []string{"docs"}
+
+		repo.EXPECT().ExistsByName(ctx, "docs").Return(true, nil)
+		repo.EXPECT().GetSettings(ctx, "docs").Return(current(), nil)
+
+		settings, err := uc.UpdateTeamSettings(ctx, &domain.UpdateTeamSettings{TeamName: "docs", FallbackTeams: &fallback})
+		assert.Nil(t, settings)
+		assert.Equal(t, domain.ErrInvalidSettings, err)
+	})
+}
+
+func TestTeamUsecase_AddTeamMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	add := &domain.AddTeamMembers{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: 1, Username: "alice", IsActive: true, ReviewWeight: 1},
+			{UserID: 7, Username: "grace", IsActive: true, ReviewWeight: 1},
+		},
+	}
+
+	t.Run("team not found", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(false, nil)
+
+		team, err := uc.AddTeamMembers(ctx, add)
+		assert.Nil(t, team)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("user in another team", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{1, 7}).Return([]domain.User{{ID: 7, TeamName: "frontend"}}, nil)
+
+		team, err := uc.AddTeamMembers(ctx, add)
+		assert.Nil(t, team)
+		assert.Equal(t, domain.ErrUserInOtherTeam, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		want := &domain.Team{Name: "backend", Members: add.Members}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{1, 7}).Return([]domain.User{{ID: 1, TeamName: "backend"}}, nil)
+		repo.EXPECT().AddMembers(ctx, "backend", add.Members).Return(nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(want, nil)
+
+		team, err := uc.AddTeamMembers(ctx, add)
+		assert.NoError(t, err)
+		assert.Equal(t, want, team)
+	})
+}
+
+func TestTeamUsecase_RemoveTeamMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	picker := mockRepo.NewMockReviewerPicker(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, picker: picker, logger: logger}
+
+	ctx := context.Background()
+
+	t.Run("user is not a member", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{{ID: 2, TeamName: "frontend"}}, nil)
+
+		res, err := uc.RemoveTeamMembers(ctx, &domain.RemoveTeamMembers{TeamName: "backend", UserIDs: []int{2, 2}})
+		assert.Nil(t, res)
+		assert.Equal(t, domain.ErrNotTeamMember, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2, 3}).Return([]domain.User{{ID: 2, TeamName: "backend"}}, nil)
+
+		res, err := uc.RemoveTeamMembers(ctx, &domain.RemoveTeamMembers{TeamName: "backend", UserIDs: []int{2, 3}})
+		assert.Nil(t, res)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("reviews are reassigned", func(t *testing.T) {
+		actorID := 1
+		ctx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleUser, UserID: &actorID})
+
+		prs := []domain.PullRequest{
+			{ID: 10, AuthorID: 1, AssignedReviewers: []int{2, 3}},
+			{ID: 11, AuthorID: 3, AssignedReviewers: []int{2}},
+		}
+		replacements := []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 4}}
+		team := &domain.Team{Name: "backend"}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{{ID: 2, TeamName: "backend"}}, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "backend", []int{2}).Return(prs, nil)
+		gomock.InOrder(
+			picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{2}, gomock.Any(), gomock.Any()).Return(4, nil),
+			picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{2}, gomock.Any(), gomock.Any()).Return(0, domain.ErrNoAvailableCandidats),
+		)
+		repo.EXPECT().ChangeMembersTeam(ctx, []int{2}, nil, replacements, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, userIDs []int, toTeam *string, reps []domain.ReviewReplacement, events []domain.AssignmentEvent,
+				hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+			) error {
+				assert.Len(t, events, 1)
+				assert.Equal(t, domain.ReasonTeamChanged, events[0].Reason)
+				assert.Equal(t, actorID, *events[0].ActorID)
+				assert.Len(t, hooks, 1)
+				assert.Equal(t, domain.WebhookPRReassigned, hooks[0].Type)
+				assert.Equal(t, 1, hooks[0].UserID)
+				return nil
+			},
+		)
+		repo.EXPECT().GetByName(ctx, "backend").Return(team, nil)
+
+		res, err := uc.RemoveTeamMembers(ctx, &domain.RemoveTeamMembers{TeamName: "backend", UserIDs: []int{2}})
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.TeamMembershipResult{
+			Team:             team,
+			Reassigned:       replacements,
+			WithoutCandidate: []domain.StuckReview{{PullRequestID: 11, ReviewerID: 2}},
+		}, res)
+	})
+}
+
+func TestTeamUsecase_MoveTeamMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	picker := mockRepo.NewMockReviewerPicker(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, picker: picker, logger: logger}
+
+	ctx := context.Background()
+	mv := &domain.MoveTeamMember{UserID: 2, ToTeamName: "frontend"}
+	team := &domain.Team{Name: "frontend"}
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "frontend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{}, nil)
+
+		res, err := uc.MoveTeamMember(ctx, mv)
+		assert.Nil(t, res)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("already in target team", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "frontend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{{ID: 2, TeamName: "frontend"}}, nil)
+		repo.EXPECT().GetByName(ctx, "frontend").Return(team, nil)
+
+		res, err := uc.MoveTeamMember(ctx, mv)
+		assert.NoError(t, err)
+		assert.Equal(t, team, res.Team)
+		assert.Empty(t, res.Reassigned)
+	})
+
+	t.Run("moved and reviews reassigned in old team", func(t *testing.T) {
+		prs := []domain.PullRequest{{ID: 10, AuthorID: 1, AssignedReviewers: []int{2}}}
+		replacements := []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 3}}
+
+		repo.EXPECT().ExistsByName(ctx, "frontend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{{ID: 2, TeamName: "backend"}}, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "backend", []int{2}).Return(prs, nil)
+		picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{2}, gomock.Any(), gomock.Any()).Return(3, nil)
+		repo.EXPECT().ChangeMembersTeam(ctx, []int{2}, &mv.ToTeamName, replacements, gomock.Len(1), gomock.Len(1), gomock.Any()).Return(nil)
+		repo.EXPECT().GetByName(ctx, "frontend").Return(team, nil)
+
+		res, err := uc.MoveTeamMember(ctx, mv)
+		assert.NoError(t, err)
+		assert.Equal(t, team, res.Team)
+		assert.Equal(t, replacements, res.Reassigned)
+	})
+
+	t.Run("user without team", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "frontend").Return(true, nil)
+		repo.EXPECT().GetUsers(ctx, []int{2}).Return([]domain.User{{ID: 2}}, nil)
+		repo.EXPECT().ChangeMembersTeam(ctx, []int{2}, &mv.ToTeamName, []domain.ReviewReplacement{}, gomock.Len(0), gomock.Len(0), gomock.Any()).Return(nil)
+		repo.EXPECT().GetByName(ctx, "frontend").Return(team, nil)
+
+		res, err := uc.MoveTeamMember(ctx, mv)
+		assert.NoError(t, err)
+		assert.Equal(t, team, res.Team)
+	})
+}
+
+func TestTeamUsecase_RenameTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	rn := &domain.RenameTeam{TeamName: "backend", NewTeamName: "platform"}
+
+	t.Run("new name is taken", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().Rename(ctx, "backend", "platform").Return(domain.ErrTeamExists)
+
+		team, err := uc.RenameTeam(ctx, rn)
+		assert.Nil(t, team)
+		assert.Equal(t, domain.ErrTeamExists, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		want := &domain.Team{Name: "platform"}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().Rename(ctx, "backend", "platform").Return(nil)
+		repo.EXPECT().GetByName(ctx, "platform").Return(want, nil)
+
+		team, err := uc.RenameTeam(ctx, rn)
+		assert.NoError(t, err)
+		assert.Equal(t, want, team)
+	})
+}
+
+func TestTeamUsecase_DeleteTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+
+	t.Run("team not found", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "legacy").Return(false, nil)
+
+		team, err := uc.DeleteTeam(ctx, "legacy")
+		assert.Nil(t, team)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("repo Delete error", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "legacy").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "legacy").Return(&domain.Team{Name: "legacy"}, nil)
+		repo.EXPECT().Delete(ctx, "legacy").Return(fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Team usecase: delete team failed")
+
+		team, err := uc.DeleteTeam(ctx, "legacy")
+		assert.Nil(t, team)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		want := &domain.Team{Name: "legacy", Members: []domain.TeamMember{{UserID: 1}}}
+
+		repo.EXPECT().ExistsByName(ctx, "legacy").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "legacy").Return(want, nil)
+		repo.EXPECT().Delete(ctx, "legacy").Return(nil)
+
+		team, err := uc.DeleteTeam(ctx, "legacy")
+		assert.NoError(t, err)
+		assert.Equal(t, want, team)
+	})
+}
+
+func TestTeamUsecase_SyncTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockteamRepo(ctrl)
+	picker := mockRepo.NewMockReviewerPicker(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &TeamUsecase{repo: repo, picker: picker, logger: logger}
+
+	ctx := context.Background()
+	alice := domain.TeamMember{UserID: 1, Username: "alice", IsActive: true, ReviewWeight: 1}
+	bob := domain.TeamMember{UserID: 2, Username: "bob", IsActive: true, ReviewWeight: 1}
+	grace := domain.TeamMember{UserID: 7, Username: "grace", IsActive: true, ReviewWeight: 1}
+	desired := &domain.Team{Name: "backend", Members: []domain.TeamMember{alice, grace}, ReviewerStrategy: domain.StrategyRandom}
+	current := func() *domain.Team {
+		return &domain.Team{ID: 3, Name: "backend", Members: []domain.TeamMember{alice, bob}, ReviewerStrategy: domain.StrategyRoundRobin}
+	}
+
+	t.Run("new team is created", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(false, nil)
+		repo.EXPECT().GetUsers(ctx, []int{1, 7}).Return([]domain.User{}, nil)
+		repo.EXPECT().Sync(ctx, desired, []int{}, []domain.ReviewReplacement{}, gomock.Len(0), gomock.Len(0), gomock.Any()).Return(nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(desired, nil)
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: desired})
+		assert.NoError(t, err)
+		assert.True(t, res.TeamCreated)
+		assert.Equal(t, []domain.TeamMember{alice, grace}, res.Added)
+		assert.Equal(t, desired, res.Team)
+	})
+
+	t.Run("dry run reports the diff and reassignments", func(t *testing.T) {
+		removedPRs := []domain.PullRequest{{ID: 10, AuthorID: 1, AssignedReviewers: []int{2}}}
+		movedPRs := []domain.PullRequest{{ID: 20, AuthorID: 8, AssignedReviewers: []int{7}}}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(current(), nil)
+		repo.EXPECT().GetUsers(ctx, []int{7}).Return([]domain.User{{ID: 7, TeamName: "frontend", ReviewWeight: 1}}, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "backend", []int{2}).Return(removedPRs, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "frontend", []int{7}).Return(movedPRs, nil)
+		picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{2}, gomock.Any(), gomock.Any()).Return(1, nil)
+		picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{7}, gomock.Any(), gomock.Any()).Return(0, domain.ErrNoAvailableCandidats)
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: desired, DryRun: true})
+		assert.NoError(t, err)
+		assert.False(t, res.TeamCreated)
+		assert.True(t, res.DryRun)
+		assert.Equal(t, []domain.TeamMember{grace}, res.Added)
+		assert.Empty(t, res.Updated)
+		assert.Equal(t, []int{2}, res.Removed)
+		assert.Equal(t, []domain.ReviewReplacement{{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 1}}, res.Reassigned)
+		assert.Equal(t, []domain.StuckReview{{PullRequestID: 20, ReviewerID: 7}}, res.WithoutCandidate)
+		assert.Equal(t, desired.Members, res.Team.Members)
+		assert.Equal(t, domain.StrategyRoundRobin, res.Team.ReviewerStrategy)
+	})
+
+	t.Run("omitted review weight is kept", func(t *testing.T) {
+		heavy := alice
+		heavy.ReviewWeight = 3
+		omitted := alice
+		omitted.ReviewWeight = 0
+		sync := &domain.Team{Name: "backend", Members: []domain.TeamMember{omitted, bob}}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(&domain.Team{ID: 3, Name: "backend", Members: []domain.TeamMember{heavy, bob}}, nil)
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: sync})
+		assert.NoError(t, err)
+		assert.False(t, res.Changed())
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		same := &domain.Team{Name: "backend", Members: []domain.TeamMember{alice, bob}}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(current(), nil)
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: same})
+		assert.NoError(t, err)
+		assert.False(t, res.Changed())
+		assert.Equal(t, current(), res.Team)
+	})
+
+	t.Run("removed and moved members are reassigned", func(t *testing.T) {
+		synced := &domain.Team{Name: "backend", Members: desired.Members}
+		removedPRs := []domain.PullRequest{{ID: 10, AuthorID: 1, AssignedReviewers: []int{2}}}
+		movedPRs := []domain.PullRequest{{ID: 20, AuthorID: 8, AssignedReviewers: []int{7}}}
+		replacements := []domain.ReviewReplacement{
+			{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 1},
+			{PullRequestID: 20, OldReviewerID: 7, NewReviewerID: 9},
+		}
+
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(current(), nil)
+		repo.EXPECT().GetUsers(ctx, []int{7}).Return([]domain.User{{ID: 7, TeamName: "frontend"}}, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "backend", []int{2}).Return(removedPRs, nil)
+		repo.EXPECT().GetOpenTeamReviews(ctx, "frontend", []int{7}).Return(movedPRs, nil)
+		picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{2}, gomock.Any(), gomock.Any()).Return(1, nil)
+		picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{7}, gomock.Any(), gomock.Any()).Return(9, nil)
+		repo.EXPECT().Sync(ctx, desired, []int{2}, replacements, gomock.Len(2), gomock.Len(2), gomock.Any()).Return(nil)
+		repo.EXPECT().GetByName(ctx, "backend").Return(synced, nil)
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: desired})
+		assert.NoError(t, err)
+		assert.Equal(t, synced, res.Team)
+		assert.Equal(t, replacements, res.Reassigned)
+		assert.Empty(t, res.WithoutCandidate)
+	})
+
+	t.Run("repo Sync error", func(t *testing.T) {
+		repo.EXPECT().ExistsByName(ctx, "backend").Return(false, nil)
+		repo.EXPECT().GetUsers(ctx, []int{1, 7}).Return([]domain.User{}, nil)
+		repo.EXPECT().Sync(ctx, desired, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Team usecase: sync team failed")
+
+		res, err := uc.SyncTeam(ctx, &domain.SyncTeam{Team: desired})
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "db error")
+	})
+}
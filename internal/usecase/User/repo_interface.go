@@ -3,12 +3,26 @@ package user
 import (
 	"context"
 	"pr-reviewer/internal/domain"
+	"time"
 )
 
 //go:generate mockgen -source repo_interface.go -destination=mocks/mock_user_repo.go -package=mocks
 
 type UserRepo interface {
 	ExistsById(ctx context.Context, id int) (bool, error)
-	UpdateIsActive(ctx context.Context, set *domain.SetUserIsActive) (*domain.User, error)
-	GetUserPullRequests(ctx context.Context, userID int) ([]domain.PullRequest, error)
+	UpdateIsActive(
+		ctx context.Context, set *domain.SetUserIsActive, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+	) (*domain.User, error)
+	GetUserPullRequests(ctx context.Context, filter *domain.ReviewFilter) (*domain.ReviewPage, error)
+	ExistsTeamByName(ctx context.Context, name string) (bool, error)
+	GetTeamUserIDs(ctx context.Context, teamName string) ([]int, error)
+	GetOpenReviewsByReviewers(ctx context.Context, userIDs []int) ([]domain.PullRequest, error)
+	DeactivateAndReassign(
+		ctx context.Context, userIDs []int, replacements []domain.ReviewReplacement, events []domain.AssignmentEvent,
+		hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+	) error
+	CreateAvailabilityWindow(ctx context.Context, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error)
+	GetAvailabilityWindows(ctx context.Context, userID int, now time.Time) ([]domain.AvailabilityWindow, error)
+	GetAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error)
+	DeleteAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error)
 }
@@ -2,26 +2,33 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/pkg/logger"
+	"pr-reviewer/internal/pkg/metrics"
+	"slices"
+	"time"
 )
 
 type UserUsecase struct {
 	repo   UserRepo
+	picker ReviewerPicker
 	logger logger.Logger
 }
 
-func NewUserUsecase(repo UserRepo, logger logger.Logger) *UserUsecase {
+func NewUserUsecase(repo UserRepo, picker ReviewerPicker, logger logger.Logger) *UserUsecase {
 	return &UserUsecase{
-		repo: repo,
+		repo:   repo,
+		picker: picker,
+		logger: logger,
 	}
 }
 
 func (uc *UserUsecase) SetUserIsActive(ctx context.Context, set *domain.SetUserIsActive) (*domain.User, error) {
 	exists, err := uc.checkUserIDExists(ctx, set.ID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": set.ID, "isActive": set.IsActive}).Error("User usecase: check user_id failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": set.ID, "isActive": set.IsActive}).Error("User usecase: check user_id failed")
 		return nil, err
 	}
 
@@ -29,20 +36,42 @@ func (uc *UserUsecase) SetUserIsActive(ctx context.Context, set *domain.SetUserI
 		return nil, domain.ErrUserNotFound
 	}
 
-	updatedUser, err := uc.repo.UpdateIsActive(ctx, set)
+	// Активность меняет, может ли пользователь ревьюить свои OPEN PR, поэтому пишется в их журнал
+	prs, err := uc.repo.GetOpenReviewsByReviewers(ctx, []int{set.ID})
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": set.ID, "isActive": set.IsActive}).Error("User usecase: update is_active failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": set.ID}).Error("User usecase: get open reviews failed")
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+	prIDs := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.ID)
+	}
+
+	now := time.Now()
+	events := domain.NewActivityEvents(prIDs, set.ID, set.IsActive, domain.ActorUserID(ctx), now)
+	hooks := []domain.WebhookEvent{
+		domain.NewUserActivityWebhookEvent(set.ID, set.IsActive, now),
+	}
+
+	updatedUser, err := uc.repo.UpdateIsActive(ctx, set, events, hooks)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": set.ID, "isActive": set.IsActive}).Error("User usecase: update is_active failed")
 		return nil, fmt.Errorf("failed to update_is_active %w", err)
 	}
 	return updatedUser, nil
 
 }
 
-// GetUserPullRequests Получить PullRequests у конктетного User
-func (uc *UserUsecase) GetUserPullRequests(ctx context.Context, userID int) ([]domain.PullRequest, error) {
-	exists, err := uc.checkUserIDExists(ctx, userID)
+// GetUserPullRequests Получить страницу PullRequests, где User назначен ревьювером
+func (uc *UserUsecase) GetUserPullRequests(ctx context.Context, filter *domain.ReviewFilter) (*domain.ReviewPage, error) {
+	// Токен роли user читает только свои ревью
+	if err := domain.CheckActorUser(ctx, filter.UserID); err != nil {
+		return nil, err
+	}
+
+	exists, err := uc.checkUserIDExists(ctx, filter.UserID)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": userID}).Error("User usecase: check user_id failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": filter.UserID}).Error("User usecase: check user_id failed")
 		return nil, err
 	}
 
@@ -50,13 +79,156 @@ func (uc *UserUsecase) GetUserPullRequests(ctx context.Context, userID int) ([]d
 		return nil, domain.ErrUserNotFound
 	}
 
-	userPRs, err := uc.repo.GetUserPullRequests(ctx, userID)
+	if filter.TeamName != nil {
+		exists, err := uc.repo.ExistsTeamByName(ctx, *filter.TeamName)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": *filter.TeamName}).Error("User usecase: check team_name failed")
+			return nil, fmt.Errorf("failed to check team existance: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	page, err := uc.repo.GetUserPullRequests(ctx, filter)
 	if err != nil {
-		uc.logger.WithFields(logger.LoggerFields{"err": err.Error(), "userID": userID}).Error("User usecase: get user prs failed")
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": filter.UserID}).Error("User usecase: get user prs failed")
 		return nil, fmt.Errorf("failed to get user pull_requests: %w", err)
 	}
 
-	return userPRs, nil
+	return page, nil
+}
+
+// BulkDeactivate деактивирует пользователей и переназначает их OPEN ревью
+// на активных участников команды автора PullRequest
+func (uc *UserUsecase) BulkDeactivate(ctx context.Context, bd *domain.BulkDeactivate) (*domain.BulkDeactivateResult, error) {
+	userIDs, err := uc.resolveDeactivated(ctx, bd)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *domain.BulkDeactivateResult
+	err = domain.RetryOnRoundRobinMoved(func() error {
+		var err error
+		res, err = uc.deactivateAndReassign(ctx, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// deactivateAndReassign выполняет одну попытку BulkDeactivate для userIDs
+func (uc *UserUsecase) deactivateAndReassign(ctx context.Context, userIDs []int) (*domain.BulkDeactivateResult, error) {
+	prs, err := uc.repo.GetOpenReviewsByReviewers(ctx, userIDs)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userIDs": userIDs}).Error("User usecase: get open reviews failed")
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+
+	res := &domain.BulkDeactivateResult{
+		Deactivated:      userIDs,
+		Reassigned:       make([]domain.ReviewReplacement, 0),
+		WithoutCandidate: make([]domain.StuckReview, 0),
+	}
+
+	now := time.Now()
+	hooks := make([]domain.WebhookEvent, 0, len(userIDs))
+	for _, id := range userIDs {
+		hooks = append(hooks, domain.NewUserActivityWebhookEvent(id, false, now))
+	}
+
+	var moves domain.RoundRobinMoves
+	// load замены, выбранные в этой операции: следующие выборы видят их в нагрузке
+	load := make(domain.BatchLoad)
+	// reassignedTeams команда автора PR для каждой замены из res.Reassigned
+	reassignedTeams := make([]string, 0)
+	for i := range prs {
+		pr := &prs[i]
+		for idx, reviewerID := range pr.AssignedReviewers {
+			if !slices.Contains(userIDs, reviewerID) {
+				continue
+			}
+
+			newReviewerID, err := uc.picker.PickReplacement(ctx, pr, userIDs, &moves, load)
+			if errors.Is(err, domain.ErrNoAvailableCandidats) {
+				res.WithoutCandidate = append(res.WithoutCandidate, domain.StuckReview{
+					PullRequestID: pr.ID,
+					ReviewerID:    reviewerID,
+				})
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to pick replacement: %w", err)
+			}
+
+			pr.AssignedReviewers[idx] = newReviewerID
+			res.Reassigned = append(res.Reassigned, domain.ReviewReplacement{
+				PullRequestID: pr.ID,
+				OldReviewerID: reviewerID,
+				NewReviewerID: newReviewerID,
+			})
+			hooks = append(hooks, domain.NewReassignedWebhookEvent(pr, reviewerID, newReviewerID, now))
+			reassignedTeams = append(reassignedTeams, pr.AuthorTeamName)
+		}
+	}
+
+	events := make([]domain.AssignmentEvent, 0, len(res.Reassigned))
+	for _, rep := range res.Reassigned {
+		events = append(events, domain.NewReplacementEvent(
+			rep.PullRequestID, rep.OldReviewerID, rep.NewReviewerID, domain.ReasonDeactivated, domain.ActorUserID(ctx), now,
+		))
+	}
+
+	if err := uc.repo.DeactivateAndReassign(ctx, userIDs, res.Reassigned, events, hooks, moves); err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userIDs": userIDs}).Error("User usecase: bulk deactivate failed")
+		return nil, fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	for _, team := range reassignedTeams {
+		metrics.ReviewerReassigned(team, string(domain.ReasonDeactivated))
+	}
+
+	return res, nil
+}
+
+// resolveDeactivated возвращает id деактивируемых пользователей без повторов
+func (uc *UserUsecase) resolveDeactivated(ctx context.Context, bd *domain.BulkDeactivate) ([]int, error) {
+	if bd.TeamName != "" {
+		exists, err := uc.repo.ExistsTeamByName(ctx, bd.TeamName)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": bd.TeamName}).Error("User usecase: check team_name failed")
+			return nil, fmt.Errorf("failed to check team existance: %w", err)
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+
+		userIDs, err := uc.repo.GetTeamUserIDs(ctx, bd.TeamName)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": bd.TeamName}).Error("User usecase: get team users failed")
+			return nil, fmt.Errorf("failed to get team users: %w", err)
+		}
+		return userIDs, nil
+	}
+
+	userIDs := make([]int, 0, len(bd.UserIDs))
+	for _, id := range bd.UserIDs {
+		if slices.Contains(userIDs, id) {
+			continue
+		}
+
+		exists, err := uc.checkUserIDExists(ctx, id)
+		if err != nil {
+			uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": id}).Error("User usecase: check user_id failed")
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrUserNotFound
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, nil
 }
 
 func (uc *UserUsecase) checkUserIDExists(ctx context.Context, id int) (bool, error) {
@@ -66,3 +238,91 @@ func (uc *UserUsecase) checkUserIDExists(ctx context.Context, id int) (bool, err
 	}
 	return exists, nil
 }
+
+// GetAvailability Получить текущие и будущие окна недоступности User
+func (uc *UserUsecase) GetAvailability(ctx context.Context, userID int) ([]domain.AvailabilityWindow, error) {
+	if err := domain.CheckActorUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	exists, err := uc.checkUserIDExists(ctx, userID)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": userID}).Error("User usecase: check user_id failed")
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+
+	windows, err := uc.repo.GetAvailabilityWindows(ctx, userID, time.Now().UTC())
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": userID}).Error("User usecase: get availability windows failed")
+		return nil, fmt.Errorf("failed to get availability windows: %w", err)
+	}
+	return windows, nil
+}
+
+// AddAvailabilityWindow Добавить окно недоступности, на время которого User не назначается ревьювером
+func (uc *UserUsecase) AddAvailabilityWindow(ctx context.Context, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error) {
+	if err := domain.CheckActorUser(ctx, w.UserID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	// Окно, которое уже закончилось, ни на что не влияет
+	if !w.To.After(now) {
+		return nil, domain.ErrInvalidAvailability
+	}
+
+	exists, err := uc.checkUserIDExists(ctx, w.UserID)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": w.UserID}).Error("User usecase: check user_id failed")
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+
+	w.CreatedAt = now
+	created, err := uc.repo.CreateAvailabilityWindow(ctx, w)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "userID": w.UserID}).Error("User usecase: create availability window failed")
+		return nil, fmt.Errorf("failed to create availability window: %w", err)
+	}
+	return created, nil
+}
+
+// DeleteAvailabilityWindow Удалить окно недоступности
+func (uc *UserUsecase) DeleteAvailabilityWindow(ctx context.Context, id int) (*domain.AvailabilityWindow, error) {
+	window, err := uc.repo.GetAvailabilityWindow(ctx, id)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "windowID": id}).Error("User usecase: get availability window failed")
+		return nil, fmt.Errorf("failed to get availability window: %w", err)
+	}
+
+	if window == nil {
+		return nil, domain.ErrAvailabilityWindowNotFound
+	}
+
+	if err := domain.CheckActorUser(ctx, window.UserID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := uc.repo.DeleteAvailabilityWindow(ctx, id)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "windowID": id}).Error("User usecase: delete availability window failed")
+		return nil, fmt.Errorf("failed to delete availability window: %w", err)
+	}
+
+	if deleted == nil {
+		return nil, domain.ErrAvailabilityWindowNotFound
+	}
+	return deleted, nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *UserUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
@@ -7,6 +7,7 @@ import (
 	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
 	mockRepo "pr-reviewer/internal/usecase/User/mocks"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -43,9 +44,22 @@ func TestUserUsecase_SetUserIsActive(t *testing.T) {
 		assert.Equal(t, domain.ErrUserNotFound, err)
 	})
 
+	t.Run("get open reviews error", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, set.ID).Return(true, nil)
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{set.ID}).Return(nil, fmt.Errorf("db error"))
+
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("User usecase: get open reviews failed")
+
+		user, err := uc.SetUserIsActive(ctx, set)
+		assert.Nil(t, user)
+		assert.ErrorContains(t, err, "db error")
+	})
+
 	t.Run("update is_active error", func(t *testing.T) {
 		repo.EXPECT().ExistsById(ctx, set.ID).Return(true, nil)
-		repo.EXPECT().UpdateIsActive(ctx, set).Return(nil, fmt.Errorf("update failed"))
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{set.ID}).Return(nil, nil)
+		repo.EXPECT().UpdateIsActive(ctx, set, gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("update failed"))
 
 		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
 		logger.EXPECT().Error("User usecase: update is_active failed")
@@ -58,7 +72,23 @@ func TestUserUsecase_SetUserIsActive(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		updated := &domain.User{ID: set.ID, IsActive: set.IsActive}
 		repo.EXPECT().ExistsById(ctx, set.ID).Return(true, nil)
-		repo.EXPECT().UpdateIsActive(ctx, set).Return(updated, nil)
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{set.ID}).Return([]domain.PullRequest{{ID: 5}, {ID: 7}}, nil)
+		repo.EXPECT().UpdateIsActive(ctx, set, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, set *domain.SetUserIsActive, events []domain.AssignmentEvent, hooks []domain.WebhookEvent,
+			) (*domain.User, error) {
+				assert.Len(t, events, 2)
+				assert.Equal(t, 7, events[1].PullRequestID)
+				assert.Equal(t, domain.ReasonActivated, events[1].Reason)
+				assert.Equal(t, set.ID, *events[1].NewReviewerID)
+				assert.Nil(t, events[1].OldReviewerID)
+
+				assert.Len(t, hooks, 1)
+				assert.Equal(t, domain.WebhookUserActivityChanged, hooks[0].Type)
+				assert.Equal(t, set.ID, hooks[0].UserID)
+				return updated, nil
+			},
+		)
 
 		user, err := uc.SetUserIsActive(ctx, set)
 		assert.NoError(t, err)
@@ -77,50 +107,341 @@ func TestUserUsecase_GetUserPullRequests(t *testing.T) {
 
 	ctx := context.Background()
 	userID := 123
+	filter := &domain.ReviewFilter{UserID: userID, Limit: domain.DefaultPageLimit}
+
+	t.Run("user token reads other user reviews", func(t *testing.T) {
+		ownerID := userID + 1
+		actorCtx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleUser, UserID: &ownerID})
+
+		page, err := uc.GetUserPullRequests(actorCtx, filter)
+		assert.Nil(t, page)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
 
 	t.Run("user not found", func(t *testing.T) {
 		repo.EXPECT().ExistsById(ctx, userID).Return(false, nil)
 
-		prs, err := uc.GetUserPullRequests(ctx, userID)
-		assert.Nil(t, prs)
+		page, err := uc.GetUserPullRequests(ctx, filter)
+		assert.Nil(t, page)
 		assert.Equal(t, domain.ErrUserNotFound, err)
 	})
 
+	t.Run("team not found", func(t *testing.T) {
+		teamName := "ghost"
+
+		repo.EXPECT().ExistsById(ctx, userID).Return(true, nil)
+		repo.EXPECT().ExistsTeamByName(ctx, teamName).Return(false, nil)
+
+		page, err := uc.GetUserPullRequests(ctx, &domain.ReviewFilter{UserID: userID, TeamName: &teamName, Limit: 10})
+		assert.Nil(t, page)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
 	t.Run("repo GetUserPullRequests error", func(t *testing.T) {
 		repo.EXPECT().ExistsById(ctx, userID).Return(true, nil)
-		repo.EXPECT().GetUserPullRequests(ctx, userID).Return(nil, fmt.Errorf("query failed"))
+		repo.EXPECT().GetUserPullRequests(ctx, filter).Return(nil, fmt.Errorf("query failed"))
 		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
 		logger.EXPECT().Error("User usecase: get user prs failed")
 
-		prs, err := uc.GetUserPullRequests(ctx, userID)
-		assert.Nil(t, prs)
+		page, err := uc.GetUserPullRequests(ctx, filter)
+		assert.Nil(t, page)
 		assert.ErrorContains(t, err, "query failed")
 	})
 
 	t.Run("ok", func(t *testing.T) {
-		userPRs := []domain.PullRequest{
-			{ID: 1, Name: "Fix bug"},
-			{ID: 2, Name: "Add feature"},
+		want := &domain.ReviewPage{
+			PullRequests: []domain.PullRequest{
+				{ID: 1, Name: "Fix bug"},
+				{ID: 2, Name: "Add feature"},
+			},
+			NextCursor: &domain.PageCursor{PullRequestID: 2},
 		}
 
 		repo.EXPECT().ExistsById(ctx, userID).Return(true, nil)
-		repo.EXPECT().GetUserPullRequests(ctx, userID).Return(userPRs, nil)
+		repo.EXPECT().GetUserPullRequests(ctx, filter).Return(want, nil)
 
-		prs, err := uc.GetUserPullRequests(ctx, userID)
+		page, err := uc.GetUserPullRequests(ctx, filter)
 		assert.NoError(t, err)
-		assert.Equal(t, userPRs, prs)
-		assert.Len(t, prs, 2)
+		assert.Equal(t, want, page)
 	})
 
 	t.Run("ok, but pull_requests are empty", func(t *testing.T) {
-		userPRs := []domain.PullRequest{}
+		want := &domain.ReviewPage{PullRequests: []domain.PullRequest{}}
 
 		repo.EXPECT().ExistsById(ctx, userID).Return(true, nil)
-		repo.EXPECT().GetUserPullRequests(ctx, userID).Return(userPRs, nil)
+		repo.EXPECT().GetUserPullRequests(ctx, filter).Return(want, nil)
+
+		page, err := uc.GetUserPullRequests(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, page.PullRequests, 0)
+		assert.Nil(t, page.NextCursor)
+	})
+}
+
+func TestUserUsecase_BulkDeactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockUserRepo(ctrl)
+	picker := mockRepo.NewMockReviewerPicker(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &UserUsecase{repo: repo, picker: picker, logger: logger}
+
+	ctx := context.Background()
+
+	t.Run("team not found", func(t *testing.T) {
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(false, nil)
+
+		res, err := uc.BulkDeactivate(ctx, &domain.BulkDeactivate{TeamName: "backend"})
+		assert.Nil(t, res)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 1).Return(true, nil)
+		repo.EXPECT().ExistsById(ctx, 2).Return(false, nil)
+
+		res, err := uc.BulkDeactivate(ctx, &domain.BulkDeactivate{UserIDs: []int{1, 2}})
+		assert.Nil(t, res)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("reviews are reassigned and report is built", func(t *testing.T) {
+		prs := []domain.PullRequest{
+			{ID: 10, AuthorID: 5, AssignedReviewers: []int{1, 2}},
+			{ID: 11, AuthorID: 6, AssignedReviewers: []int{2, 7}},
+		}
+		replacements := []domain.ReviewReplacement{
+			{PullRequestID: 10, OldReviewerID: 1, NewReviewerID: 8},
+			{PullRequestID: 10, OldReviewerID: 2, NewReviewerID: 9},
+		}
+
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetTeamUserIDs(ctx, "backend").Return([]int{1, 2}, nil)
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{1, 2}).Return(prs, nil)
+		gomock.InOrder(
+			picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{1, 2}, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(
+					ctx context.Context, pr *domain.PullRequest, exclude []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+				) (int, error) {
+					load.Add(8)
+					return 8, nil
+				},
+			),
+			picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{1, 2}, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(
+					ctx context.Context, pr *domain.PullRequest, exclude []int, moves *domain.RoundRobinMoves, load domain.BatchLoad,
+				) (int, error) {
+					// Замена первого ревьювера уже учтена в PR и в нагрузке операции
+					assert.Equal(t, []int{8, 2}, pr.AssignedReviewers)
+					assert.Equal(t, domain.BatchLoad{8: 1}, load)
+					return 9, nil
+				},
+			),
+			picker.EXPECT().PickReplacement(ctx, gomock.Any(), []int{1, 2}, gomock.Any(), gomock.Any()).Return(0, domain.ErrNoAvailableCandidats),
+		)
+		repo.EXPECT().DeactivateAndReassign(ctx, []int{1, 2}, replacements, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context, userIDs []int, reps []domain.ReviewReplacement, events []domain.AssignmentEvent,
+				hooks []domain.WebhookEvent, moves domain.RoundRobinMoves,
+			) error {
+				assert.Len(t, events, len(reps))
+				for i, e := range events {
+					assert.Equal(t, domain.ReasonDeactivated, e.Reason)
+					assert.Nil(t, e.ActorID)
+					assert.Equal(t, reps[i].OldReviewerID, *e.OldReviewerID)
+					assert.Equal(t, reps[i].NewReviewerID, *e.NewReviewerID)
+				}
+
+				// На каждого деактивированного смена активности, на каждую замену - reassigned
+				types := make([]domain.WebhookEventType, 0, len(hooks))
+				for _, h := range hooks {
+					types = append(types, h.Type)
+				}
+				assert.Equal(t, []domain.WebhookEventType{
+					domain.WebhookUserActivityChanged, domain.WebhookUserActivityChanged,
+					domain.WebhookPRReassigned, domain.WebhookPRReassigned,
+				}, types)
+				assert.Equal(t, []int{1, 2, 5, 5}, []int{hooks[0].UserID, hooks[1].UserID, hooks[2].UserID, hooks[3].UserID})
+				return nil
+			},
+		)
+
+		res, err := uc.BulkDeactivate(ctx, &domain.BulkDeactivate{TeamName: "backend"})
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.BulkDeactivateResult{
+			Deactivated:      []int{1, 2},
+			Reassigned:       replacements,
+			WithoutCandidate: []domain.StuckReview{{PullRequestID: 11, ReviewerID: 2}},
+		}, res)
+	})
+
+	t.Run("duplicate ids are deactivated once", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 3).Return(true, nil)
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{3}).Return([]domain.PullRequest{}, nil)
+		repo.EXPECT().DeactivateAndReassign(ctx, []int{3}, []domain.ReviewReplacement{}, []domain.AssignmentEvent{}, gomock.Len(1), domain.RoundRobinMoves(nil)).
+			Return(nil)
+
+		res, err := uc.BulkDeactivate(ctx, &domain.BulkDeactivate{UserIDs: []int{3, 3}})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3}, res.Deactivated)
+	})
+
+	t.Run("transaction error", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 3).Return(true, nil)
+		repo.EXPECT().GetOpenReviewsByReviewers(ctx, []int{3}).Return([]domain.PullRequest{}, nil)
+		repo.EXPECT().DeactivateAndReassign(ctx, []int{3}, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("tx failed"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("User usecase: bulk deactivate failed")
+
+		res, err := uc.BulkDeactivate(ctx, &domain.BulkDeactivate{UserIDs: []int{3}})
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "tx failed")
+	})
+}
+
+func TestUserUsecase_AddAvailabilityWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockUserRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &UserUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	newWindow := func() *domain.AvailabilityWindow {
+		from := time.Now().UTC().Add(time.Hour)
+		return &domain.AvailabilityWindow{UserID: 2, From: from, To: from.Add(24 * time.Hour)}
+	}
+
+	t.Run("user token for another user", func(t *testing.T) {
+		ownerID := 3
+		actorCtx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleUser, UserID: &ownerID})
+
+		window, err := uc.AddAvailabilityWindow(actorCtx, newWindow())
+		assert.Nil(t, window)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("window already ended", func(t *testing.T) {
+		w := newWindow()
+		w.From = time.Now().UTC().Add(-2 * time.Hour)
+		w.To = time.Now().UTC().Add(-time.Hour)
+
+		window, err := uc.AddAvailabilityWindow(ctx, w)
+		assert.Nil(t, window)
+		assert.Equal(t, domain.ErrInvalidAvailability, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 2).Return(false, nil)
+
+		window, err := uc.AddAvailabilityWindow(ctx, newWindow())
+		assert.Nil(t, window)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 2).Return(true, nil)
+		repo.EXPECT().CreateAvailabilityWindow(ctx, gomock.Any()).Return(nil, fmt.Errorf("db error"))
+
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("User usecase: create availability window failed")
+
+		window, err := uc.AddAvailabilityWindow(ctx, newWindow())
+		assert.Nil(t, window)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("ok own window", func(t *testing.T) {
+		ownerID := 2
+		actorCtx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleUser, UserID: &ownerID})
+		w := newWindow()
+
+		repo.EXPECT().ExistsById(actorCtx, 2).Return(true, nil)
+		repo.EXPECT().CreateAvailabilityWindow(actorCtx, w).DoAndReturn(
+			func(ctx context.Context, w *domain.AvailabilityWindow) (*domain.AvailabilityWindow, error) {
+				assert.False(t, w.CreatedAt.IsZero())
+				created := *w
+				created.ID = 7
+				return &created, nil
+			},
+		)
+
+		window, err := uc.AddAvailabilityWindow(actorCtx, w)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, window.ID)
+	})
+}
+
+func TestUserUsecase_GetAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockUserRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &UserUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().ExistsById(ctx, 2).Return(false, nil)
+
+		windows, err := uc.GetAvailability(ctx, 2)
+		assert.Nil(t, windows)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		expected := []domain.AvailabilityWindow{{ID: 1, UserID: 2}}
+		repo.EXPECT().ExistsById(ctx, 2).Return(true, nil)
+		repo.EXPECT().GetAvailabilityWindows(ctx, 2, gomock.Any()).Return(expected, nil)
+
+		windows, err := uc.GetAvailability(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, windows)
+	})
+}
+
+func TestUserUsecase_DeleteAvailabilityWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockUserRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := &UserUsecase{repo: repo, logger: logger}
+
+	ctx := context.Background()
+	window := &domain.AvailabilityWindow{ID: 5, UserID: 2}
+
+	t.Run("window not found", func(t *testing.T) {
+		repo.EXPECT().GetAvailabilityWindow(ctx, 5).Return(nil, nil)
+
+		deleted, err := uc.DeleteAvailabilityWindow(ctx, 5)
+		assert.Nil(t, deleted)
+		assert.Equal(t, domain.ErrAvailabilityWindowNotFound, err)
+	})
+
+	t.Run("user token for another user", func(t *testing.T) {
+		ownerID := 3
+		actorCtx := domain.WithActor(ctx, &domain.Actor{Role: domain.RoleUser, UserID: &ownerID})
+		repo.EXPECT().GetAvailabilityWindow(actorCtx, 5).Return(window, nil)
+
+		deleted, err := uc.DeleteAvailabilityWindow(actorCtx, 5)
+		assert.Nil(t, deleted)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		repo.EXPECT().GetAvailabilityWindow(ctx, 5).Return(window, nil)
+		repo.EXPECT().DeleteAvailabilityWindow(ctx, 5).Return(window, nil)
 
-		prs, err := uc.GetUserPullRequests(ctx, userID)
+		deleted, err := uc.DeleteAvailabilityWindow(ctx, 5)
 		assert.NoError(t, err)
-		assert.Equal(t, userPRs, prs)
-		assert.Len(t, prs, 0)
+		assert.Equal(t, window, deleted)
 	})
 }
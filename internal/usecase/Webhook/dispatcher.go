@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"strconv"
+	"time"
+)
+
+// Заголовки запроса к подписчику
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderID        = "X-Webhook-ID"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Параметры Dispatcher по умолчанию
+const (
+	DefaultBatchSize    = 50
+	DefaultPollInterval = 2 * time.Second
+	DefaultLease        = time.Minute
+	DefaultTimeout      = 10 * time.Second
+)
+
+// Dispatcher доставляет события из outbox подписчикам
+type Dispatcher struct {
+	repo   OutboxRepo
+	client *http.Client
+	logger logger.Logger
+
+	batchSize int
+	interval  time.Duration
+	// lease на сколько откладывается взятое событие, должно превышать таймаут запроса
+	lease time.Duration
+	now   func() time.Time
+}
+
+func NewDispatcher(repo OutboxRepo, logger logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:      repo,
+		client:    &http.Client{Timeout: DefaultTimeout},
+		logger:    logger,
+		batchSize: DefaultBatchSize,
+		interval:  DefaultPollInterval,
+		lease:     DefaultLease,
+		now:       time.Now,
+	}
+}
+
+// Run опрашивает outbox до отмены ctx
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		// Пока outbox отдает полные пачки, забираем без ожидания
+		for {
+			n, err := d.DispatchBatch(ctx)
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch отправляет одну пачку готовых событий, возвращает ее размер
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	now := d.now()
+	msgs, err := d.repo.ClaimDue(ctx, d.batchSize, now, now.Add(d.lease))
+	if err != nil {
+		d.logger.WithFields(logger.LoggerFields{"err": err.Error()}).Error("Webhook dispatcher: claim outbox failed")
+		return 0, fmt.Errorf("failed to claim outbox: %w", err)
+	}
+
+	for i := range msgs {
+		d.handle(ctx, &msgs[i])
+	}
+	return len(msgs), nil
+}
+
+// handle отправляет событие и сохраняет результат попытки
+func (d *Dispatcher) handle(ctx context.Context, msg *domain.OutboxMessage) {
+	sendErr := d.send(ctx, msg)
+	now := d.now()
+
+	var err error
+	attempts := msg.Attempts + 1
+	switch {
+	case sendErr == nil:
+		err = d.repo.MarkDelivered(ctx, msg.ID, now)
+	case attempts >= domain.WebhookMaxAttempts:
+		d.logger.WithFields(logger.LoggerFields{"err": sendErr.Error(), "outboxID": msg.ID, "attempts": attempts}).
+			Warn("Webhook dispatcher: delivery attempts exhausted")
+		err = d.repo.MarkFailed(ctx, msg.ID, attempts, now, sendErr.Error())
+	default:
+		err = d.repo.ScheduleRetry(ctx, msg.ID, attempts, now.Add(domain.WebhookBackoff(attempts)), sendErr.Error())
+	}
+
+	if err != nil {
+		d.logger.WithFields(logger.LoggerFields{"err": err.Error(), "outboxID": msg.ID}).Error("Webhook dispatcher: save attempt failed")
+	}
+}
+
+// send делает POST подписчику, успех - любой 2xx ответ
+func (d *Dispatcher) send(ctx context.Context, msg *domain.OutboxMessage) error {
+	id := strconv.FormatInt(msg.ID, 10)
+	body, err := json.Marshal(domain.WebhookEnvelope{
+		ID:        id,
+		Event:     msg.EventType,
+		CreatedAt: msg.CreatedAt,
+		Data:      json.RawMessage(msg.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(msg.EventType))
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderSignature, domain.SignWebhook(msg.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
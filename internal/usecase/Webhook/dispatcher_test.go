@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Webhook/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockOutboxRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	d := NewDispatcher(repo, logger)
+	d.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	secret := "0123456789abcdef"
+
+	newMessage := func(url string, attempts int) domain.OutboxMessage {
+		return domain.OutboxMessage{
+			ID:        7,
+			URL:       url,
+			Secret:    secret,
+			EventType: domain.WebhookPRMerged,
+			Payload:   []byte(`{"pull_request_id":"pr-1"}`),
+			Attempts:  attempts,
+			CreatedAt: now.Add(-time.Minute),
+		}
+	}
+
+	t.Run("delivered with signature", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, domain.SignWebhook(secret, body), r.Header.Get(HeaderSignature))
+			assert.Equal(t, "pull_request.merged", r.Header.Get(HeaderEvent))
+			assert.Equal(t, "7", r.Header.Get(HeaderID))
+
+			var envelope map[string]any
+			require.NoError(t, json.Unmarshal(body, &envelope))
+			assert.Equal(t, "pull_request.merged", envelope["event"])
+			assert.Equal(t, map[string]any{"pull_request_id": "pr-1"}, envelope["data"])
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		repo.EXPECT().ClaimDue(ctx, DefaultBatchSize, now, now.Add(DefaultLease)).
+			Return([]domain.OutboxMessage{newMessage(srv.URL, 0)}, nil)
+		repo.EXPECT().MarkDelivered(ctx, int64(7), now).Return(nil)
+
+		n, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("retry with backoff on error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		repo.EXPECT().ClaimDue(ctx, DefaultBatchSize, now, now.Add(DefaultLease)).
+			Return([]domain.OutboxMessage{newMessage(srv.URL, 2)}, nil)
+		repo.EXPECT().ScheduleRetry(ctx, int64(7), 3, now.Add(domain.WebhookBackoff(3)), "webhook responded with status 502").
+			Return(nil)
+
+		_, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed after max attempts", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		repo.EXPECT().ClaimDue(ctx, DefaultBatchSize, now, now.Add(DefaultLease)).
+			Return([]domain.OutboxMessage{newMessage(srv.URL, domain.WebhookMaxAttempts-1)}, nil)
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Warn("Webhook dispatcher: delivery attempts exhausted")
+		repo.EXPECT().MarkFailed(ctx, int64(7), domain.WebhookMaxAttempts, now, "webhook responded with status 500").
+			Return(nil)
+
+		_, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		repo.EXPECT().ClaimDue(ctx, DefaultBatchSize, now, now.Add(DefaultLease)).Return(nil, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Webhook dispatcher: claim outbox failed")
+
+		n, err := d.DispatchBatch(ctx)
+		assert.Equal(t, 0, n)
+		assert.ErrorContains(t, err, "db error")
+	})
+}
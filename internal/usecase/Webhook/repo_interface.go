@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"context"
+	"pr-reviewer/internal/domain"
+	"time"
+)
+
+//go:generate mockgen -source repo_interface.go -destination=mocks/mock_webhook_repo.go -package=mocks
+
+type WebhookRepo interface {
+	ExistsTeamByName(ctx context.Context, name string) (bool, error)
+	Create(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetByTeam(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error)
+	Delete(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+}
+
+type OutboxRepo interface {
+	ClaimDue(ctx context.Context, limit int, now time.Time, leaseUntil time.Time) ([]domain.OutboxMessage, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	ScheduleRetry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastErr string) error
+	MarkFailed(ctx context.Context, id int64, attempts int, at time.Time, lastErr string) error
+}
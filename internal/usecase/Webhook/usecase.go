@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/pkg/logger"
+	"time"
+)
+
+type WebhookUsecase struct {
+	repo   WebhookRepo
+	logger logger.Logger
+}
+
+func NewWebhookUsecase(repo WebhookRepo, logger logger.Logger) *WebhookUsecase {
+	return &WebhookUsecase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateWebhook подписывает команду на события
+func (uc *WebhookUsecase) CreateWebhook(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if err := uc.checkTeamExists(ctx, sub.TeamName); err != nil {
+		return nil, err
+	}
+
+	sub.CreatedAt = time.Now()
+	created, err := uc.repo.Create(ctx, sub)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": sub.TeamName}).Error("Webhook usecase: create webhook failed")
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return created, nil
+}
+
+// GetTeamWebhooks возвращает подписки команды
+func (uc *WebhookUsecase) GetTeamWebhooks(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error) {
+	if err := uc.checkTeamExists(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	subs, err := uc.repo.GetByTeam(ctx, teamName)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": teamName}).Error("Webhook usecase: get webhooks failed")
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return subs, nil
+}
+
+// DeleteWebhook удаляет подписку вместе с недоставленными событиями
+func (uc *WebhookUsecase) DeleteWebhook(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	deleted, err := uc.repo.Delete(ctx, id)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "webhookID": id}).Error("Webhook usecase: delete webhook failed")
+		return nil, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if deleted == nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return deleted, nil
+}
+
+func (uc *WebhookUsecase) checkTeamExists(ctx context.Context, teamName string) error {
+	exists, err := uc.repo.ExistsTeamByName(ctx, teamName)
+	if err != nil {
+		uc.log(ctx).WithFields(logger.LoggerFields{"err": err.Error(), "team_name": teamName}).Error("Webhook usecase: check team_name failed")
+		return fmt.Errorf("failed to check team existance: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+// log возвращает логгер запроса из ctx, а вне запроса - логгер usecase
+func (uc *WebhookUsecase) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
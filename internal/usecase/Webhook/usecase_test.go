@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pr-reviewer/internal/domain"
+	mocksLogger "pr-reviewer/internal/pkg/logger/mocks"
+	mockRepo "pr-reviewer/internal/usecase/Webhook/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockWebhookRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewWebhookUsecase(repo, logger)
+
+	ctx := context.Background()
+	sub := &domain.WebhookSubscription{
+		TeamName: "backend",
+		URL:      "https://bots.example.com/hooks",
+		Secret:   "0123456789abcdef",
+		Events:   []domain.WebhookEventType{domain.WebhookPRCreated},
+	}
+
+	t.Run("team not found", func(t *testing.T) {
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(false, nil)
+
+		created, err := uc.CreateWebhook(ctx, sub)
+		assert.Nil(t, created)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("repo Create error", func(t *testing.T) {
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().Create(ctx, sub).Return(nil, fmt.Errorf("insert failed"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Webhook usecase: create webhook failed")
+
+		created, err := uc.CreateWebhook(ctx, sub)
+		assert.Nil(t, created)
+		assert.ErrorContains(t, err, "insert failed")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().Create(ctx, sub).DoAndReturn(
+			func(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+				assert.False(t, sub.CreatedAt.IsZero())
+				sub.ID = 1
+				return sub, nil
+			},
+		)
+
+		created, err := uc.CreateWebhook(ctx, sub)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, created.ID)
+	})
+}
+
+func TestWebhookUsecase_GetTeamWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockWebhookRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewWebhookUsecase(repo, logger)
+
+	ctx := context.Background()
+
+	t.Run("check team error", func(t *testing.T) {
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(false, fmt.Errorf("db error"))
+		logger.EXPECT().WithFields(gomock.Any()).Return(logger)
+		logger.EXPECT().Error("Webhook usecase: check team_name failed")
+
+		subs, err := uc.GetTeamWebhooks(ctx, "backend")
+		assert.Nil(t, subs)
+		assert.ErrorContains(t, err, "db error")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		subs := []domain.WebhookSubscription{{ID: 1, TeamName: "backend"}}
+		repo.EXPECT().ExistsTeamByName(ctx, "backend").Return(true, nil)
+		repo.EXPECT().GetByTeam(ctx, "backend").Return(subs, nil)
+
+		got, err := uc.GetTeamWebhooks(ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, subs, got)
+	})
+}
+
+func TestWebhookUsecase_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockRepo.NewMockWebhookRepo(ctrl)
+	logger := mocksLogger.NewMockLogger(ctrl)
+
+	uc := NewWebhookUsecase(repo, logger)
+
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		repo.EXPECT().Delete(ctx, 5).Return(nil, nil)
+
+		deleted, err := uc.DeleteWebhook(ctx, 5)
+		assert.Nil(t, deleted)
+		assert.Equal(t, domain.ErrWebhookNotFound, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		sub := &domain.WebhookSubscription{ID: 5, TeamName: "backend"}
+		repo.EXPECT().Delete(ctx, 5).Return(sub, nil)
+
+		deleted, err := uc.DeleteWebhook(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, sub, deleted)
+	})
+}
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN IF EXISTS review_weight;
+
+ALTER TABLE team
+    DROP COLUMN IF EXISTS last_reviewer_id,
+    DROP COLUMN IF EXISTS reviewer_strategy;
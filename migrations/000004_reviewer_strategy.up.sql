@@ -0,0 +1,9 @@
+-- Стратегия выбора ревьюверов и последний назначенный ревьювер (для ROUND_ROBIN)
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS reviewer_strategy TEXT NOT NULL DEFAULT 'RANDOM'
+        CHECK (reviewer_strategy IN ('RANDOM', 'LEAST_LOADED', 'ROUND_ROBIN', 'WEIGHTED')),
+    ADD COLUMN IF NOT EXISTS last_reviewer_id INTEGER NULL;
+
+-- Вес пользователя для стратегии WEIGHTED
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS review_weight INTEGER NOT NULL DEFAULT 1 CHECK (review_weight > 0);
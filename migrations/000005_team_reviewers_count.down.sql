@@ -0,0 +1,4 @@
+ALTER TABLE team
+    DROP CONSTRAINT IF EXISTS team_reviewers_count_check,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
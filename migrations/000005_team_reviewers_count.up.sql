@@ -0,0 +1,6 @@
+-- Количество ревьюверов на PullRequest для команды
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS min_reviewers INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2,
+    ADD CONSTRAINT team_reviewers_count_check
+        CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
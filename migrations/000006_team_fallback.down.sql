@@ -0,0 +1,3 @@
+ALTER TABLE assigned_pr DROP COLUMN IF EXISTS from_fallback;
+
+DROP TABLE IF EXISTS team_fallback;
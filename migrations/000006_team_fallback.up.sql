@@ -0,0 +1,13 @@
+-- Резервные команды, из которых добираются ревьюверы
+CREATE TABLE IF NOT EXISTS team_fallback (
+    team_id INTEGER NOT NULL REFERENCES team(id) ON DELETE CASCADE,
+    fallback_team_id INTEGER NOT NULL REFERENCES team(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (team_id, fallback_team_id),
+    UNIQUE (team_id, position),
+    CHECK (team_id <> fallback_team_id)
+);
+
+-- Ревьювер назначен из резервной команды
+ALTER TABLE assigned_pr
+    ADD COLUMN IF NOT EXISTS from_fallback BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+ALTER TABLE pull_request DROP COLUMN IF EXISTS closed_at;
+
+UPDATE pull_request SET status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+WHERE status_id IN (SELECT id FROM pr_status WHERE name IN ('DRAFT', 'CLOSED'));
+
+DELETE FROM pr_status
+WHERE name IN ('DRAFT', 'CLOSED');
@@ -0,0 +1,8 @@
+-- Черновики и закрытые без слияния PullRequest
+INSERT INTO pr_status (name) VALUES
+  ('DRAFT'),
+  ('CLOSED')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE pull_request
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_review_verdict_pr_reviewer;
+
+DROP TABLE IF EXISTS review_verdict;
@@ -0,0 +1,13 @@
+-- Вердикты ревьюверов, история сохраняется полностью
+CREATE TABLE IF NOT EXISTS review_verdict (
+    id SERIAL PRIMARY KEY,
+    pr_id INTEGER NOT NULL REFERENCES pull_request(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    verdict TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT review_verdict_check
+        CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_verdict_pr_reviewer
+    ON review_verdict (pr_id, reviewer_id, created_at DESC);
@@ -0,0 +1,4 @@
+ALTER TABLE team
+    DROP CONSTRAINT IF EXISTS team_required_approvals_check,
+    DROP COLUMN IF EXISTS block_on_changes_requested,
+    DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,6 @@
+-- Политика слияния PullRequest для команды
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT team_required_approvals_check
+        CHECK (required_approvals >= 0);
@@ -0,0 +1,2 @@
+-- Время слияния для статистики; переназначения считаются по журналу назначений
+CREATE INDEX IF NOT EXISTS idx_pull_request_merged_at ON pull_request(merged_at);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS assignment_event_no_update ON assignment_event;
+
+DROP FUNCTION IF EXISTS assignment_event_forbid_update();
+
+DROP INDEX IF EXISTS idx_assignment_event_pr;
+
+DROP TABLE IF EXISTS assignment_event;
@@ -0,0 +1,31 @@
+-- Журнал назначений ревьюверов, только добавление записей
+CREATE TABLE IF NOT EXISTS assignment_event (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id INTEGER NOT NULL REFERENCES pull_request(id) ON DELETE CASCADE,
+    actor_id INTEGER NULL,
+    old_reviewer_id INTEGER NULL,
+    new_reviewer_id INTEGER NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT assignment_event_reason_check
+        CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_event_pr ON assignment_event (pr_id, id);
+
+CREATE OR REPLACE FUNCTION assignment_event_forbid_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'assignment_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER assignment_event_no_update
+    BEFORE UPDATE ON assignment_event
+    FOR EACH ROW EXECUTE FUNCTION assignment_event_forbid_update();
+
+-- Текущие назначения переносятся в журнал как ASSIGNED автором PR
+INSERT INTO assignment_event (pr_id, actor_id, new_reviewer_id, reason, created_at)
+SELECT a.pr_id, pr.author_id, a.reviewer_id, 'ASSIGNED', pr.created_at
+FROM assigned_pr a
+JOIN pull_request pr ON pr.id = a.pr_id
+ORDER BY pr.created_at, a.pr_id, a.reviewer_id;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_webhook_outbox_pending;
+
+DROP TABLE IF EXISTS webhook_outbox;
+
+DROP INDEX IF EXISTS idx_webhook_subscription_team;
+
+DROP TABLE IF EXISTS webhook_subscription;
@@ -0,0 +1,9 @@
+-- Связь PullRequest во внешних сервисах (GitHub, GitLab) с PullRequest сервиса
+CREATE TABLE IF NOT EXISTS external_pull_request (
+    provider TEXT NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    repository TEXT NOT NULL,
+    number INTEGER NOT NULL,
+    pr_id INTEGER NOT NULL UNIQUE REFERENCES pull_request(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, repository, number)
+);
@@ -0,0 +1,6 @@
+DELETE FROM assignment_event WHERE reason = 'TEAM_CHANGED';
+
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED'));
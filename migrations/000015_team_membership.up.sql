@@ -0,0 +1,5 @@
+-- Переназначения ревьюверов при уходе участника из команды
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED', 'TEAM_CHANGED'));
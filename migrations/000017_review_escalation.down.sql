@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS review_escalation;
+
+DELETE FROM assignment_event WHERE reason = 'ESCALATED';
+
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED', 'TEAM_CHANGED'));
+
+ALTER TABLE team
+    DROP CONSTRAINT IF EXISTS team_escalation_policy_check,
+    DROP CONSTRAINT IF EXISTS team_review_sla_check,
+    DROP COLUMN IF EXISTS escalation_policy,
+    DROP COLUMN IF EXISTS review_sla_minutes;
@@ -0,0 +1,32 @@
+-- SLA на ревью и политика эскалации просроченных ревью для команды
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS review_sla_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS escalation_policy TEXT NOT NULL DEFAULT 'NOTIFY',
+    ADD CONSTRAINT team_review_sla_check
+        CHECK (review_sla_minutes >= 0),
+    ADD CONSTRAINT team_escalation_policy_check
+        CHECK (escalation_policy IN ('NOTIFY', 'ADD_REVIEWER', 'REASSIGN'));
+
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED', 'TEAM_CHANGED', 'ESCALATED'));
+
+-- Эскалации просроченных ревью. Запись захватывается до действия, поэтому одно
+-- назначение (pr_id, reviewer_id, stale_since) эскалируется один раз;
+-- action NULL, пока действие выполняется
+CREATE TABLE IF NOT EXISTS review_escalation (
+    id SERIAL PRIMARY KEY,
+    pr_id INTEGER NOT NULL REFERENCES pull_request(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    policy TEXT NOT NULL,
+    action TEXT NULL,
+    new_reviewer_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    stale_since TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT review_escalation_policy_check
+        CHECK (policy IN ('NOTIFY', 'ADD_REVIEWER', 'REASSIGN')),
+    CONSTRAINT review_escalation_action_check
+        CHECK (action IN ('NOTIFY', 'ADD_REVIEWER', 'REASSIGN')),
+    CONSTRAINT review_escalation_assignment_key UNIQUE (pr_id, reviewer_id, stale_since)
+);
@@ -0,0 +1,13 @@
+-- Окна недоступности пользователей: отпуск, больничный и т.п.
+-- Пока окно идет, пользователь не назначается ревьювером
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_unavailability_window_check CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user ON user_unavailability (user_id, ends_at);
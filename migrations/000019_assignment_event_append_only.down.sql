@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS assignment_event_no_truncate ON assignment_event;
+
+DROP TRIGGER IF EXISTS assignment_event_no_delete ON assignment_event;
+
+DELETE FROM assignment_event WHERE reason = 'ACTIVATED';
+
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN ('ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'MERGED', 'CLOSED', 'REOPENED', 'TEAM_CHANGED', 'ESCALATED'));
+
+ALTER TABLE assignment_event
+    DROP CONSTRAINT IF EXISTS assignment_event_pr_id_fkey,
+    ADD CONSTRAINT assignment_event_pr_id_fkey
+        FOREIGN KEY (pr_id) REFERENCES pull_request(id) ON DELETE CASCADE;
@@ -0,0 +1,23 @@
+-- Журнал назначений только дополняется: кроме изменения запрещено удаление записей,
+-- а PullRequest с записями в журнале удалить нельзя
+CREATE TRIGGER assignment_event_no_delete
+    BEFORE DELETE ON assignment_event
+    FOR EACH ROW EXECUTE FUNCTION assignment_event_forbid_update();
+
+CREATE TRIGGER assignment_event_no_truncate
+    BEFORE TRUNCATE ON assignment_event
+    FOR EACH STATEMENT EXECUTE FUNCTION assignment_event_forbid_update();
+
+ALTER TABLE assignment_event
+    DROP CONSTRAINT IF EXISTS assignment_event_pr_id_fkey,
+    ADD CONSTRAINT assignment_event_pr_id_fkey
+        FOREIGN KEY (pr_id) REFERENCES pull_request(id) ON DELETE RESTRICT;
+
+-- Смена активности назначенного ревьювера без замены: ACTIVATED,
+-- а DEACTIVATED без нового ревьювера
+ALTER TABLE assignment_event DROP CONSTRAINT IF EXISTS assignment_event_reason_check;
+
+ALTER TABLE assignment_event ADD CONSTRAINT assignment_event_reason_check
+    CHECK (reason IN (
+        'ASSIGNED', 'REASSIGNED', 'DEACTIVATED', 'ACTIVATED', 'MERGED', 'CLOSED', 'REOPENED', 'TEAM_CHANGED', 'ESCALATED'
+    ));
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS pull_request_id_seq_follow ON pull_request;
+
+DROP FUNCTION IF EXISTS pull_request_id_seq_follow();
+
+DROP SEQUENCE IF EXISTS pull_request_id_seq;
@@ -0,0 +1,19 @@
+-- id PR, созданных из событий GitHub/GitLab, выдает последовательность.
+-- Клиенты API по-прежнему задают id сами, поэтому последовательность
+-- сдвигается за любой явно вставленный id
+CREATE SEQUENCE IF NOT EXISTS pull_request_id_seq OWNED BY pull_request.id;
+
+SELECT setval('pull_request_id_seq', COALESCE((SELECT MAX(id) FROM pull_request), 0) + 1, false);
+
+CREATE OR REPLACE FUNCTION pull_request_id_seq_follow() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.id >= (SELECT last_value FROM pull_request_id_seq) THEN
+        PERFORM setval('pull_request_id_seq', NEW.id + 1, false);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_request_id_seq_follow
+    BEFORE INSERT ON pull_request
+    FOR EACH ROW EXECUTE FUNCTION pull_request_id_seq_follow();
@@ -0,0 +1,56 @@
+// Package migrations встраивает SQL миграции, чтобы сборка знала ожидаемую версию схемы
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.up.sql
+var files embed.FS
+
+// LatestVersion номер последней up миграции вида 000018_name.up.sql
+func LatestVersion() (int, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	latest := 0
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("invalid migration name %q", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration name %q: %w", name, err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// Up тексты up миграций по возрастанию версии
+func Up() ([]string, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	// Номера версий дополнены нулями, поэтому порядок имен совпадает с порядком версий
+	slices.Sort(names)
+
+	scripts := make([]string, 0, len(names))
+	for _, name := range names {
+		data, err := files.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", name, err)
+		}
+		scripts = append(scripts, string(data))
+	}
+	return scripts, nil
+}
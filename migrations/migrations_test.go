@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestVersion(t *testing.T) {
+	version, err := LatestVersion()
+	assert.NoError(t, err)
+
+	// Каждая up миграция должна иметь парную down
+	ups, _ := filepath.Glob("*.up.sql")
+	assert.Equal(t, len(ups), version)
+	for _, up := range ups {
+		_, err := os.Stat(up[:len(up)-len(".up.sql")] + ".down.sql")
+		assert.NoError(t, err, up)
+	}
+
+	scripts, err := Up()
+	assert.NoError(t, err)
+	assert.Len(t, scripts, version)
+}